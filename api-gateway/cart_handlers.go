@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	pb "github.com/order-management/proto"
+)
+
+// Carts are served under /carts/:id for anonymous carts and /users/:id/cart for the
+// cart of a user, with the same handlers.
+
+// cartRef returns the cart a request is for: a user's cart under /users/:id/cart, an
+// anonymous cart under /carts/:id.
+func cartRef(c *gin.Context) (cartID, userID string) {
+	if strings.HasPrefix(c.FullPath(), "/users/") {
+		return "", c.Param("id")
+	}
+	return c.Param("id"), ""
+}
+
+func (g *APIGateway) createCart(c *gin.Context) {
+	cart, err := g.cartClient.CreateCart(orderContext(c), &pb.CreateCartRequest{})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusCreated, cart)
+}
+
+func (g *APIGateway) getCart(c *gin.Context) {
+	cartID, userID := cartRef(c)
+	cart, err := g.cartClient.GetCart(orderContext(c), &pb.GetCartRequest{CartId: cartID, UserId: userID})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, cart)
+}
+
+func (g *APIGateway) addCartItem(c *gin.Context) {
+	var req struct {
+		ProductID string `json:"product_id"`
+		Quantity  int32  `json:"quantity"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cartID, userID := cartRef(c)
+	cart, err := g.cartClient.AddCartItem(orderContext(c), &pb.AddCartItemRequest{
+		CartId:    cartID,
+		UserId:    userID,
+		ProductId: req.ProductID,
+		Quantity:  req.Quantity,
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, cart)
+}
+
+func (g *APIGateway) updateCartItem(c *gin.Context) {
+	var req struct {
+		Quantity int32 `json:"quantity"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cartID, userID := cartRef(c)
+	cart, err := g.cartClient.UpdateCartItem(orderContext(c), &pb.UpdateCartItemRequest{
+		CartId:    cartID,
+		UserId:    userID,
+		ProductId: c.Param("productId"),
+		Quantity:  req.Quantity,
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, cart)
+}
+
+func (g *APIGateway) removeCartItem(c *gin.Context) {
+	cartID, userID := cartRef(c)
+	cart, err := g.cartClient.RemoveCartItem(orderContext(c), &pb.RemoveCartItemRequest{
+		CartId:    cartID,
+		UserId:    userID,
+		ProductId: c.Param("productId"),
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, cart)
+}
+
+func (g *APIGateway) checkoutCart(c *gin.Context) {
+	var req pb.CheckoutCartRequest
+	if !readProto(c, &req) {
+		return
+	}
+	req.UserId = c.Param("id")
+
+	// Forward the client's idempotency key so a retried checkout returns the same order
+	ctx := orderContext(c)
+	if key := c.GetHeader("Idempotency-Key"); key != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "idempotency-key", key)
+	}
+
+	order, err := g.cartClient.CheckoutCart(ctx, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusCreated, order)
+}
+
+// mergeCart moves an anonymous cart into the cart of a user who just logged in and
+// returns the result as JSON, or nil if there was nothing to merge. A failed merge
+// doesn't fail the login, the anonymous cart is then left as it is.
+func (g *APIGateway) mergeCart(c *gin.Context, cartID string, auth *pb.AuthResponse) json.RawMessage {
+	ctx := metadata.AppendToOutgoingContext(c.Request.Context(), "authorization", "Bearer "+auth.Token)
+	cart, err := g.cartClient.MergeCarts(ctx, &pb.MergeCartsRequest{CartId: cartID, UserId: auth.User.GetId()})
+	if err != nil {
+		// Already merged, or expired
+		if status.Code(err) != codes.NotFound {
+			log.Printf("Failed to merge cart %s into the cart of user %s: %v", cartID, auth.User.GetId(), err)
+		}
+		return nil
+	}
+
+	body, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(cart)
+	if err != nil {
+		log.Printf("Failed to encode cart %s: %v", cart.Id, err)
+		return nil
+	}
+	return body
+}
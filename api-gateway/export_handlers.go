@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/encoding/protojson"
+	pb "github.com/order-management/proto"
+)
+
+// Exports are written as the orders arrive from ExportOrders, with chunked transfer
+// encoding, and flushed every exportFlushRows rows. Nothing is buffered beyond that,
+// so an export of any size takes the same memory.
+
+const exportFlushRows = 100
+
+// exportErrorTrailer is the trailer set when an export fails after it started. The
+// status code has been sent by then, so without it a cut-off export would look
+// complete.
+const exportErrorTrailer = "X-Export-Error"
+
+// orderCSVHeader lists the columns of CSV exports. There is one row per order item,
+// with the columns of the order repeated; amounts are in minor units of currency.
+var orderCSVHeader = []string{
+	"order_id", "user_id", "status", "created_at", "updated_at", "currency",
+	"subtotal_minor", "discount_minor", "tax_minor", "shipping_minor", "total_minor",
+	"product_id", "quantity", "unit_price_minor", "line_total_minor", "backordered_quantity",
+}
+
+// exportOrders streams the orders matching the filters and sort order of GET /orders
+// as CSV or, with ?format=ndjson, as one JSON order per line. Pagination parameters
+// are ignored.
+func (g *APIGateway) exportOrders(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "ndjson" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown export format: " + format})
+		return
+	}
+	req, ok := listOrdersRequest(c)
+	if !ok {
+		return
+	}
+
+	stream, err := g.orderClient.ExportOrders(orderContext(c), req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	// Wait for the first order, so a request the Order Service rejects still gets a
+	// proper error response
+	order, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="orders.`+format+`"`)
+	c.Header("Trailer", exportErrorTrailer)
+	var write func(order *pb.Order) error
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		w := csv.NewWriter(c.Writer)
+		defer w.Flush()
+		if err := w.Write(orderCSVHeader); err != nil {
+			return
+		}
+		write = func(order *pb.Order) error {
+			return w.WriteAll(orderCSVRows(order))
+		}
+	} else {
+		c.Header("Content-Type", "application/x-ndjson")
+		write = func(order *pb.Order) error {
+			line, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(order)
+			if err == nil {
+				_, err = c.Writer.Write(append(line, '\n'))
+			}
+			return err
+		}
+	}
+	c.Status(http.StatusOK)
+
+	for rows := 1; err == nil; rows++ {
+		if err = write(order); err != nil {
+			// The client went away
+			return
+		}
+		if rows%exportFlushRows == 0 {
+			c.Writer.Flush()
+		}
+		order, err = stream.Recv()
+	}
+	if err != io.EOF {
+		log.Printf("Order export failed: %v", err)
+		c.Writer.Header().Set(exportErrorTrailer, err.Error())
+	}
+}
+
+// orderCSVRows returns the CSV rows of an order, one per item.
+func orderCSVRows(order *pb.Order) [][]string {
+	var discount, tax int64
+	for _, d := range order.Discounts {
+		discount += d.Amount.GetUnitsMinor()
+	}
+	for _, line := range order.TaxLines {
+		tax += line.Amount.GetUnitsMinor()
+	}
+
+	orderStatus := strings.ToLower(strings.TrimPrefix(order.Status.String(), "ORDER_STATUS_"))
+	head := []string{
+		order.Id, order.UserId, orderStatus, order.CreatedAt, order.UpdatedAt,
+		order.TotalAmount.GetCurrency(),
+		strconv.FormatInt(order.Subtotal.GetUnitsMinor(), 10),
+		strconv.FormatInt(discount, 10),
+		strconv.FormatInt(tax, 10),
+		strconv.FormatInt(order.Shipping.GetCost().GetUnitsMinor(), 10),
+		strconv.FormatInt(order.TotalAmount.GetUnitsMinor(), 10),
+	}
+	if len(order.Items) == 0 {
+		return [][]string{append(head, "", "", "", "", "")}
+	}
+
+	rows := make([][]string, 0, len(order.Items))
+	for _, item := range order.Items {
+		unit := item.Price.GetUnitsMinor()
+		row := append(append([]string{}, head...),
+			item.ProductId,
+			strconv.Itoa(int(item.Quantity)),
+			strconv.FormatInt(unit, 10),
+			strconv.FormatInt(unit*int64(item.Quantity), 10),
+			strconv.Itoa(int(item.BackorderedQuantity)),
+		)
+		rows = append(rows, row)
+	}
+	return rows
+}
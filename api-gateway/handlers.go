@@ -91,24 +91,40 @@ func (g *APIGateway) updateStock(c *gin.Context) {
 
 	// Return a simplified response that includes the stock quantity
 	c.JSON(http.StatusOK, gin.H{
-		"id":             product.Id,
-		"name":           product.Name,
-		"stock_quantity": product.StockQuantity,
+		"id":                   product.Id,
+		"name":                 product.Name,
+		"stock_quantity":       product.StockQuantity,
+		"backordered_quantity": product.BackorderedQuantity,
 	})
 }
 
+func (g *APIGateway) listBackorders(c *gin.Context) {
+	resp, err := g.productClient.ListBackorders(c.Request.Context(), &pb.ListBackordersRequest{
+		ProductId:       c.Param("id"),
+		OutstandingOnly: c.Query("outstanding") == "true",
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 func (g *APIGateway) listProducts(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	page, _ := strconv.Atoi(c.Query("page"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	category := c.Query("category")
 
 	resp, err := g.productClient.ListProducts(c.Request.Context(), &pb.ListProductsRequest{
-		Page:     int32(page),
-		Limit:    int32(limit),
-		Category: category,
+		Page:         int32(page),
+		Limit:        int32(limit),
+		Category:     category,
+		PageToken:    c.Query("page_token"),
+		IncludeTotal: c.Query("include_total") == "true",
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/order-management/proto"
+)
+
+// importChunkSize is how much of an import file is sent to the Order Service per message.
+const importChunkSize = 256 << 10
+
+// importOrders streams the request body, a CSV or JSON file of historical orders, to
+// the Order Service and returns its report. The format comes from ?format=csv|json,
+// or else from the Content-Type; ?dry_run=true only validates the file.
+func (g *APIGateway) importOrders(c *gin.Context) {
+	format := c.Query("format")
+	if format == "" {
+		format = "csv"
+		if strings.Contains(c.ContentType(), "json") {
+			format = "json"
+		}
+	}
+	first := &pb.ImportOrdersChunk{DryRun: c.Query("dry_run") == "true"}
+	switch format {
+	case "csv":
+		first.Format = pb.ImportFormat_IMPORT_FORMAT_CSV
+	case "json":
+		first.Format = pb.ImportFormat_IMPORT_FORMAT_JSON
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown import format: " + format})
+		return
+	}
+
+	stream, err := g.orderClient.ImportOrders(orderContext(c))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	chunk := first
+	for {
+		// A sent message must not be modified, so every chunk gets its own buffer
+		buf := make([]byte, importChunkSize)
+		n, readErr := io.ReadFull(c.Request.Body, buf)
+		if n > 0 || chunk == first {
+			chunk.Data = buf[:n]
+			if err := stream.Send(chunk); err != nil {
+				// The Order Service ended the import; its error comes with CloseAndRecv
+				break
+			}
+			chunk = &pb.ImportOrdersChunk{}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read import file: " + readErr.Error()})
+			return
+		}
+	}
+
+	response, err := stream.CloseAndRecv()
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, response)
+}
@@ -1,20 +1,34 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	pb "github.com/order-management/proto"
 )
 
 type APIGateway struct {
-	orderClient   pb.OrderServiceClient
-	productClient pb.ProductServiceClient
-	userClient    pb.UserServiceClient
+	orderClient        pb.OrderServiceClient
+	promotionClient    pb.PromotionServiceClient
+	subscriptionClient pb.SubscriptionServiceClient
+	cartClient         pb.CartServiceClient
+	productClient      pb.ProductServiceClient
+	userClient         pb.UserServiceClient
+	paymentClient      pb.PaymentServiceClient
 }
 
 func main() {
@@ -34,6 +48,11 @@ func main() {
 		userServiceURL = "localhost:50053"
 	}
 
+	paymentServiceURL := os.Getenv("PAYMENT_SERVICE_URL")
+	if paymentServiceURL == "" {
+		paymentServiceURL = "localhost:50054"
+	}
+
 	// Connect to Order Service
 	orderConn, err := grpc.Dial(orderServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -55,10 +74,21 @@ func main() {
 	}
 	defer userConn.Close()
 
+	// Connect to Payment Service
+	paymentConn, err := grpc.Dial(paymentServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to Payment service: %v", err)
+	}
+	defer paymentConn.Close()
+
 	gateway := &APIGateway{
-		orderClient:   pb.NewOrderServiceClient(orderConn),
-		productClient: pb.NewProductServiceClient(productConn),
-		userClient:    pb.NewUserServiceClient(userConn),
+		orderClient:        pb.NewOrderServiceClient(orderConn),
+		promotionClient:    pb.NewPromotionServiceClient(orderConn),
+		subscriptionClient: pb.NewSubscriptionServiceClient(orderConn),
+		cartClient:         pb.NewCartServiceClient(orderConn),
+		productClient:      pb.NewProductServiceClient(productConn),
+		userClient:         pb.NewUserServiceClient(userConn),
+		paymentClient:      pb.NewPaymentServiceClient(paymentConn),
 	}
 
 	// Initialize Gin router
@@ -69,6 +99,59 @@ func main() {
 	r.GET("/orders/:id", gateway.getOrder)
 	r.PUT("/orders/:id", gateway.updateOrder)
 	r.GET("/orders", gateway.listOrders)
+	r.GET("/orders/export", gateway.exportOrders)
+	r.POST("/orders/import", gateway.importOrders)
+	r.POST("/orders/:id/cancel", gateway.cancelOrder)
+	r.POST("/orders/:id/items", gateway.addOrderItem)
+	r.PUT("/orders/:id/items/:productId", gateway.updateOrderItemQuantity)
+	r.DELETE("/orders/:id/items/:productId", gateway.removeOrderItem)
+	r.GET("/orders/:id/history", gateway.getOrderHistory)
+	r.GET("/orders/:id/invoice", gateway.getInvoice)
+	r.POST("/orders/:id/shipments", gateway.createShipment)
+	r.GET("/orders/:id/shipments", gateway.listShipments)
+	r.POST("/orders/:id/returns", gateway.requestReturn)
+	r.GET("/orders/:id/returns", gateway.listReturns)
+	r.POST("/orders/:id/returns/:returnId/approve", gateway.approveReturn)
+	r.POST("/orders/:id/returns/:returnId/reject", gateway.rejectReturn)
+	r.POST("/orders/:id/returns/:returnId/receive", gateway.receiveReturn)
+	r.POST("/orders/:id/payments", gateway.authorizePayment)
+
+	// Payment endpoints
+	r.GET("/payments/:id", gateway.getPayment)
+	r.POST("/payments/:id/capture", gateway.capturePayment)
+	r.POST("/payments/:id/refund", gateway.refundPayment)
+
+	// Shipping endpoints
+	r.GET("/shipping/quote", gateway.quoteShipping)
+
+	// Promotion endpoints
+	r.POST("/promotions", gateway.createPromotion)
+	r.GET("/promotions/:id", gateway.getPromotion)
+	r.PUT("/promotions/:id", gateway.updatePromotion)
+	r.GET("/promotions", gateway.listPromotions)
+	r.DELETE("/promotions/:id", gateway.deletePromotion)
+
+	// Subscription endpoints
+	r.POST("/subscriptions", gateway.createSubscription)
+	r.GET("/subscriptions/:id", gateway.getSubscription)
+	r.PUT("/subscriptions/:id", gateway.updateSubscription)
+	r.GET("/subscriptions", gateway.listSubscriptions)
+	r.POST("/subscriptions/:id/pause", gateway.pauseSubscription)
+	r.POST("/subscriptions/:id/resume", gateway.resumeSubscription)
+	r.POST("/subscriptions/:id/skip", gateway.skipSubscription)
+	r.POST("/subscriptions/:id/cancel", gateway.cancelSubscription)
+
+	// Cart endpoints
+	r.POST("/carts", gateway.createCart)
+	r.GET("/carts/:id", gateway.getCart)
+	r.POST("/carts/:id/items", gateway.addCartItem)
+	r.PUT("/carts/:id/items/:productId", gateway.updateCartItem)
+	r.DELETE("/carts/:id/items/:productId", gateway.removeCartItem)
+	r.GET("/users/:id/cart", gateway.getCart)
+	r.POST("/users/:id/cart/items", gateway.addCartItem)
+	r.PUT("/users/:id/cart/items/:productId", gateway.updateCartItem)
+	r.DELETE("/users/:id/cart/items/:productId", gateway.removeCartItem)
+	r.POST("/users/:id/cart/checkout", gateway.checkoutCart)
 
 	// Product endpoints
 	r.POST("/products", gateway.createProduct)
@@ -76,6 +159,7 @@ func main() {
 	r.PUT("/products/:id", gateway.updateProduct)
 	r.GET("/products", gateway.listProducts)
 	r.PUT("/products/:id/stock", gateway.updateStock)
+	r.GET("/products/:id/backorders", gateway.listBackorders)
 
 	// User endpoints
 	r.POST("/users", gateway.createUser)
@@ -99,58 +183,428 @@ func (g *APIGateway) createOrder(c *gin.Context) {
 		return
 	}
 
-	order, err := g.orderClient.CreateOrder(c.Request.Context(), &req)
+	// Forward the client's idempotency key so retries don't create duplicate orders
+	ctx := orderContext(c)
+	if key := c.GetHeader("Idempotency-Key"); key != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "idempotency-key", key)
+	}
+
+	order, err := g.orderClient.CreateOrder(ctx, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, order)
+	writeProto(c, http.StatusCreated, order)
 }
 
 func (g *APIGateway) getOrder(c *gin.Context) {
 	id := c.Param("id")
-	order, err := g.orderClient.GetOrder(c.Request.Context(), &pb.GetOrderRequest{Id: id})
+	order, err := g.orderClient.GetOrder(orderContext(c), &pb.GetOrderRequest{Id: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, order)
+	writeProto(c, http.StatusOK, order)
 }
 
 func (g *APIGateway) updateOrder(c *gin.Context) {
 	id := c.Param("id")
-	var req pb.UpdateOrderRequest
+	var req struct {
+		Status string `json:"status"`
+		Reason string `json:"reason"`
+	}
 	if err := c.BindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	req.Id = id
 
-	order, err := g.orderClient.UpdateOrder(c.Request.Context(), &req)
+	orderStatus, ok := parseOrderStatus(req.Status)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown order status: " + req.Status})
+		return
+	}
+
+	order, err := g.orderClient.UpdateOrder(orderContext(c), &pb.UpdateOrderRequest{
+		Id:     id,
+		Status: orderStatus,
+		Reason: req.Reason,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, order)
+	writeProto(c, http.StatusOK, order)
 }
 
 func (g *APIGateway) listOrders(c *gin.Context) {
-	var req pb.ListOrdersRequest
-	if err := c.BindQuery(&req); err != nil {
+	req, ok := listOrdersRequest(c)
+	if !ok {
+		return
+	}
+
+	response, err := g.orderClient.ListOrders(orderContext(c), req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, response)
+}
+
+// listOrdersRequest reads the filters, sort order and pagination of GET /orders from
+// the query string. It writes a 400 response and returns false if they are invalid.
+func listOrdersRequest(c *gin.Context) (*pb.ListOrdersRequest, bool) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	req := &pb.ListOrdersRequest{
+		UserId:        c.Query("user_id"),
+		Page:          int32(page),
+		Limit:         int32(limit),
+		PageToken:     c.Query("page_token"),
+		IncludeTotal:  c.Query("include_total") == "true",
+		CreatedAfter:  c.Query("created_after"),
+		CreatedBefore: c.Query("created_before"),
+		UpdatedAfter:  c.Query("updated_after"),
+		UpdatedBefore: c.Query("updated_before"),
+		ProductId:     c.Query("product_id"),
+		SortBy:        c.Query("sort_by"),
+		SortOrder:     c.Query("sort_order"),
+	}
+
+	// Statuses may be repeated or comma separated: ?status=paid,shipped
+	for _, param := range c.QueryArray("status") {
+		for _, name := range strings.Split(param, ",") {
+			orderStatus, ok := parseOrderStatus(name)
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "unknown order status: " + name})
+				return nil, false
+			}
+			req.Statuses = append(req.Statuses, orderStatus)
+		}
+	}
+
+	// Total bounds are in minor units of the currency parameter
+	for _, bound := range []struct {
+		param  string
+		target **pb.Money
+	}{
+		{"min_total", &req.MinTotal},
+		{"max_total", &req.MaxTotal},
+	} {
+		value := c.Query(bound.param)
+		if value == "" {
+			continue
+		}
+		units, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || c.Query("currency") == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": bound.param + " must be an integer amount in minor units, with a currency"})
+			return nil, false
+		}
+		*bound.target = &pb.Money{UnitsMinor: units, Currency: strings.ToUpper(c.Query("currency"))}
+	}
+
+	return req, true
+}
+
+func (g *APIGateway) cancelOrder(c *gin.Context) {
+	id := c.Param("id")
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	// The body is optional; a cancellation without a reason is allowed
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	order, err := g.orderClient.CancelOrder(orderContext(c), &pb.CancelOrderRequest{
+		Id:     id,
+		Reason: req.Reason,
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, order)
+}
+
+func (g *APIGateway) addOrderItem(c *gin.Context) {
+	var req struct {
+		ProductID string `json:"product_id"`
+		Quantity  int32  `json:"quantity"`
+	}
+	if err := c.BindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	response, err := g.orderClient.ListOrders(c.Request.Context(), &req)
+	order, err := g.orderClient.AddOrderItem(orderContext(c), &pb.AddOrderItemRequest{
+		OrderId:   c.Param("id"),
+		ProductId: req.ProductID,
+		Quantity:  req.Quantity,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, order)
+}
+
+func (g *APIGateway) updateOrderItemQuantity(c *gin.Context) {
+	var req struct {
+		Quantity int32 `json:"quantity"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := g.orderClient.UpdateOrderItemQuantity(orderContext(c), &pb.UpdateOrderItemQuantityRequest{
+		OrderId:   c.Param("id"),
+		ProductId: c.Param("productId"),
+		Quantity:  req.Quantity,
+	})
+	if err != nil {
+		writeError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	writeProto(c, http.StatusOK, order)
 }
 
+func (g *APIGateway) removeOrderItem(c *gin.Context) {
+	order, err := g.orderClient.RemoveOrderItem(orderContext(c), &pb.RemoveOrderItemRequest{
+		OrderId:   c.Param("id"),
+		ProductId: c.Param("productId"),
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, order)
+}
+
+func (g *APIGateway) getOrderHistory(c *gin.Context) {
+	id := c.Param("id")
+	history, err := g.orderClient.GetOrderHistory(orderContext(c), &pb.GetOrderRequest{Id: id})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
 
+	writeProto(c, http.StatusOK, history)
+}
+
+func (g *APIGateway) createShipment(c *gin.Context) {
+	var req pb.CreateShipmentRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.OrderId = c.Param("id")
+
+	shipment, err := g.orderClient.CreateShipment(orderContext(c), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusCreated, shipment)
+}
+
+func (g *APIGateway) listShipments(c *gin.Context) {
+	resp, err := g.orderClient.ListShipments(orderContext(c), &pb.ListShipmentsRequest{OrderId: c.Param("id")})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, resp)
+}
+
+func (g *APIGateway) requestReturn(c *gin.Context) {
+	var req pb.RequestReturnRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.OrderId = c.Param("id")
+
+	rma, err := g.orderClient.RequestReturn(orderContext(c), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusCreated, rma)
+}
+
+func (g *APIGateway) listReturns(c *gin.Context) {
+	resp, err := g.orderClient.ListReturns(orderContext(c), &pb.ListReturnsRequest{OrderId: c.Param("id")})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, resp)
+}
+
+func (g *APIGateway) approveReturn(c *gin.Context) {
+	g.reviewReturn(c, g.orderClient.ApproveReturn)
+}
+
+func (g *APIGateway) rejectReturn(c *gin.Context) {
+	g.reviewReturn(c, g.orderClient.RejectReturn)
+}
+
+// reviewReturn handles approve and reject, which differ only in the RPC called.
+func (g *APIGateway) reviewReturn(c *gin.Context,
+	review func(context.Context, *pb.ReviewReturnRequest, ...grpc.CallOption) (*pb.ReturnAuthorization, error)) {
+	var req struct {
+		Note string `json:"note"`
+	}
+	// The body is optional; a review without a note is allowed
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	rma, err := review(orderContext(c), &pb.ReviewReturnRequest{
+		OrderId:  c.Param("id"),
+		ReturnId: c.Param("returnId"),
+		Note:     req.Note,
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, rma)
+}
+
+func (g *APIGateway) receiveReturn(c *gin.Context) {
+	var req pb.ReceiveReturnRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.OrderId = c.Param("id")
+	req.ReturnId = c.Param("returnId")
+
+	rma, err := g.orderClient.ReceiveReturn(orderContext(c), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, rma)
+}
+
+// getInvoice downloads the invoice of an order as a PDF, or as HTML or JSON metadata
+// with ?format=html or ?format=json.
+func (g *APIGateway) getInvoice(c *gin.Context) {
+	req := pb.GetInvoiceRequest{OrderId: c.Param("id")}
+	switch format := c.DefaultQuery("format", "pdf"); format {
+	case "pdf":
+		req.Format = pb.InvoiceFormat_INVOICE_FORMAT_PDF
+	case "html":
+		req.Format = pb.InvoiceFormat_INVOICE_FORMAT_HTML
+	case "json":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown invoice format: " + format})
+		return
+	}
+
+	invoice, err := g.orderClient.GetInvoice(orderContext(c), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	if req.Format == pb.InvoiceFormat_INVOICE_FORMAT_PDF {
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", invoice.Number+".pdf"))
+	}
+	if len(invoice.Document) > 0 {
+		c.Data(http.StatusOK, invoice.ContentType, invoice.Document)
+		return
+	}
+	writeProto(c, http.StatusOK, invoice)
+}
+
+// orderContext forwards the caller's Authorization header to the order and payment
+// services, which record the user it identifies as the actor of order changes.
+func orderContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if auth := c.GetHeader("Authorization"); auth != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", auth)
+	}
+	return ctx
+}
+
+// parseOrderStatus accepts either the short form ("shipped") or the full enum
+// name ("ORDER_STATUS_SHIPPED") of an order status.
+func parseOrderStatus(name string) (pb.OrderStatus, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !strings.HasPrefix(name, "ORDER_STATUS_") {
+		name = "ORDER_STATUS_" + name
+	}
+	value, ok := pb.OrderStatus_value[name]
+	if !ok || value == int32(pb.OrderStatus_ORDER_STATUS_UNSPECIFIED) {
+		return pb.OrderStatus_ORDER_STATUS_UNSPECIFIED, false
+	}
+	return pb.OrderStatus(value), true
+}
+
+// writeError translates a gRPC error into an HTTP status, including any per-field
+// precondition violations reported by the service.
+func writeError(c *gin.Context, err error) {
+	st := status.Convert(err)
+
+	code := http.StatusInternalServerError
+	switch st.Code() {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+		code = http.StatusConflict
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.Unavailable:
+		code = http.StatusServiceUnavailable
+	}
+
+	body := gin.H{"error": st.Message()}
+	for _, detail := range st.Details() {
+		if failure, ok := detail.(*errdetails.PreconditionFailure); ok {
+			violations := make([]gin.H, 0, len(failure.Violations))
+			for _, v := range failure.Violations {
+				violations = append(violations, gin.H{"subject": v.Subject, "description": v.Description})
+			}
+			body["violations"] = violations
+		}
+	}
+	c.JSON(code, body)
+}
+
+// writeProto renders a protobuf message with protojson so enums are written by name.
+func writeProto(c *gin.Context, code int, msg proto.Message) {
+	body, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(code, "application/json; charset=utf-8", body)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/order-management/proto"
+)
+
+func (g *APIGateway) authorizePayment(c *gin.Context) {
+	var req pb.AuthorizePaymentRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.OrderId = c.Param("id")
+
+	payment, err := g.paymentClient.AuthorizePayment(orderContext(c), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusCreated, payment)
+}
+
+func (g *APIGateway) getPayment(c *gin.Context) {
+	payment, err := g.paymentClient.GetPayment(orderContext(c), &pb.GetPaymentRequest{Id: c.Param("id")})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, payment)
+}
+
+func (g *APIGateway) capturePayment(c *gin.Context) {
+	var req pb.CapturePaymentRequest
+	// The body is optional; without an amount the full authorization is captured
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	req.PaymentId = c.Param("id")
+
+	payment, err := g.paymentClient.CapturePayment(orderContext(c), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, payment)
+}
+
+func (g *APIGateway) refundPayment(c *gin.Context) {
+	var req pb.RefundPaymentRequest
+	// The body is optional; without an amount everything not yet refunded is refunded
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	req.PaymentId = c.Param("id")
+
+	payment, err := g.paymentClient.RefundPayment(orderContext(c), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, payment)
+}
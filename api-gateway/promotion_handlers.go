@@ -0,0 +1,93 @@
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	pb "github.com/order-management/proto"
+)
+
+func (g *APIGateway) createPromotion(c *gin.Context) {
+	var req pb.Promotion
+	if !readProto(c, &req) {
+		return
+	}
+
+	promotion, err := g.promotionClient.CreatePromotion(c.Request.Context(), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusCreated, promotion)
+}
+
+func (g *APIGateway) getPromotion(c *gin.Context) {
+	promotion, err := g.promotionClient.GetPromotion(c.Request.Context(), &pb.GetPromotionRequest{Id: c.Param("id")})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, promotion)
+}
+
+func (g *APIGateway) updatePromotion(c *gin.Context) {
+	var req pb.Promotion
+	if !readProto(c, &req) {
+		return
+	}
+	req.Id = c.Param("id")
+
+	promotion, err := g.promotionClient.UpdatePromotion(c.Request.Context(), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, promotion)
+}
+
+func (g *APIGateway) listPromotions(c *gin.Context) {
+	var req pb.ListPromotionsRequest
+	if err := c.BindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := g.promotionClient.ListPromotions(c.Request.Context(), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, response)
+}
+
+func (g *APIGateway) deletePromotion(c *gin.Context) {
+	response, err := g.promotionClient.DeletePromotion(c.Request.Context(), &pb.DeletePromotionRequest{Id: c.Param("id")})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, response)
+}
+
+// readProto decodes the request body with protojson, so enums can be sent by name
+// (e.g. "PROMOTION_TYPE_PERCENTAGE"). It writes a 400 response and returns false if
+// the body is not valid.
+func readProto(c *gin.Context, msg proto.Message) bool {
+	body, err := io.ReadAll(c.Request.Body)
+	if err == nil {
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, msg)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
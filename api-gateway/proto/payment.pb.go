@@ -0,0 +1,665 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: payment.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PaymentStatus int32
+
+const (
+	PaymentStatus_PAYMENT_STATUS_UNSPECIFIED        PaymentStatus = 0
+	PaymentStatus_PAYMENT_STATUS_PENDING            PaymentStatus = 1 // Sent to the provider, no answer yet
+	PaymentStatus_PAYMENT_STATUS_AUTHORIZED         PaymentStatus = 2
+	PaymentStatus_PAYMENT_STATUS_CAPTURED           PaymentStatus = 3
+	PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED PaymentStatus = 4
+	PaymentStatus_PAYMENT_STATUS_REFUNDED           PaymentStatus = 5
+	PaymentStatus_PAYMENT_STATUS_DECLINED           PaymentStatus = 6
+	PaymentStatus_PAYMENT_STATUS_FAILED             PaymentStatus = 7 // The provider could not be reached
+	PaymentStatus_PAYMENT_STATUS_VOIDED             PaymentStatus = 8 // Authorization released without capture
+)
+
+// Enum value maps for PaymentStatus.
+var (
+	PaymentStatus_name = map[int32]string{
+		0: "PAYMENT_STATUS_UNSPECIFIED",
+		1: "PAYMENT_STATUS_PENDING",
+		2: "PAYMENT_STATUS_AUTHORIZED",
+		3: "PAYMENT_STATUS_CAPTURED",
+		4: "PAYMENT_STATUS_PARTIALLY_REFUNDED",
+		5: "PAYMENT_STATUS_REFUNDED",
+		6: "PAYMENT_STATUS_DECLINED",
+		7: "PAYMENT_STATUS_FAILED",
+		8: "PAYMENT_STATUS_VOIDED",
+	}
+	PaymentStatus_value = map[string]int32{
+		"PAYMENT_STATUS_UNSPECIFIED":        0,
+		"PAYMENT_STATUS_PENDING":            1,
+		"PAYMENT_STATUS_AUTHORIZED":         2,
+		"PAYMENT_STATUS_CAPTURED":           3,
+		"PAYMENT_STATUS_PARTIALLY_REFUNDED": 4,
+		"PAYMENT_STATUS_REFUNDED":           5,
+		"PAYMENT_STATUS_DECLINED":           6,
+		"PAYMENT_STATUS_FAILED":             7,
+		"PAYMENT_STATUS_VOIDED":             8,
+	}
+)
+
+func (x PaymentStatus) Enum() *PaymentStatus {
+	p := new(PaymentStatus)
+	*p = x
+	return p
+}
+
+func (x PaymentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PaymentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_proto_enumTypes[0].Descriptor()
+}
+
+func (PaymentStatus) Type() protoreflect.EnumType {
+	return &file_payment_proto_enumTypes[0]
+}
+
+func (x PaymentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PaymentStatus.Descriptor instead.
+func (PaymentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{0}
+}
+
+type Payment struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId           string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status            PaymentStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=proto.PaymentStatus" json:"status,omitempty"`
+	Amount            *Money                 `protobuf:"bytes,15,opt,name=amount,proto3" json:"amount,omitempty"` // Authorized amount, in the currency of the order
+	CapturedAmount    *Money                 `protobuf:"bytes,16,opt,name=captured_amount,json=capturedAmount,proto3" json:"captured_amount,omitempty"`
+	RefundedAmount    *Money                 `protobuf:"bytes,17,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	Provider          string                 `protobuf:"bytes,8,opt,name=provider,proto3" json:"provider,omitempty"`
+	ProviderReference string                 `protobuf:"bytes,9,opt,name=provider_reference,json=providerReference,proto3" json:"provider_reference,omitempty"`
+	FailureReason     string                 `protobuf:"bytes,10,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	Refunds           []*Refund              `protobuf:"bytes,11,rep,name=refunds,proto3" json:"refunds,omitempty"`
+	OrderPaidAt       string                 `protobuf:"bytes,12,opt,name=order_paid_at,json=orderPaidAt,proto3" json:"order_paid_at,omitempty"` // When the order was moved to paid after capture
+	CreatedAt         string                 `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         string                 `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_payment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Payment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Payment) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Payment) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Payment) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Payment) GetCapturedAmount() *Money {
+	if x != nil {
+		return x.CapturedAmount
+	}
+	return nil
+}
+
+func (x *Payment) GetRefundedAmount() *Money {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return nil
+}
+
+func (x *Payment) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Payment) GetProviderReference() string {
+	if x != nil {
+		return x.ProviderReference
+	}
+	return ""
+}
+
+func (x *Payment) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *Payment) GetRefunds() []*Refund {
+	if x != nil {
+		return x.Refunds
+	}
+	return nil
+}
+
+func (x *Payment) GetOrderPaidAt() string {
+	if x != nil {
+		return x.OrderPaidAt
+	}
+	return ""
+}
+
+func (x *Payment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Payment) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type Refund struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Amount            *Money                 `protobuf:"bytes,8,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason            string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	ProviderReference string                 `protobuf:"bytes,4,opt,name=provider_reference,json=providerReference,proto3" json:"provider_reference,omitempty"`
+	CreatedAt         string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Status            string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // pending, succeeded or failed
+	FailureReason     string                 `protobuf:"bytes,7,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Refund) Reset() {
+	*x = Refund{}
+	mi := &file_payment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Refund) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Refund) ProtoMessage() {}
+
+func (x *Refund) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Refund.ProtoReflect.Descriptor instead.
+func (*Refund) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Refund) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Refund) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Refund) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Refund) GetProviderReference() string {
+	if x != nil {
+		return x.ProviderReference
+	}
+	return ""
+}
+
+func (x *Refund) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Refund) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Refund) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+type AuthorizePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	PaymentMethod string                 `protobuf:"bytes,2,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"` // Provider token for the card or account to charge
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorizePaymentRequest) Reset() {
+	*x = AuthorizePaymentRequest{}
+	mi := &file_payment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizePaymentRequest) ProtoMessage() {}
+
+func (x *AuthorizePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizePaymentRequest.ProtoReflect.Descriptor instead.
+func (*AuthorizePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AuthorizePaymentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AuthorizePaymentRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+type CapturePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"` // Defaults to the authorized amount
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapturePaymentRequest) Reset() {
+	*x = CapturePaymentRequest{}
+	mi := &file_payment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapturePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturePaymentRequest) ProtoMessage() {}
+
+func (x *CapturePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturePaymentRequest.ProtoReflect.Descriptor instead.
+func (*CapturePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CapturePaymentRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *CapturePaymentRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+type RefundPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"` // Defaults to everything not yet refunded
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundPaymentRequest) Reset() {
+	*x = RefundPaymentRequest{}
+	mi := &file_payment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentRequest) ProtoMessage() {}
+
+func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentRequest.ProtoReflect.Descriptor instead.
+func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RefundPaymentRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *RefundPaymentRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *RefundPaymentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentRequest) Reset() {
+	*x = GetPaymentRequest{}
+	mi := &file_payment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentRequest) ProtoMessage() {}
+
+func (x *GetPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetPaymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_payment_proto protoreflect.FileDescriptor
+
+const file_payment_proto_rawDesc = "" +
+	"\n" +
+	"\rpayment.proto\x12\x05proto\x1a\vmoney.proto\"\x8b\x04\n" +
+	"\aPayment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12,\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x14.proto.PaymentStatusR\x06status\x12$\n" +
+	"\x06amount\x18\x0f \x01(\v2\f.proto.MoneyR\x06amount\x125\n" +
+	"\x0fcaptured_amount\x18\x10 \x01(\v2\f.proto.MoneyR\x0ecapturedAmount\x125\n" +
+	"\x0frefunded_amount\x18\x11 \x01(\v2\f.proto.MoneyR\x0erefundedAmount\x12\x1a\n" +
+	"\bprovider\x18\b \x01(\tR\bprovider\x12-\n" +
+	"\x12provider_reference\x18\t \x01(\tR\x11providerReference\x12%\n" +
+	"\x0efailure_reason\x18\n" +
+	" \x01(\tR\rfailureReason\x12'\n" +
+	"\arefunds\x18\v \x03(\v2\r.proto.RefundR\arefunds\x12\"\n" +
+	"\rorder_paid_at\x18\f \x01(\tR\vorderPaidAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\r \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\tR\tupdatedAtJ\x04\b\x04\x10\x05J\x04\b\x05\x10\x06J\x04\b\x06\x10\aJ\x04\b\a\x10\b\"\xe9\x01\n" +
+	"\x06Refund\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12$\n" +
+	"\x06amount\x18\b \x01(\v2\f.proto.MoneyR\x06amount\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12-\n" +
+	"\x12provider_reference\x18\x04 \x01(\tR\x11providerReference\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12%\n" +
+	"\x0efailure_reason\x18\a \x01(\tR\rfailureReasonJ\x04\b\x02\x10\x03\"a\n" +
+	"\x17AuthorizePaymentRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12%\n" +
+	"\x0epayment_method\x18\x02 \x01(\tR\rpaymentMethodJ\x04\b\x03\x10\x04\"b\n" +
+	"\x15CapturePaymentRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12$\n" +
+	"\x06amount\x18\x03 \x01(\v2\f.proto.MoneyR\x06amountJ\x04\b\x02\x10\x03\"y\n" +
+	"\x14RefundPaymentRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12$\n" +
+	"\x06amount\x18\x04 \x01(\v2\f.proto.MoneyR\x06amount\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reasonJ\x04\b\x02\x10\x03\"#\n" +
+	"\x11GetPaymentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id*\x9e\x02\n" +
+	"\rPaymentStatus\x12\x1e\n" +
+	"\x1aPAYMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16PAYMENT_STATUS_PENDING\x10\x01\x12\x1d\n" +
+	"\x19PAYMENT_STATUS_AUTHORIZED\x10\x02\x12\x1b\n" +
+	"\x17PAYMENT_STATUS_CAPTURED\x10\x03\x12%\n" +
+	"!PAYMENT_STATUS_PARTIALLY_REFUNDED\x10\x04\x12\x1b\n" +
+	"\x17PAYMENT_STATUS_REFUNDED\x10\x05\x12\x1b\n" +
+	"\x17PAYMENT_STATUS_DECLINED\x10\x06\x12\x19\n" +
+	"\x15PAYMENT_STATUS_FAILED\x10\a\x12\x19\n" +
+	"\x15PAYMENT_STATUS_VOIDED\x10\b2\x92\x02\n" +
+	"\x0ePaymentService\x12D\n" +
+	"\x10AuthorizePayment\x12\x1e.proto.AuthorizePaymentRequest\x1a\x0e.proto.Payment\"\x00\x12@\n" +
+	"\x0eCapturePayment\x12\x1c.proto.CapturePaymentRequest\x1a\x0e.proto.Payment\"\x00\x12>\n" +
+	"\rRefundPayment\x12\x1b.proto.RefundPaymentRequest\x1a\x0e.proto.Payment\"\x00\x128\n" +
+	"\n" +
+	"GetPayment\x12\x18.proto.GetPaymentRequest\x1a\x0e.proto.Payment\"\x00B#Z!github.com/order-management/protob\x06proto3"
+
+var (
+	file_payment_proto_rawDescOnce sync.Once
+	file_payment_proto_rawDescData []byte
+)
+
+func file_payment_proto_rawDescGZIP() []byte {
+	file_payment_proto_rawDescOnce.Do(func() {
+		file_payment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_payment_proto_rawDesc), len(file_payment_proto_rawDesc)))
+	})
+	return file_payment_proto_rawDescData
+}
+
+var file_payment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_payment_proto_goTypes = []any{
+	(PaymentStatus)(0),              // 0: proto.PaymentStatus
+	(*Payment)(nil),                 // 1: proto.Payment
+	(*Refund)(nil),                  // 2: proto.Refund
+	(*AuthorizePaymentRequest)(nil), // 3: proto.AuthorizePaymentRequest
+	(*CapturePaymentRequest)(nil),   // 4: proto.CapturePaymentRequest
+	(*RefundPaymentRequest)(nil),    // 5: proto.RefundPaymentRequest
+	(*GetPaymentRequest)(nil),       // 6: proto.GetPaymentRequest
+	(*Money)(nil),                   // 7: proto.Money
+}
+var file_payment_proto_depIdxs = []int32{
+	0,  // 0: proto.Payment.status:type_name -> proto.PaymentStatus
+	7,  // 1: proto.Payment.amount:type_name -> proto.Money
+	7,  // 2: proto.Payment.captured_amount:type_name -> proto.Money
+	7,  // 3: proto.Payment.refunded_amount:type_name -> proto.Money
+	2,  // 4: proto.Payment.refunds:type_name -> proto.Refund
+	7,  // 5: proto.Refund.amount:type_name -> proto.Money
+	7,  // 6: proto.CapturePaymentRequest.amount:type_name -> proto.Money
+	7,  // 7: proto.RefundPaymentRequest.amount:type_name -> proto.Money
+	3,  // 8: proto.PaymentService.AuthorizePayment:input_type -> proto.AuthorizePaymentRequest
+	4,  // 9: proto.PaymentService.CapturePayment:input_type -> proto.CapturePaymentRequest
+	5,  // 10: proto.PaymentService.RefundPayment:input_type -> proto.RefundPaymentRequest
+	6,  // 11: proto.PaymentService.GetPayment:input_type -> proto.GetPaymentRequest
+	1,  // 12: proto.PaymentService.AuthorizePayment:output_type -> proto.Payment
+	1,  // 13: proto.PaymentService.CapturePayment:output_type -> proto.Payment
+	1,  // 14: proto.PaymentService.RefundPayment:output_type -> proto.Payment
+	1,  // 15: proto.PaymentService.GetPayment:output_type -> proto.Payment
+	12, // [12:16] is the sub-list for method output_type
+	8,  // [8:12] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_payment_proto_init() }
+func file_payment_proto_init() {
+	if File_payment_proto != nil {
+		return
+	}
+	file_money_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_proto_rawDesc), len(file_payment_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_payment_proto_goTypes,
+		DependencyIndexes: file_payment_proto_depIdxs,
+		EnumInfos:         file_payment_proto_enumTypes,
+		MessageInfos:      file_payment_proto_msgTypes,
+	}.Build()
+	File_payment_proto = out.File
+	file_payment_proto_goTypes = nil
+	file_payment_proto_depIdxs = nil
+}
@@ -21,18 +21,72 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// BackorderPolicy decides whether a product can be ordered beyond its stock.
+type BackorderPolicy int32
+
+const (
+	BackorderPolicy_BACKORDER_POLICY_NONE      BackorderPolicy = 0 // Orders beyond stock are rejected
+	BackorderPolicy_BACKORDER_POLICY_BACKORDER BackorderPolicy = 1 // Missing units are owed until restocked
+	BackorderPolicy_BACKORDER_POLICY_PREORDER  BackorderPolicy = 2 // Like backorder, for products not released yet
+)
+
+// Enum value maps for BackorderPolicy.
+var (
+	BackorderPolicy_name = map[int32]string{
+		0: "BACKORDER_POLICY_NONE",
+		1: "BACKORDER_POLICY_BACKORDER",
+		2: "BACKORDER_POLICY_PREORDER",
+	}
+	BackorderPolicy_value = map[string]int32{
+		"BACKORDER_POLICY_NONE":      0,
+		"BACKORDER_POLICY_BACKORDER": 1,
+		"BACKORDER_POLICY_PREORDER":  2,
+	}
+)
+
+func (x BackorderPolicy) Enum() *BackorderPolicy {
+	p := new(BackorderPolicy)
+	*p = x
+	return p
+}
+
+func (x BackorderPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BackorderPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_product_proto_enumTypes[0].Descriptor()
+}
+
+func (BackorderPolicy) Type() protoreflect.EnumType {
+	return &file_product_proto_enumTypes[0]
+}
+
+func (x BackorderPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BackorderPolicy.Descriptor instead.
+func (BackorderPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{0}
+}
+
 type Product struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	StockQuantity int32                  `protobuf:"varint,5,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
-	Category      string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description         string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price               *Money                 `protobuf:"bytes,9,opt,name=price,proto3" json:"price,omitempty"`
+	StockQuantity       int32                  `protobuf:"varint,5,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	Category            string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+	CreatedAt           string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Parcel              *Parcel                `protobuf:"bytes,10,opt,name=parcel,proto3" json:"parcel,omitempty"`
+	BackorderPolicy     BackorderPolicy        `protobuf:"varint,11,opt,name=backorder_policy,json=backorderPolicy,proto3,enum=proto.BackorderPolicy" json:"backorder_policy,omitempty"`
+	BackorderLimit      int32                  `protobuf:"varint,12,opt,name=backorder_limit,json=backorderLimit,proto3" json:"backorder_limit,omitempty"`                // Most units that may be owed at once; 0 means no limit
+	BackorderedQuantity int32                  `protobuf:"varint,13,opt,name=backordered_quantity,json=backorderedQuantity,proto3" json:"backordered_quantity,omitempty"` // Units owed to orders, output only
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *Product) Reset() {
@@ -86,11 +140,11 @@ func (x *Product) GetDescription() string {
 	return ""
 }
 
-func (x *Product) GetPrice() float64 {
+func (x *Product) GetPrice() *Money {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return nil
 }
 
 func (x *Product) GetStockQuantity() int32 {
@@ -121,20 +175,120 @@ func (x *Product) GetUpdatedAt() string {
 	return ""
 }
 
-type CreateProductRequest struct {
+func (x *Product) GetParcel() *Parcel {
+	if x != nil {
+		return x.Parcel
+	}
+	return nil
+}
+
+func (x *Product) GetBackorderPolicy() BackorderPolicy {
+	if x != nil {
+		return x.BackorderPolicy
+	}
+	return BackorderPolicy_BACKORDER_POLICY_NONE
+}
+
+func (x *Product) GetBackorderLimit() int32 {
+	if x != nil {
+		return x.BackorderLimit
+	}
+	return 0
+}
+
+func (x *Product) GetBackorderedQuantity() int32 {
+	if x != nil {
+		return x.BackorderedQuantity
+	}
+	return 0
+}
+
+// Parcel is the weight and size of one unit of a product packed for shipping.
+type Parcel struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	StockQuantity int32                  `protobuf:"varint,4,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
-	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	WeightGrams   int32                  `protobuf:"varint,1,opt,name=weight_grams,json=weightGrams,proto3" json:"weight_grams,omitempty"`
+	LengthMm      int32                  `protobuf:"varint,2,opt,name=length_mm,json=lengthMm,proto3" json:"length_mm,omitempty"`
+	WidthMm       int32                  `protobuf:"varint,3,opt,name=width_mm,json=widthMm,proto3" json:"width_mm,omitempty"`
+	HeightMm      int32                  `protobuf:"varint,4,opt,name=height_mm,json=heightMm,proto3" json:"height_mm,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Parcel) Reset() {
+	*x = Parcel{}
+	mi := &file_product_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Parcel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parcel) ProtoMessage() {}
+
+func (x *Parcel) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parcel.ProtoReflect.Descriptor instead.
+func (*Parcel) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Parcel) GetWeightGrams() int32 {
+	if x != nil {
+		return x.WeightGrams
+	}
+	return 0
+}
+
+func (x *Parcel) GetLengthMm() int32 {
+	if x != nil {
+		return x.LengthMm
+	}
+	return 0
+}
+
+func (x *Parcel) GetWidthMm() int32 {
+	if x != nil {
+		return x.WidthMm
+	}
+	return 0
+}
+
+func (x *Parcel) GetHeightMm() int32 {
+	if x != nil {
+		return x.HeightMm
+	}
+	return 0
+}
+
+type CreateProductRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Price           *Money                 `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"`
+	StockQuantity   int32                  `protobuf:"varint,4,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	Category        string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	Parcel          *Parcel                `protobuf:"bytes,7,opt,name=parcel,proto3" json:"parcel,omitempty"`
+	BackorderPolicy BackorderPolicy        `protobuf:"varint,8,opt,name=backorder_policy,json=backorderPolicy,proto3,enum=proto.BackorderPolicy" json:"backorder_policy,omitempty"`
+	BackorderLimit  int32                  `protobuf:"varint,9,opt,name=backorder_limit,json=backorderLimit,proto3" json:"backorder_limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
 func (x *CreateProductRequest) Reset() {
 	*x = CreateProductRequest{}
-	mi := &file_product_proto_msgTypes[1]
+	mi := &file_product_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -146,7 +300,7 @@ func (x *CreateProductRequest) String() string {
 func (*CreateProductRequest) ProtoMessage() {}
 
 func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[1]
+	mi := &file_product_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,7 +313,7 @@ func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
 func (*CreateProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{1}
+	return file_product_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateProductRequest) GetName() string {
@@ -176,11 +330,11 @@ func (x *CreateProductRequest) GetDescription() string {
 	return ""
 }
 
-func (x *CreateProductRequest) GetPrice() float64 {
+func (x *CreateProductRequest) GetPrice() *Money {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return nil
 }
 
 func (x *CreateProductRequest) GetStockQuantity() int32 {
@@ -197,6 +351,27 @@ func (x *CreateProductRequest) GetCategory() string {
 	return ""
 }
 
+func (x *CreateProductRequest) GetParcel() *Parcel {
+	if x != nil {
+		return x.Parcel
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetBackorderPolicy() BackorderPolicy {
+	if x != nil {
+		return x.BackorderPolicy
+	}
+	return BackorderPolicy_BACKORDER_POLICY_NONE
+}
+
+func (x *CreateProductRequest) GetBackorderLimit() int32 {
+	if x != nil {
+		return x.BackorderLimit
+	}
+	return 0
+}
+
 type GetProductRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -206,7 +381,7 @@ type GetProductRequest struct {
 
 func (x *GetProductRequest) Reset() {
 	*x = GetProductRequest{}
-	mi := &file_product_proto_msgTypes[2]
+	mi := &file_product_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -218,7 +393,7 @@ func (x *GetProductRequest) String() string {
 func (*GetProductRequest) ProtoMessage() {}
 
 func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[2]
+	mi := &file_product_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -231,7 +406,7 @@ func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
 func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{2}
+	return file_product_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetProductRequest) GetId() string {
@@ -242,19 +417,22 @@ func (x *GetProductRequest) GetId() string {
 }
 
 type UpdateProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price           *Money                 `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"` // Left unchanged when not set
+	Category        string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	Parcel          *Parcel                `protobuf:"bytes,7,opt,name=parcel,proto3" json:"parcel,omitempty"`                                                                            // Left unchanged when not set
+	BackorderPolicy *BackorderPolicy       `protobuf:"varint,8,opt,name=backorder_policy,json=backorderPolicy,proto3,enum=proto.BackorderPolicy,oneof" json:"backorder_policy,omitempty"` // Left unchanged when not set
+	BackorderLimit  *int32                 `protobuf:"varint,9,opt,name=backorder_limit,json=backorderLimit,proto3,oneof" json:"backorder_limit,omitempty"`                               // Left unchanged when not set
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateProductRequest) Reset() {
 	*x = UpdateProductRequest{}
-	mi := &file_product_proto_msgTypes[3]
+	mi := &file_product_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -266,7 +444,7 @@ func (x *UpdateProductRequest) String() string {
 func (*UpdateProductRequest) ProtoMessage() {}
 
 func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[3]
+	mi := &file_product_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -279,7 +457,7 @@ func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
 func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{3}
+	return file_product_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UpdateProductRequest) GetId() string {
@@ -303,11 +481,11 @@ func (x *UpdateProductRequest) GetDescription() string {
 	return ""
 }
 
-func (x *UpdateProductRequest) GetPrice() float64 {
+func (x *UpdateProductRequest) GetPrice() *Money {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return nil
 }
 
 func (x *UpdateProductRequest) GetCategory() string {
@@ -317,17 +495,41 @@ func (x *UpdateProductRequest) GetCategory() string {
 	return ""
 }
 
+func (x *UpdateProductRequest) GetParcel() *Parcel {
+	if x != nil {
+		return x.Parcel
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetBackorderPolicy() BackorderPolicy {
+	if x != nil && x.BackorderPolicy != nil {
+		return *x.BackorderPolicy
+	}
+	return BackorderPolicy_BACKORDER_POLICY_NONE
+}
+
+func (x *UpdateProductRequest) GetBackorderLimit() int32 {
+	if x != nil && x.BackorderLimit != nil {
+		return *x.BackorderLimit
+	}
+	return 0
+}
+
+// UpdateStockRequest changes the stock of a product. With an update_key the change
+// is made once: repeating the request returns the product without changing it again.
 type UpdateStockRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	QuantityChange int32                  `protobuf:"varint,2,opt,name=quantity_change,json=quantityChange,proto3" json:"quantity_change,omitempty"` // Positive for stock addition, negative for reduction
+	UpdateKey      string                 `protobuf:"bytes,3,opt,name=update_key,json=updateKey,proto3" json:"update_key,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *UpdateStockRequest) Reset() {
 	*x = UpdateStockRequest{}
-	mi := &file_product_proto_msgTypes[4]
+	mi := &file_product_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -339,7 +541,7 @@ func (x *UpdateStockRequest) String() string {
 func (*UpdateStockRequest) ProtoMessage() {}
 
 func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[4]
+	mi := &file_product_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -352,7 +554,7 @@ func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateStockRequest.ProtoReflect.Descriptor instead.
 func (*UpdateStockRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{4}
+	return file_product_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UpdateStockRequest) GetId() string {
@@ -369,18 +571,473 @@ func (x *UpdateStockRequest) GetQuantityChange() int32 {
 	return 0
 }
 
+func (x *UpdateStockRequest) GetUpdateKey() string {
+	if x != nil {
+		return x.UpdateKey
+	}
+	return ""
+}
+
+// ReserveStockRequest takes quantity units of a product for an order. Units beyond
+// the stock are backordered if the product's policy allows it. With a reservation_key
+// the request is idempotent: repeating it returns the first reservation, and it is
+// refused once a release with the same key was made.
+type ReserveStockRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProductId      string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	OrderId        string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Quantity       int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ReservationKey string                 `protobuf:"bytes,4,opt,name=reservation_key,json=reservationKey,proto3" json:"reservation_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_product_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReserveStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReserveStockRequest) GetReservationKey() string {
+	if x != nil {
+		return x.ReservationKey
+	}
+	return ""
+}
+
+type StockReservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reserved      int32                  `protobuf:"varint,1,opt,name=reserved,proto3" json:"reserved,omitempty"`       // Units taken from stock
+	Backordered   int32                  `protobuf:"varint,2,opt,name=backordered,proto3" json:"backordered,omitempty"` // Units owed to the order until restocked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockReservation) Reset() {
+	*x = StockReservation{}
+	mi := &file_product_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockReservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockReservation) ProtoMessage() {}
+
+func (x *StockReservation) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockReservation.ProtoReflect.Descriptor instead.
+func (*StockReservation) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StockReservation) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *StockReservation) GetBackordered() int32 {
+	if x != nil {
+		return x.Backordered
+	}
+	return 0
+}
+
+// ReleaseStockRequest gives back quantity units of a product held for an order. Units
+// still owed to the order are cancelled first, the rest is returned to stock. With a
+// reservation_key only the reservation made with that key is released, once; if none
+// was made, nothing is released and a later reservation with the key is refused.
+type ReleaseStockRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProductId      string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	OrderId        string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Quantity       int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ReservationKey string                 `protobuf:"bytes,4,opt,name=reservation_key,json=reservationKey,proto3" json:"reservation_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReleaseStockRequest) Reset() {
+	*x = ReleaseStockRequest{}
+	mi := &file_product_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockRequest) ProtoMessage() {}
+
+func (x *ReleaseStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseStockRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReleaseStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReleaseStockRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReleaseStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReleaseStockRequest) GetReservationKey() string {
+	if x != nil {
+		return x.ReservationKey
+	}
+	return ""
+}
+
+type StockRelease struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Restocked          int32                  `protobuf:"varint,1,opt,name=restocked,proto3" json:"restocked,omitempty"`
+	BackorderCancelled int32                  `protobuf:"varint,2,opt,name=backorder_cancelled,json=backorderCancelled,proto3" json:"backorder_cancelled,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *StockRelease) Reset() {
+	*x = StockRelease{}
+	mi := &file_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockRelease) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockRelease) ProtoMessage() {}
+
+func (x *StockRelease) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockRelease.ProtoReflect.Descriptor instead.
+func (*StockRelease) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StockRelease) GetRestocked() int32 {
+	if x != nil {
+		return x.Restocked
+	}
+	return 0
+}
+
+func (x *StockRelease) GetBackorderCancelled() int32 {
+	if x != nil {
+		return x.BackorderCancelled
+	}
+	return 0
+}
+
+// Backorder is the part of an order's quantity of a product that had no stock when
+// it was reserved. Incoming stock is allocated to the oldest backorders first.
+type Backorder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`   // Units still owed
+	Allocated     int32                  `protobuf:"varint,5,opt,name=allocated,proto3" json:"allocated,omitempty"` // Units allocated from incoming stock so far
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Backorder) Reset() {
+	*x = Backorder{}
+	mi := &file_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Backorder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Backorder) ProtoMessage() {}
+
+func (x *Backorder) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Backorder.ProtoReflect.Descriptor instead.
+func (*Backorder) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Backorder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Backorder) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Backorder) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Backorder) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Backorder) GetAllocated() int32 {
+	if x != nil {
+		return x.Allocated
+	}
+	return 0
+}
+
+func (x *Backorder) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Backorder) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type ListBackordersRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OrderId         string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProductId       string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	OutstandingOnly bool                   `protobuf:"varint,3,opt,name=outstanding_only,json=outstandingOnly,proto3" json:"outstanding_only,omitempty"` // Leave out backorders that are fully allocated
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListBackordersRequest) Reset() {
+	*x = ListBackordersRequest{}
+	mi := &file_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBackordersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBackordersRequest) ProtoMessage() {}
+
+func (x *ListBackordersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBackordersRequest.ProtoReflect.Descriptor instead.
+func (*ListBackordersRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListBackordersRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ListBackordersRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ListBackordersRequest) GetOutstandingOnly() bool {
+	if x != nil {
+		return x.OutstandingOnly
+	}
+	return false
+}
+
+type ListBackordersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backorders    []*Backorder           `protobuf:"bytes,1,rep,name=backorders,proto3" json:"backorders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBackordersResponse) Reset() {
+	*x = ListBackordersResponse{}
+	mi := &file_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBackordersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBackordersResponse) ProtoMessage() {}
+
+func (x *ListBackordersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBackordersResponse.ProtoReflect.Descriptor instead.
+func (*ListBackordersResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListBackordersResponse) GetBackorders() []*Backorder {
+	if x != nil {
+		return x.Backorders
+	}
+	return nil
+}
+
 type ListProductsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
 	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
 	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`           // next_page_token of the previous page; page is ignored
+	IncludeTotal  bool                   `protobuf:"varint,5,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"` // Count the matching products even when paging by token
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListProductsRequest) Reset() {
 	*x = ListProductsRequest{}
-	mi := &file_product_proto_msgTypes[5]
+	mi := &file_product_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -392,7 +1049,7 @@ func (x *ListProductsRequest) String() string {
 func (*ListProductsRequest) ProtoMessage() {}
 
 func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[5]
+	mi := &file_product_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,7 +1062,7 @@ func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
 func (*ListProductsRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{5}
+	return file_product_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListProductsRequest) GetCategory() string {
@@ -429,17 +1086,32 @@ func (x *ListProductsRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *ListProductsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
 type ListProductsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`                                       // Only set when counted, see ListProductsRequest
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListProductsResponse) Reset() {
 	*x = ListProductsResponse{}
-	mi := &file_product_proto_msgTypes[6]
+	mi := &file_product_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -451,7 +1123,7 @@ func (x *ListProductsResponse) String() string {
 func (*ListProductsResponse) ProtoMessage() {}
 
 func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[6]
+	mi := &file_product_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -464,7 +1136,7 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
 func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{6}
+	return file_product_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListProductsResponse) GetProducts() []*Product {
@@ -481,53 +1153,129 @@ func (x *ListProductsResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *ListProductsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_product_proto protoreflect.FileDescriptor
 
 const file_product_proto_rawDesc = "" +
 	"\n" +
-	"\rproduct.proto\x12\x05proto\"\xe6\x01\n" +
+	"\rproduct.proto\x12\x05proto\x1a\vmoney.proto\"\xc0\x03\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\"\n" +
+	"\x05price\x18\t \x01(\v2\f.proto.MoneyR\x05price\x12%\n" +
 	"\x0estock_quantity\x18\x05 \x01(\x05R\rstockQuantity\x12\x1a\n" +
 	"\bcategory\x18\x06 \x01(\tR\bcategory\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\tR\tupdatedAt\"\xa5\x01\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12%\n" +
+	"\x06parcel\x18\n" +
+	" \x01(\v2\r.proto.ParcelR\x06parcel\x12A\n" +
+	"\x10backorder_policy\x18\v \x01(\x0e2\x16.proto.BackorderPolicyR\x0fbackorderPolicy\x12'\n" +
+	"\x0fbackorder_limit\x18\f \x01(\x05R\x0ebackorderLimit\x121\n" +
+	"\x14backordered_quantity\x18\r \x01(\x05R\x13backorderedQuantityJ\x04\b\x04\x10\x05\"\x80\x01\n" +
+	"\x06Parcel\x12!\n" +
+	"\fweight_grams\x18\x01 \x01(\x05R\vweightGrams\x12\x1b\n" +
+	"\tlength_mm\x18\x02 \x01(\x05R\blengthMm\x12\x19\n" +
+	"\bwidth_mm\x18\x03 \x01(\x05R\awidthMm\x12\x1b\n" +
+	"\theight_mm\x18\x04 \x01(\x05R\bheightMm\"\xcc\x02\n" +
 	"\x14CreateProductRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\"\n" +
+	"\x05price\x18\x06 \x01(\v2\f.proto.MoneyR\x05price\x12%\n" +
 	"\x0estock_quantity\x18\x04 \x01(\x05R\rstockQuantity\x12\x1a\n" +
-	"\bcategory\x18\x05 \x01(\tR\bcategory\"#\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12%\n" +
+	"\x06parcel\x18\a \x01(\v2\r.proto.ParcelR\x06parcel\x12A\n" +
+	"\x10backorder_policy\x18\b \x01(\x0e2\x16.proto.BackorderPolicyR\x0fbackorderPolicy\x12'\n" +
+	"\x0fbackorder_limit\x18\t \x01(\x05R\x0ebackorderLimitJ\x04\b\x03\x10\x04\"#\n" +
 	"\x11GetProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x8e\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xe8\x02\n" +
 	"\x14UpdateProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bcategory\x18\x05 \x01(\tR\bcategory\"M\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\"\n" +
+	"\x05price\x18\x06 \x01(\v2\f.proto.MoneyR\x05price\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12%\n" +
+	"\x06parcel\x18\a \x01(\v2\r.proto.ParcelR\x06parcel\x12F\n" +
+	"\x10backorder_policy\x18\b \x01(\x0e2\x16.proto.BackorderPolicyH\x00R\x0fbackorderPolicy\x88\x01\x01\x12,\n" +
+	"\x0fbackorder_limit\x18\t \x01(\x05H\x01R\x0ebackorderLimit\x88\x01\x01B\x13\n" +
+	"\x11_backorder_policyB\x12\n" +
+	"\x10_backorder_limitJ\x04\b\x04\x10\x05\"l\n" +
 	"\x12UpdateStockRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
-	"\x0fquantity_change\x18\x02 \x01(\x05R\x0equantityChange\"[\n" +
+	"\x0fquantity_change\x18\x02 \x01(\x05R\x0equantityChange\x12\x1d\n" +
+	"\n" +
+	"update_key\x18\x03 \x01(\tR\tupdateKey\"\x94\x01\n" +
+	"\x13ReserveStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12'\n" +
+	"\x0freservation_key\x18\x04 \x01(\tR\x0ereservationKey\"P\n" +
+	"\x10StockReservation\x12\x1a\n" +
+	"\breserved\x18\x01 \x01(\x05R\breserved\x12 \n" +
+	"\vbackordered\x18\x02 \x01(\x05R\vbackordered\"\x94\x01\n" +
+	"\x13ReleaseStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12'\n" +
+	"\x0freservation_key\x18\x04 \x01(\tR\x0ereservationKey\"]\n" +
+	"\fStockRelease\x12\x1c\n" +
+	"\trestocked\x18\x01 \x01(\x05R\trestocked\x12/\n" +
+	"\x13backorder_cancelled\x18\x02 \x01(\x05R\x12backorderCancelled\"\xcd\x01\n" +
+	"\tBackorder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\tallocated\x18\x05 \x01(\x05R\tallocated\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\"|\n" +
+	"\x15ListBackordersRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12)\n" +
+	"\x10outstanding_only\x18\x03 \x01(\bR\x0foutstandingOnly\"J\n" +
+	"\x16ListBackordersResponse\x120\n" +
+	"\n" +
+	"backorders\x18\x01 \x03(\v2\x10.proto.BackorderR\n" +
+	"backorders\"\x9f\x01\n" +
 	"\x13ListProductsRequest\x12\x1a\n" +
 	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\"X\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12#\n" +
+	"\rinclude_total\x18\x05 \x01(\bR\fincludeTotal\"\x80\x01\n" +
 	"\x14ListProductsResponse\x12*\n" +
 	"\bproducts\x18\x01 \x03(\v2\x0e.proto.ProductR\bproducts\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total2\xd1\x02\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken*k\n" +
+	"\x0fBackorderPolicy\x12\x19\n" +
+	"\x15BACKORDER_POLICY_NONE\x10\x00\x12\x1e\n" +
+	"\x1aBACKORDER_POLICY_BACKORDER\x10\x01\x12\x1d\n" +
+	"\x19BACKORDER_POLICY_PREORDER\x10\x022\xac\x04\n" +
 	"\x0eProductService\x12>\n" +
 	"\rCreateProduct\x12\x1b.proto.CreateProductRequest\x1a\x0e.proto.Product\"\x00\x128\n" +
 	"\n" +
 	"GetProduct\x12\x18.proto.GetProductRequest\x1a\x0e.proto.Product\"\x00\x12>\n" +
 	"\rUpdateProduct\x12\x1b.proto.UpdateProductRequest\x1a\x0e.proto.Product\"\x00\x12I\n" +
 	"\fListProducts\x12\x1a.proto.ListProductsRequest\x1a\x1b.proto.ListProductsResponse\"\x00\x12:\n" +
-	"\vUpdateStock\x12\x19.proto.UpdateStockRequest\x1a\x0e.proto.Product\"\x00B#Z!github.com/order-management/protob\x06proto3"
+	"\vUpdateStock\x12\x19.proto.UpdateStockRequest\x1a\x0e.proto.Product\"\x00\x12E\n" +
+	"\fReserveStock\x12\x1a.proto.ReserveStockRequest\x1a\x17.proto.StockReservation\"\x00\x12A\n" +
+	"\fReleaseStock\x12\x1a.proto.ReleaseStockRequest\x1a\x13.proto.StockRelease\"\x00\x12O\n" +
+	"\x0eListBackorders\x12\x1c.proto.ListBackordersRequest\x1a\x1d.proto.ListBackordersResponse\"\x00B#Z!github.com/order-management/protob\x06proto3"
 
 var (
 	file_product_proto_rawDescOnce sync.Once
@@ -541,33 +1289,60 @@ func file_product_proto_rawDescGZIP() []byte {
 	return file_product_proto_rawDescData
 }
 
-var file_product_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_product_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_product_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_product_proto_goTypes = []any{
-	(*Product)(nil),              // 0: proto.Product
-	(*CreateProductRequest)(nil), // 1: proto.CreateProductRequest
-	(*GetProductRequest)(nil),    // 2: proto.GetProductRequest
-	(*UpdateProductRequest)(nil), // 3: proto.UpdateProductRequest
-	(*UpdateStockRequest)(nil),   // 4: proto.UpdateStockRequest
-	(*ListProductsRequest)(nil),  // 5: proto.ListProductsRequest
-	(*ListProductsResponse)(nil), // 6: proto.ListProductsResponse
+	(BackorderPolicy)(0),           // 0: proto.BackorderPolicy
+	(*Product)(nil),                // 1: proto.Product
+	(*Parcel)(nil),                 // 2: proto.Parcel
+	(*CreateProductRequest)(nil),   // 3: proto.CreateProductRequest
+	(*GetProductRequest)(nil),      // 4: proto.GetProductRequest
+	(*UpdateProductRequest)(nil),   // 5: proto.UpdateProductRequest
+	(*UpdateStockRequest)(nil),     // 6: proto.UpdateStockRequest
+	(*ReserveStockRequest)(nil),    // 7: proto.ReserveStockRequest
+	(*StockReservation)(nil),       // 8: proto.StockReservation
+	(*ReleaseStockRequest)(nil),    // 9: proto.ReleaseStockRequest
+	(*StockRelease)(nil),           // 10: proto.StockRelease
+	(*Backorder)(nil),              // 11: proto.Backorder
+	(*ListBackordersRequest)(nil),  // 12: proto.ListBackordersRequest
+	(*ListBackordersResponse)(nil), // 13: proto.ListBackordersResponse
+	(*ListProductsRequest)(nil),    // 14: proto.ListProductsRequest
+	(*ListProductsResponse)(nil),   // 15: proto.ListProductsResponse
+	(*Money)(nil),                  // 16: proto.Money
 }
 var file_product_proto_depIdxs = []int32{
-	0, // 0: proto.ListProductsResponse.products:type_name -> proto.Product
-	1, // 1: proto.ProductService.CreateProduct:input_type -> proto.CreateProductRequest
-	2, // 2: proto.ProductService.GetProduct:input_type -> proto.GetProductRequest
-	3, // 3: proto.ProductService.UpdateProduct:input_type -> proto.UpdateProductRequest
-	5, // 4: proto.ProductService.ListProducts:input_type -> proto.ListProductsRequest
-	4, // 5: proto.ProductService.UpdateStock:input_type -> proto.UpdateStockRequest
-	0, // 6: proto.ProductService.CreateProduct:output_type -> proto.Product
-	0, // 7: proto.ProductService.GetProduct:output_type -> proto.Product
-	0, // 8: proto.ProductService.UpdateProduct:output_type -> proto.Product
-	6, // 9: proto.ProductService.ListProducts:output_type -> proto.ListProductsResponse
-	0, // 10: proto.ProductService.UpdateStock:output_type -> proto.Product
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	16, // 0: proto.Product.price:type_name -> proto.Money
+	2,  // 1: proto.Product.parcel:type_name -> proto.Parcel
+	0,  // 2: proto.Product.backorder_policy:type_name -> proto.BackorderPolicy
+	16, // 3: proto.CreateProductRequest.price:type_name -> proto.Money
+	2,  // 4: proto.CreateProductRequest.parcel:type_name -> proto.Parcel
+	0,  // 5: proto.CreateProductRequest.backorder_policy:type_name -> proto.BackorderPolicy
+	16, // 6: proto.UpdateProductRequest.price:type_name -> proto.Money
+	2,  // 7: proto.UpdateProductRequest.parcel:type_name -> proto.Parcel
+	0,  // 8: proto.UpdateProductRequest.backorder_policy:type_name -> proto.BackorderPolicy
+	11, // 9: proto.ListBackordersResponse.backorders:type_name -> proto.Backorder
+	1,  // 10: proto.ListProductsResponse.products:type_name -> proto.Product
+	3,  // 11: proto.ProductService.CreateProduct:input_type -> proto.CreateProductRequest
+	4,  // 12: proto.ProductService.GetProduct:input_type -> proto.GetProductRequest
+	5,  // 13: proto.ProductService.UpdateProduct:input_type -> proto.UpdateProductRequest
+	14, // 14: proto.ProductService.ListProducts:input_type -> proto.ListProductsRequest
+	6,  // 15: proto.ProductService.UpdateStock:input_type -> proto.UpdateStockRequest
+	7,  // 16: proto.ProductService.ReserveStock:input_type -> proto.ReserveStockRequest
+	9,  // 17: proto.ProductService.ReleaseStock:input_type -> proto.ReleaseStockRequest
+	12, // 18: proto.ProductService.ListBackorders:input_type -> proto.ListBackordersRequest
+	1,  // 19: proto.ProductService.CreateProduct:output_type -> proto.Product
+	1,  // 20: proto.ProductService.GetProduct:output_type -> proto.Product
+	1,  // 21: proto.ProductService.UpdateProduct:output_type -> proto.Product
+	15, // 22: proto.ProductService.ListProducts:output_type -> proto.ListProductsResponse
+	1,  // 23: proto.ProductService.UpdateStock:output_type -> proto.Product
+	8,  // 24: proto.ProductService.ReserveStock:output_type -> proto.StockReservation
+	10, // 25: proto.ProductService.ReleaseStock:output_type -> proto.StockRelease
+	13, // 26: proto.ProductService.ListBackorders:output_type -> proto.ListBackordersResponse
+	19, // [19:27] is the sub-list for method output_type
+	11, // [11:19] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_product_proto_init() }
@@ -575,18 +1350,21 @@ func file_product_proto_init() {
 	if File_product_proto != nil {
 		return
 	}
+	file_money_proto_init()
+	file_product_proto_msgTypes[4].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_proto_rawDesc), len(file_product_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      1,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_product_proto_goTypes,
 		DependencyIndexes: file_product_proto_depIdxs,
+		EnumInfos:         file_product_proto_enumTypes,
 		MessageInfos:      file_product_proto_msgTypes,
 	}.Build()
 	File_product_proto = out.File
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/order-management/proto"
+)
+
+// quoteShipping prices the shipping options for a set of items and a destination. Items
+// are passed as repeated product_id:quantity pairs, e.g.
+// /shipping/quote?items=abc:2&items=def:1&country=US&region=CA&postal_code=94105
+func (g *APIGateway) quoteShipping(c *gin.Context) {
+	req := pb.QuoteShippingRequest{
+		ShippingAddress: &pb.Address{
+			City:       c.Query("city"),
+			Region:     c.Query("region"),
+			PostalCode: c.Query("postal_code"),
+			Country:    c.Query("country"),
+		},
+	}
+	for _, param := range c.QueryArray("items") {
+		productID, quantity, ok := strings.Cut(param, ":")
+		n, err := strconv.ParseInt(quantity, 10, 32)
+		if !ok || err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "items must be given as product_id:quantity, got " + param})
+			return
+		}
+		req.Items = append(req.Items, &pb.OrderItem{ProductId: productID, Quantity: int32(n)})
+	}
+
+	quote, err := g.orderClient.QuoteShipping(c.Request.Context(), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, quote)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	pb "github.com/order-management/proto"
+)
+
+func (g *APIGateway) createSubscription(c *gin.Context) {
+	var req pb.CreateSubscriptionRequest
+	if !readProto(c, &req) {
+		return
+	}
+
+	subscription, err := g.subscriptionClient.CreateSubscription(orderContext(c), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusCreated, subscription)
+}
+
+func (g *APIGateway) getSubscription(c *gin.Context) {
+	subscription, err := g.subscriptionClient.GetSubscription(orderContext(c), &pb.GetSubscriptionRequest{Id: c.Param("id")})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, subscription)
+}
+
+func (g *APIGateway) updateSubscription(c *gin.Context) {
+	var req pb.UpdateSubscriptionRequest
+	if !readProto(c, &req) {
+		return
+	}
+	req.Id = c.Param("id")
+
+	subscription, err := g.subscriptionClient.UpdateSubscription(orderContext(c), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, subscription)
+}
+
+func (g *APIGateway) listSubscriptions(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	req := pb.ListSubscriptionsRequest{
+		UserId:       c.Query("user_id"),
+		Limit:        int32(limit),
+		PageToken:    c.Query("page_token"),
+		IncludeTotal: c.Query("include_total") == "true",
+	}
+	if name := c.Query("status"); name != "" {
+		value, ok := pb.SubscriptionStatus_value["SUBSCRIPTION_STATUS_"+strings.ToUpper(strings.TrimSpace(name))]
+		if !ok || value == int32(pb.SubscriptionStatus_SUBSCRIPTION_STATUS_UNSPECIFIED) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "unknown subscription status: " + name})
+			return
+		}
+		req.Status = pb.SubscriptionStatus(value)
+	}
+
+	response, err := g.subscriptionClient.ListSubscriptions(orderContext(c), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, response)
+}
+
+func (g *APIGateway) pauseSubscription(c *gin.Context) {
+	g.subscriptionAction(c, g.subscriptionClient.PauseSubscription)
+}
+
+func (g *APIGateway) resumeSubscription(c *gin.Context) {
+	g.subscriptionAction(c, g.subscriptionClient.ResumeSubscription)
+}
+
+func (g *APIGateway) skipSubscription(c *gin.Context) {
+	g.subscriptionAction(c, g.subscriptionClient.SkipSubscription)
+}
+
+func (g *APIGateway) cancelSubscription(c *gin.Context) {
+	g.subscriptionAction(c, g.subscriptionClient.CancelSubscription)
+}
+
+// subscriptionAction calls one of the SubscriptionService RPCs that act on the
+// subscription named in the path.
+func (g *APIGateway) subscriptionAction(c *gin.Context,
+	action func(context.Context, *pb.GetSubscriptionRequest, ...grpc.CallOption) (*pb.Subscription, error)) {
+	subscription, err := action(orderContext(c), &pb.GetSubscriptionRequest{Id: c.Param("id")})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, subscription)
+}
@@ -80,17 +80,19 @@ func (g *APIGateway) deleteUser(c *gin.Context) {
 }
 
 func (g *APIGateway) listUsers(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	page, _ := strconv.Atoi(c.Query("page"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	role := c.Query("role")
 
 	resp, err := g.userClient.ListUsers(c.Request.Context(), &pb.ListUsersRequest{
-		Page:  int32(page),
-		Limit: int32(limit),
-		Role:  role,
+		Page:         int32(page),
+		Limit:        int32(limit),
+		Role:         role,
+		PageToken:    c.Query("page_token"),
+		IncludeTotal: c.Query("include_total") == "true",
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -98,17 +100,29 @@ func (g *APIGateway) listUsers(c *gin.Context) {
 }
 
 func (g *APIGateway) authenticateUser(c *gin.Context) {
-	var req pb.AuthRequest
+	// cart_id names an anonymous cart to merge into the user's cart
+	var req struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+		CartID   string `json:"cart_id"`
+	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	resp, err := g.userClient.AuthenticateUser(c.Request.Context(), &req)
+	resp, err := g.userClient.AuthenticateUser(c.Request.Context(), &pb.AuthRequest{Email: req.Email, Password: req.Password})
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
+	if req.CartID != "" {
+		if cart := g.mergeCart(c, req.CartID, resp); cart != nil {
+			c.JSON(http.StatusOK, gin.H{"token": resp.Token, "user": resp.User, "cart": cart})
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, resp)
 }
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	pb "github.com/order-management/proto"
+)
+
+// Backorders are kept by the Product Service, which allocates incoming stock to the
+// oldest ones first. Order lines record how many of their units are still backordered.
+// Every backorderSyncInterval the replica holding the backorder lease asks the Product
+// Service what is still owed to each order with backordered lines, updates the lines
+// and publishes OrderBackorderAllocated for the orders that received stock.
+
+// backorderSyncInterval is how often backordered orders are brought up to date.
+const backorderSyncInterval = time.Minute
+
+// backorderLease is the lease that lets one replica at a time follow backorders.
+const backorderLease = "sync_backorders"
+
+// syncBackorders updates the backordered lines of open orders every
+// backorderSyncInterval until ctx is done. Only the replica holding the backorder
+// lease does so.
+func (s *server) syncBackorders(ctx context.Context) {
+	holder := newInstanceID()
+	ticker := time.NewTicker(backorderSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		held, err := acquireLease(ctx, s.db, backorderLease, holder, 2*backorderSyncInterval)
+		if err != nil {
+			log.Printf("Failed to acquire the %s lease: %v", backorderLease, err)
+		} else if held {
+			s.syncBackorderedOrders(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncBackorderedOrders refreshes every open order that still has backordered units.
+// Cancelled orders give their backorders up, so their lines are left as they were.
+func (s *server) syncBackorderedOrders(ctx context.Context) {
+	cursor, err := s.db.Collection("orders").Find(ctx,
+		bson.M{
+			"items.backordered": bson.M{"$gt": 0},
+			"status": bson.M{"$in": []string{
+				statusName(pb.OrderStatus_ORDER_STATUS_CONFIRMED),
+				statusName(pb.OrderStatus_ORDER_STATUS_PAID),
+				statusName(pb.OrderStatus_ORDER_STATUS_PARTIALLY_SHIPPED),
+			}},
+		},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		log.Printf("Failed to look up backordered orders: %v", err)
+		return
+	}
+	var backordered []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &backordered); err != nil {
+		log.Printf("Failed to decode backordered orders: %v", err)
+		return
+	}
+
+	for _, order := range backordered {
+		if err := s.refreshBackorders(ctx, order.ID); err != nil {
+			log.Printf("Failed to update backorders of order %s: %v", order.ID.Hex(), err)
+		}
+	}
+}
+
+// refreshBackorders sets the backordered units of an order's lines to what the
+// Product Service still owes the order. An order changed meanwhile is left for the
+// next run.
+func (s *server) refreshBackorders(ctx context.Context, id primitive.ObjectID) error {
+	var order orderModel
+	if err := s.db.Collection("orders").FindOne(ctx, bson.M{"_id": id}).Decode(&order); err != nil {
+		return err
+	}
+
+	response, err := s.productClient.ListBackorders(ctx, &pb.ListBackordersRequest{
+		OrderId:         id.Hex(),
+		OutstandingOnly: true,
+	})
+	if err != nil {
+		return err
+	}
+	owed := map[string]int32{}
+	for _, backorder := range response.Backorders {
+		owed[backorder.ProductId] += backorder.Quantity
+	}
+
+	// Stock only ever reduces what is owed, so lines keep at most their current count
+	items := make([]orderItemModel, len(order.Items))
+	allocated := false
+	for i, item := range order.Items {
+		backordered := min(item.Backordered, owed[item.ProductID])
+		owed[item.ProductID] -= backordered
+		if backordered != item.Backordered {
+			allocated = true
+		}
+		item.Backordered = backordered
+		items[i] = item
+	}
+	if !allocated {
+		return nil
+	}
+
+	return s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		var updated orderModel
+		err := s.db.Collection("orders").FindOneAndUpdate(sc,
+			bson.M{"_id": id, "updated_at": order.UpdatedAt},
+			bson.M{"$set": bson.M{"items": items, "updated_at": time.Now().UTC()}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&updated)
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return s.enqueueEvent(sc, eventOrderBackorderAllocated, &updated, "", "")
+	})
+}
+
+// ensureBackorderIndexes creates the index for finding orders with backordered lines.
+func ensureBackorderIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("orders").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "items.backordered", Value: 1}},
+	})
+	return err
+}
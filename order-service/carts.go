@@ -0,0 +1,456 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// A cart only stores products and quantities. Every read looks up the current price
+// and stock of its products, so a cart always shows what checking it out would cost;
+// prices are fixed when CheckoutCart places the order through the same path as
+// CreateOrder. Each user has one cart, created on first use. Anonymous carts are
+// deleted by a TTL index once left unchanged for anonymousCartTTL, or when merged
+// into a user's cart on login.
+
+// anonymousCartTTL is how long an anonymous cart is kept after its last change.
+const anonymousCartTTL = 30 * 24 * time.Hour
+
+// maxCartItems bounds the number of products in a cart.
+const maxCartItems = 100
+
+type cartModel struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    string             `bson:"user_id,omitempty"`
+	Items     []cartItemModel    `bson:"items"`
+	CreatedAt time.Time          `bson:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+	ExpiresAt *time.Time         `bson:"expires_at,omitempty"` // Anonymous carts only
+}
+
+type cartItemModel struct {
+	ProductID string `bson:"product_id"`
+	Quantity  int32  `bson:"quantity"`
+}
+
+// cartServer implements the CartService RPCs and checks carts out through the order
+// server.
+type cartServer struct {
+	pb.UnimplementedCartServiceServer
+	db     *mongo.Database
+	orders *server
+}
+
+func (s *cartServer) CreateCart(ctx context.Context, req *pb.CreateCartRequest) (*pb.Cart, error) {
+	if req.UserId != "" {
+		cart, err := s.userCart(ctx, req.UserId)
+		if err != nil {
+			return nil, err
+		}
+		return s.annotate(ctx, cart)
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(anonymousCartTTL)
+	cart := &cartModel{
+		ID:        primitive.NewObjectID(),
+		Items:     []cartItemModel{},
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: &expiresAt,
+	}
+	if _, err := s.db.Collection("carts").InsertOne(ctx, cart); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create cart: %v", err)
+	}
+	return s.annotate(ctx, cart)
+}
+
+func (s *cartServer) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.Cart, error) {
+	cart, err := s.findCart(ctx, req.CartId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return s.annotate(ctx, cart)
+}
+
+func (s *cartServer) AddCartItem(ctx context.Context, req *pb.AddCartItemRequest) (*pb.Cart, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.Quantity < 1 {
+		return nil, status.Error(codes.InvalidArgument, "quantity must be at least 1")
+	}
+
+	// Only products in the catalog can be added; their price and stock are checked on
+	// every read
+	if _, err := s.orders.productClient.GetProduct(ctx, &pb.GetProductRequest{Id: req.ProductId}); err != nil {
+		switch status.Code(err) {
+		case codes.NotFound, codes.InvalidArgument:
+			return nil, status.Error(codes.NotFound, "product not found")
+		default:
+			return nil, status.Errorf(codes.Unavailable, "failed to look up product %s: %v", req.ProductId, err)
+		}
+	}
+
+	return s.changeCart(ctx, req.CartId, req.UserId, func(cart *cartModel) error {
+		return cart.add(req.ProductId, req.Quantity)
+	})
+}
+
+func (s *cartServer) UpdateCartItem(ctx context.Context, req *pb.UpdateCartItemRequest) (*pb.Cart, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.Quantity < 1 {
+		return nil, status.Error(codes.InvalidArgument, "quantity must be at least 1, remove the item instead")
+	}
+
+	return s.changeCart(ctx, req.CartId, req.UserId, func(cart *cartModel) error {
+		i := cart.find(req.ProductId)
+		if i < 0 {
+			return status.Error(codes.NotFound, "product is not in the cart")
+		}
+		cart.Items[i].Quantity = req.Quantity
+		return nil
+	})
+}
+
+func (s *cartServer) RemoveCartItem(ctx context.Context, req *pb.RemoveCartItemRequest) (*pb.Cart, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	return s.changeCart(ctx, req.CartId, req.UserId, func(cart *cartModel) error {
+		i := cart.find(req.ProductId)
+		if i < 0 {
+			return status.Error(codes.NotFound, "product is not in the cart")
+		}
+		cart.Items = append(cart.Items[:i], cart.Items[i+1:]...)
+		return nil
+	})
+}
+
+// MergeCarts moves the items of an anonymous cart into the user's cart and deletes the
+// anonymous cart, in one transaction. A merged cart is gone, so merging it again
+// returns NotFound.
+func (s *cartServer) MergeCarts(ctx context.Context, req *pb.MergeCartsRequest) (*pb.Cart, error) {
+	if req.CartId == "" {
+		return nil, status.Error(codes.InvalidArgument, "cart_id is required")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	var merged *cartModel
+	err := s.orders.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		anonymous, err := s.findCart(sc, req.CartId, "")
+		if err != nil {
+			return err
+		}
+		if anonymous.UserID != "" {
+			return status.Error(codes.FailedPrecondition, "cart belongs to a user, only anonymous carts can be merged")
+		}
+		cart, err := s.userCart(sc, req.UserId)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range anonymous.Items {
+			if err := cart.add(item.ProductID, item.Quantity); err != nil {
+				return err
+			}
+		}
+		cart.UpdatedAt = time.Now().UTC()
+		if _, err := s.db.Collection("carts").ReplaceOne(sc, bson.M{"_id": cart.ID}, cart); err != nil {
+			return status.Errorf(codes.Internal, "failed to update cart: %v", err)
+		}
+		if _, err := s.db.Collection("carts").DeleteOne(sc, bson.M{"_id": anonymous.ID}); err != nil {
+			return status.Errorf(codes.Internal, "failed to delete merged cart: %v", err)
+		}
+		merged = cart
+		return nil
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to merge carts: %v", err)
+	}
+
+	return s.annotate(ctx, merged)
+}
+
+// CheckoutCart places an order for the items of a user's cart and removes them from
+// the cart. The order is placed as CreateOrder would place it, so a cart whose items
+// cannot be ordered fails with the same PreconditionFailure violations, indexed by the
+// position of the item in the cart. A retry with the idempotency key of an order
+// already placed returns that order, even though the cart has been emptied since.
+func (s *cartServer) CheckoutCart(ctx context.Context, req *pb.CheckoutCartRequest) (*pb.Order, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idempotencyKey, err := idempotencyKeyFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if idempotencyKey != "" {
+		var placed orderModel
+		err := s.db.Collection("orders").FindOne(ctx, bson.M{"idempotency_key": idempotencyKey}).Decode(&placed)
+		if err == nil {
+			if placed.UserID != req.UserId {
+				return nil, status.Error(codes.AlreadyExists, "idempotency key was already used for a different order")
+			}
+			return placed.toProto(), nil
+		}
+		if err != mongo.ErrNoDocuments {
+			return nil, status.Errorf(codes.Internal, "failed to look up idempotency key: %v", err)
+		}
+	}
+
+	cart, err := s.findCart(ctx, "", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "cart is empty")
+	}
+
+	orderReq := &pb.CreateOrderRequest{
+		UserId:          req.UserId,
+		CouponCodes:     req.CouponCodes,
+		ShippingAddress: req.ShippingAddress,
+		ShippingOption:  req.ShippingOption,
+	}
+	productIDs := make([]string, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		orderReq.Items = append(orderReq.Items, &pb.OrderItem{ProductId: item.ProductID, Quantity: item.Quantity})
+		productIDs = append(productIDs, item.ProductID)
+	}
+	if err := validateOrderRequest(orderReq); err != nil {
+		return nil, err
+	}
+
+	order, err := s.orders.createOrder(ctx, orderReq, actor, idempotencyKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// The order is placed either way, so a failure only leaves the items in the cart
+	_, err = s.db.Collection("carts").UpdateOne(ctx,
+		bson.M{"_id": cart.ID},
+		bson.M{
+			"$pull": bson.M{"items": bson.M{"product_id": bson.M{"$in": productIDs}}},
+			"$set":  bson.M{"updated_at": time.Now().UTC()},
+		},
+	)
+	if err != nil {
+		log.Printf("Failed to empty cart %s after placing order %s: %v", cart.ID.Hex(), order.Id, err)
+	}
+
+	return order, nil
+}
+
+// findCart loads the cart of a user, created on first use, or the anonymous cart with
+// the given hex id.
+func (s *cartServer) findCart(ctx context.Context, hexID, userID string) (*cartModel, error) {
+	if userID != "" {
+		if hexID != "" {
+			return nil, status.Error(codes.InvalidArgument, "send either cart_id or user_id, not both")
+		}
+		return s.userCart(ctx, userID)
+	}
+	if hexID == "" {
+		return nil, status.Error(codes.InvalidArgument, "cart_id or user_id is required")
+	}
+
+	// Convert string ID to ObjectID
+	id, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid cart id")
+	}
+
+	var cart cartModel
+	err = s.db.Collection("carts").FindOne(ctx, bson.M{"_id": id}).Decode(&cart)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Error(codes.NotFound, "cart not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get cart: %v", err)
+	}
+	return &cart, nil
+}
+
+// userCart loads the cart of a user, creating an empty one if the user has none yet.
+func (s *cartServer) userCart(ctx context.Context, userID string) (*cartModel, error) {
+	now := time.Now().UTC()
+	update := bson.M{"$setOnInsert": bson.M{"items": []cartItemModel{}, "created_at": now, "updated_at": now}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var cart cartModel
+	err := s.db.Collection("carts").FindOneAndUpdate(ctx, bson.M{"user_id": userID}, update, opts).Decode(&cart)
+	if mongo.IsDuplicateKeyError(err) {
+		// A concurrent request created the cart first
+		err = s.db.Collection("carts").FindOne(ctx, bson.M{"user_id": userID}).Decode(&cart)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get cart: %v", err)
+	}
+	return &cart, nil
+}
+
+// changeCart applies change to a cart and stores the result, unless the cart was
+// changed meanwhile. Anonymous carts are kept for another anonymousCartTTL.
+func (s *cartServer) changeCart(ctx context.Context, hexID, userID string,
+	change func(cart *cartModel) error) (*pb.Cart, error) {
+	cart, err := s.findCart(ctx, hexID, userID)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt := cart.UpdatedAt
+	if err := change(cart); err != nil {
+		return nil, err
+	}
+
+	cart.UpdatedAt = time.Now().UTC()
+	if cart.ExpiresAt != nil {
+		expiresAt := cart.UpdatedAt.Add(anonymousCartTTL)
+		cart.ExpiresAt = &expiresAt
+	}
+	result, err := s.db.Collection("carts").ReplaceOne(ctx,
+		bson.M{"_id": cart.ID, "updated_at": updatedAt}, cart)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update cart: %v", err)
+	}
+	if result.MatchedCount == 0 {
+		return nil, status.Error(codes.Aborted, "cart changed concurrently, retry the change")
+	}
+	return s.annotate(ctx, cart)
+}
+
+// annotate returns a cart with the current name, price and stock of its products. An
+// item that could not be ordered as it is gets a problem, and the cart is only ready
+// for checkout when none has one. Items priced in another currency than the first
+// priced item are left out of the subtotal.
+func (s *cartServer) annotate(ctx context.Context, cart *cartModel) (*pb.Cart, error) {
+	out := &pb.Cart{
+		Id:        cart.ID.Hex(),
+		UserId:    cart.UserID,
+		Items:     make([]*pb.CartItem, 0, len(cart.Items)),
+		CreatedAt: cart.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: cart.UpdatedAt.Format(time.RFC3339),
+	}
+	if cart.ExpiresAt != nil {
+		out.ExpiresAt = cart.ExpiresAt.Format(time.RFC3339)
+	}
+
+	var subtotal moneyModel
+	ready := len(cart.Items) > 0
+	for _, item := range cart.Items {
+		line := &pb.CartItem{ProductId: item.ProductID, Quantity: item.Quantity}
+		out.Items = append(out.Items, line)
+
+		product, err := s.orders.productClient.GetProduct(ctx, &pb.GetProductRequest{Id: item.ProductID})
+		if err != nil {
+			switch status.Code(err) {
+			case codes.NotFound, codes.InvalidArgument:
+				line.Problem = "product not found"
+				ready = false
+				continue
+			default:
+				return nil, status.Errorf(codes.Unavailable, "failed to look up product %s: %v", item.ProductID, err)
+			}
+		}
+
+		line.Name = product.Name
+		line.StockQuantity = product.StockQuantity
+		if item.Quantity > product.StockQuantity {
+			line.BackorderedQuantity = item.Quantity - max(product.StockQuantity, 0)
+		}
+		line.Problem = stockProblem(product, item.Quantity)
+		if product.Price == nil {
+			line.Problem = "product has no price"
+		} else {
+			line.UnitPrice = product.Price
+			total, err := moneyFromProto(product.Price).times(item.Quantity)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "total of product %s: %v", item.ProductID, err)
+			}
+			line.LineTotal = total.toProto()
+			sum, err := subtotal.add(total)
+			switch {
+			case err == errCurrencyMismatch:
+				line.Problem = "product is priced in another currency than the rest of the cart"
+			case err != nil:
+				return nil, status.Errorf(codes.InvalidArgument, "cart subtotal: %v", err)
+			default:
+				subtotal = sum
+			}
+		}
+		if line.Problem != "" {
+			ready = false
+		}
+	}
+
+	out.Subtotal = subtotal.toProto()
+	out.ReadyForCheckout = ready
+	return out, nil
+}
+
+// find returns the index of the cart item for productID, or -1.
+func (c *cartModel) find(productID string) int {
+	for i, item := range c.Items {
+		if item.ProductID == productID {
+			return i
+		}
+	}
+	return -1
+}
+
+// add adds quantity units of a product to the cart.
+func (c *cartModel) add(productID string, quantity int32) error {
+	i := c.find(productID)
+	if i < 0 {
+		if len(c.Items) >= maxCartItems {
+			return status.Errorf(codes.FailedPrecondition, "a cart can hold at most %d products", maxCartItems)
+		}
+		c.Items = append(c.Items, cartItemModel{ProductID: productID, Quantity: quantity})
+		return nil
+	}
+	if c.Items[i].Quantity > math.MaxInt32-quantity {
+		return status.Error(codes.InvalidArgument, "quantity is too large")
+	}
+	c.Items[i].Quantity += quantity
+	return nil
+}
+
+// ensureCartIndexes creates the unique index that gives each user one cart and the TTL
+// index that deletes expired anonymous carts.
+func ensureCartIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("carts").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"user_id": bson.M{"$type": "string"}}),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	return err
+}
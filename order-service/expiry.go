@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// expiryInterval is how often stale pending orders are looked for.
+const expiryInterval = time.Minute
+
+// expiryBatchSize bounds the orders cancelled in one run, so a run finishes well within
+// the lease even after a long outage.
+const expiryBatchSize = 100
+
+// expiryLease is the lease that lets one replica at a time cancel stale orders.
+const expiryLease = "expire_pending_orders"
+
+// expirePendingOrders cancels orders that have been pending for longer than ttl, every
+// expiryInterval until ctx is done. Only the replica holding the expiry lease does so.
+func (s *server) expirePendingOrders(ctx context.Context, ttl time.Duration) {
+	holder := newInstanceID()
+	ticker := time.NewTicker(expiryInterval)
+	defer ticker.Stop()
+
+	for {
+		held, err := acquireLease(ctx, s.db, expiryLease, holder, 2*expiryInterval)
+		if err != nil {
+			log.Printf("Failed to acquire the %s lease: %v", expiryLease, err)
+		} else if held {
+			s.cancelStaleOrders(ctx, ttl)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// cancelStaleOrders cancels up to expiryBatchSize orders pending for longer than ttl,
+// oldest first, through the same path as a manual cancellation.
+func (s *server) cancelStaleOrders(ctx context.Context, ttl time.Duration) {
+	cursor, err := s.db.Collection("orders").Find(ctx,
+		bson.M{
+			"status":     statusName(pb.OrderStatus_ORDER_STATUS_PENDING),
+			"created_at": bson.M{"$lt": time.Now().UTC().Add(-ttl)},
+		},
+		options.Find().
+			SetSort(bson.D{{Key: "created_at", Value: 1}}).
+			SetLimit(expiryBatchSize).
+			SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		log.Printf("Failed to look up stale pending orders: %v", err)
+		return
+	}
+	var stale []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &stale); err != nil {
+		log.Printf("Failed to decode stale pending orders: %v", err)
+		return
+	}
+
+	reason := fmt.Sprintf("pending for longer than %s", ttl)
+	for _, order := range stale {
+		_, err := s.cancelOrder(ctx, order.ID, systemActor, reason)
+		switch status.Code(err) {
+		case codes.OK:
+			log.Printf("Cancelled order %s: %s", order.ID.Hex(), reason)
+		case codes.FailedPrecondition, codes.Aborted, codes.NotFound:
+			// The order moved on since it was looked up
+		default:
+			log.Printf("Failed to cancel stale order %s: %v", order.ID.Hex(), err)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// exportBatchSize is how many orders ExportOrders reads from MongoDB at a time. Only
+// one batch is held in memory; the client's pace sets how fast the next is read.
+const exportBatchSize = 500
+
+// ExportOrders streams the orders matching a ListOrders request in its sort order,
+// reading them through a single cursor.
+func (s *server) ExportOrders(req *pb.ListOrdersRequest, stream pb.OrderService_ExportOrdersServer) error {
+	ctx := stream.Context()
+
+	filter, err := listOrdersFilter(req)
+	if err != nil {
+		return err
+	}
+	sort, err := listOrdersSort(req)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := s.db.Collection("orders").Find(ctx, filter,
+		options.Find().SetSort(sort).SetBatchSize(exportBatchSize))
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to export orders: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var order orderModel
+		if err := cursor.Decode(&order); err != nil {
+			return status.Errorf(codes.Internal, "failed to decode order: %v", err)
+		}
+		if err := stream.Send(order.toProto()); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Errorf(codes.Internal, "failed to export orders: %v", err)
+	}
+	return nil
+}
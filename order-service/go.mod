@@ -3,12 +3,16 @@ module github.com/order-management/order-service
 go 1.21
 
 require (
+	github.com/golang-jwt/jwt/v5 v5.0.0
 	github.com/order-management/proto v0.0.0
 	go.mongodb.org/mongo-driver v1.13.1
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20231002182017-d307bd883b97
 	google.golang.org/grpc v1.58.2
+	google.golang.org/protobuf v1.32.0
 )
 
 require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/golang/protobuf v1.5.3 // indirect
 	github.com/golang/snappy v0.0.1 // indirect
 	github.com/klauspost/compress v1.13.6 // indirect
@@ -22,8 +26,6 @@ require (
 	golang.org/x/sync v0.4.0 // indirect
 	golang.org/x/sys v0.13.0 // indirect
 	golang.org/x/text v0.13.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20231002182017-d307bd883b97 // indirect
-	google.golang.org/protobuf v1.32.0 // indirect
 )
 
 replace github.com/order-management/proto => ../proto
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// idempotencyKeyHeader is the gRPC metadata key the API gateway uses to forward the
+// client's Idempotency-Key header.
+const idempotencyKeyHeader = "idempotency-key"
+
+const maxIdempotencyKeyLength = 255
+
+// idempotencyKeyFromContext returns the idempotency key sent with the request, if any.
+func idempotencyKeyFromContext(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	values := md.Get(idempotencyKeyHeader)
+	if len(values) == 0 || values[0] == "" {
+		return "", nil
+	}
+	if len(values[0]) > maxIdempotencyKeyLength {
+		return "", status.Errorf(codes.InvalidArgument, "idempotency key must be at most %d characters", maxIdempotencyKeyLength)
+	}
+	return values[0], nil
+}
+
+// requestHash fingerprints the parts of a CreateOrderRequest that determine the order,
+// so a retry can be told apart from a different request reusing the same key.
+func requestHash(req *pb.CreateOrderRequest) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "user_id=%s\n", req.UserId)
+	for _, item := range req.Items {
+		fmt.Fprintf(h, "item=%s:%d\n", item.ProductId, item.Quantity)
+	}
+	// Only written when present so keys stored before coupons existed still match
+	for _, code := range req.CouponCodes {
+		fmt.Fprintf(h, "coupon=%s\n", normalizeCouponCode(code))
+	}
+	if a := req.ShippingAddress; a != nil {
+		fmt.Fprintf(h, "address=%q,%q,%q,%q,%q,%q\n", a.Line1, a.Line2, a.City, a.Region, a.PostalCode, a.Country)
+	}
+	if req.ShippingOption != "" {
+		fmt.Fprintf(h, "shipping=%s\n", req.ShippingOption)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// findIdempotentOrder returns the order previously created with key, or nil if there is
+// none. A stored order whose request hash differs from hash is reported as AlreadyExists.
+func (s *server) findIdempotentOrder(ctx context.Context, key, hash string) (*orderModel, error) {
+	var order orderModel
+	err := s.db.Collection("orders").FindOne(ctx, bson.M{"idempotency_key": key}).Decode(&order)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to look up idempotency key: %v", err)
+	}
+
+	if order.RequestHash != hash {
+		return nil, status.Error(codes.AlreadyExists, "idempotency key was already used for a different order")
+	}
+	return &order, nil
+}
+
+// ensureIdempotencyIndex creates the unique index on idempotency_key. Orders without a
+// key are excluded so they don't collide with each other.
+func ensureIdempotencyIndex(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("orders").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "idempotency_key", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"idempotency_key": bson.M{"$type": "string"}}),
+	})
+	return err
+}
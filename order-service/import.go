@@ -0,0 +1,525 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// Imported orders are historical. They keep the status, dates and prices given in the
+// file and are stored as they are: stock is neither checked nor reserved, no
+// promotions, taxes or shipping rates are applied and no events are published. They
+// are marked imported, so cancelling one releases no stock and its items cannot be
+// changed. Only the user and the products must exist. Each order is stored with the
+// idempotency key import:<external_id>, so importing a file again skips the orders
+// already imported.
+//
+// Orders are read, checked and stored one at a time as the file streams in, so files
+// of any size can be imported. An order with errors is reported and left out; the
+// others are still imported.
+
+// maxReportedImportErrors bounds the errors an ImportOrdersResponse lists.
+const maxReportedImportErrors = 1000
+
+// importKeyPrefix prefixes the external id of an imported order in its idempotency key.
+const importKeyPrefix = "import:"
+
+// importCSVColumns are the columns a CSV import file must have. It may also have
+// updated_at, shipping_minor and tax_minor; other columns are ignored.
+var importCSVColumns = []string{
+	"external_id", "user_id", "status", "created_at", "currency", "product_id", "quantity", "unit_price_minor",
+}
+
+// importCSVOrderColumns are the columns that describe the order rather than the item,
+// and must be the same on every row of an order.
+var importCSVOrderColumns = []string{
+	"user_id", "status", "created_at", "updated_at", "currency", "shipping_minor", "tax_minor",
+}
+
+// importOrder is one order of an import file. The JSON format is an array of these.
+type importOrder struct {
+	ExternalID    string       `json:"external_id"`
+	UserID        string       `json:"user_id"`
+	Status        string       `json:"status"`
+	CreatedAt     string       `json:"created_at"`
+	UpdatedAt     string       `json:"updated_at"`
+	Currency      string       `json:"currency"`
+	ShippingMinor int64        `json:"shipping_minor"`
+	TaxMinor      int64        `json:"tax_minor"`
+	Items         []importItem `json:"items"`
+
+	row    int
+	errors []string
+}
+
+type importItem struct {
+	ProductID      string `json:"product_id"`
+	Quantity       int32  `json:"quantity"`
+	UnitPriceMinor int64  `json:"unit_price_minor"`
+}
+
+func (o *importOrder) errorf(format string, args ...interface{}) {
+	o.errors = append(o.errors, fmt.Sprintf(format, args...))
+}
+
+// importFileError reports a file that cannot be read any further.
+type importFileError struct {
+	row     int
+	message string
+}
+
+func (e *importFileError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.row, e.message)
+}
+
+// importLookups remembers which users and products exist, so each is looked up once
+// per import.
+type importLookups struct {
+	users    map[string]bool
+	products map[string]bool
+}
+
+func (s *server) ImportOrders(stream pb.OrderService_ImportOrdersServer) error {
+	ctx := stream.Context()
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if actor == "" {
+		actor = systemActor
+	}
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "import file is empty")
+	}
+	if err != nil {
+		return err
+	}
+	var read func(r io.Reader, emit func(order *importOrder) error) error
+	switch first.Format {
+	case pb.ImportFormat_IMPORT_FORMAT_CSV:
+		read = readImportCSV
+	case pb.ImportFormat_IMPORT_FORMAT_JSON:
+		read = readImportJSON
+	default:
+		return status.Error(codes.InvalidArgument, "format must be IMPORT_FORMAT_CSV or IMPORT_FORMAT_JSON")
+	}
+
+	response := &pb.ImportOrdersResponse{DryRun: first.DryRun}
+	lookups := &importLookups{users: map[string]bool{}, products: map[string]bool{}}
+	seen := map[string]bool{}
+	err = read(&importReader{stream: stream, buf: first.Data}, func(order *importOrder) error {
+		response.Orders++
+		if order.ExternalID != "" {
+			if seen[order.ExternalID] {
+				order.errorf("external_id %s appears more than once in the file", order.ExternalID)
+			}
+			seen[order.ExternalID] = true
+		}
+
+		imported, err := s.importOrder(ctx, order, first.DryRun, actor, lookups)
+		if err != nil {
+			return err
+		}
+		switch {
+		case len(order.errors) > 0:
+			response.Failed++
+			addImportError(response, order.row, order.ExternalID, order.errors)
+		case imported:
+			response.Imported++
+		default:
+			response.Skipped++
+		}
+		return nil
+	})
+	var fileErr *importFileError
+	if errors.As(err, &fileErr) {
+		// Orders read before the error have been handled
+		addImportError(response, fileErr.row, "", []string{fileErr.message})
+		err = nil
+	}
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "failed to import orders: %v", err)
+	}
+
+	if !response.DryRun {
+		log.Printf("Imported %d of %d orders for %s (%d skipped, %d failed)",
+			response.Imported, response.Orders, actor, response.Skipped, response.Failed)
+	}
+	return stream.SendAndClose(response)
+}
+
+// importOrder checks an order of an import file and, unless dryRun is set, stores it.
+// It reports whether the order was, or would be, imported; orders imported before are
+// not. Problems with the order are added to its errors; the returned error means the
+// import cannot go on.
+func (s *server) importOrder(ctx context.Context, order *importOrder, dryRun bool,
+	actor string, lookups *importLookups) (bool, error) {
+	if order.ExternalID != "" {
+		err := s.db.Collection("orders").FindOne(ctx, bson.M{"idempotency_key": importKeyPrefix + order.ExternalID}).Err()
+		if err == nil {
+			return false, nil
+		}
+		if err != mongo.ErrNoDocuments {
+			return false, status.Errorf(codes.Internal, "failed to look up imported order: %v", err)
+		}
+	}
+
+	model, err := s.importOrderModel(ctx, order, actor, lookups)
+	if err != nil || len(order.errors) > 0 || dryRun {
+		return err == nil && len(order.errors) == 0, err
+	}
+
+	if _, err := s.db.Collection("orders").InsertOne(ctx, model); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			// Imported concurrently
+			return false, nil
+		}
+		return false, status.Errorf(codes.Internal, "failed to store order: %v", err)
+	}
+	return true, nil
+}
+
+// importOrderModel checks an order of an import file and returns it as it would be
+// stored. Problems with the order are added to its errors.
+func (s *server) importOrderModel(ctx context.Context, order *importOrder, actor string,
+	lookups *importLookups) (*orderModel, error) {
+	if order.ExternalID == "" {
+		order.errorf("external_id is required")
+	}
+
+	if order.UserID == "" {
+		order.errorf("user_id is required")
+	} else {
+		exists, err := lookups.exists(lookups.users, order.UserID, func() error {
+			_, err := s.userClient.GetUser(ctx, &pb.GetUserRequest{Id: order.UserID})
+			return err
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to look up user %s: %v", order.UserID, err)
+		}
+		if !exists {
+			order.errorf("user %s not found", order.UserID)
+		}
+	}
+
+	orderStatus := strings.ToUpper(strings.TrimSpace(order.Status))
+	value, ok := pb.OrderStatus_value["ORDER_STATUS_"+strings.TrimPrefix(orderStatus, "ORDER_STATUS_")]
+	switch st := pb.OrderStatus(value); {
+	case !ok || st == pb.OrderStatus_ORDER_STATUS_UNSPECIFIED:
+		order.errorf("unknown status %q", order.Status)
+	case st == pb.OrderStatus_ORDER_STATUS_PENDING:
+		// Pending orders are placed by the saga; an imported one would never be confirmed
+		order.errorf("pending orders cannot be imported")
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, order.CreatedAt)
+	if err != nil {
+		order.errorf("created_at must be an RFC 3339 timestamp")
+	}
+	updatedAt := createdAt
+	if order.UpdatedAt != "" {
+		if updatedAt, err = time.Parse(time.RFC3339, order.UpdatedAt); err != nil {
+			order.errorf("updated_at must be an RFC 3339 timestamp")
+		} else if updatedAt.Before(createdAt) {
+			order.errorf("updated_at cannot be before created_at")
+		}
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(order.Currency))
+	if len(currency) != 3 || strings.Trim(currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		order.errorf("currency must be a three-letter ISO 4217 code")
+	}
+	if order.ShippingMinor < 0 || order.TaxMinor < 0 {
+		order.errorf("shipping_minor and tax_minor cannot be negative")
+	}
+
+	if len(order.Items) == 0 {
+		order.errorf("order must contain at least one item")
+	}
+	items := make([]orderItemModel, 0, len(order.Items))
+	for i, item := range order.Items {
+		switch {
+		case item.ProductID == "":
+			order.errorf("item %d: product_id is required", i+1)
+			continue
+		case item.Quantity < 1:
+			order.errorf("item %d: quantity must be at least 1", i+1)
+		case item.UnitPriceMinor < 0:
+			order.errorf("item %d: unit_price_minor cannot be negative", i+1)
+		}
+
+		exists, err := lookups.exists(lookups.products, item.ProductID, func() error {
+			_, err := s.productClient.GetProduct(ctx, &pb.GetProductRequest{Id: item.ProductID})
+			return err
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to look up product %s: %v", item.ProductID, err)
+		}
+		if !exists {
+			order.errorf("item %d: product %s not found", i+1, item.ProductID)
+		}
+
+		items = append(items, orderItemModel{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     moneyModel{UnitsMinor: item.UnitPriceMinor, Currency: currency},
+		})
+	}
+	if len(order.errors) > 0 {
+		return nil, nil
+	}
+
+	subtotal, err := itemsTotal(items)
+	tax := moneyModel{UnitsMinor: order.TaxMinor, Currency: currency}
+	shipping := moneyModel{UnitsMinor: order.ShippingMinor, Currency: currency}
+	total := subtotal
+	if err == nil {
+		if total, err = total.add(tax); err == nil {
+			total, err = total.add(shipping)
+		}
+	}
+	if err != nil {
+		order.errorf("order total is out of range")
+		return nil, nil
+	}
+
+	model := &orderModel{
+		UserID:         order.UserID,
+		Items:          items,
+		Status:         statusName(pb.OrderStatus(value)),
+		TotalAmount:    total,
+		Subtotal:       &subtotal,
+		CreatedAt:      createdAt.UTC(),
+		UpdatedAt:      updatedAt.UTC(),
+		IdempotencyKey: importKeyPrefix + order.ExternalID,
+		Imported:       true,
+		StatusHistory: []statusChangeModel{{
+			NewStatus: statusName(pb.OrderStatus(value)),
+			ActorID:   actor,
+			Reason:    "imported as " + order.ExternalID,
+			ChangedAt: updatedAt.UTC(),
+		}},
+	}
+	if order.TaxMinor > 0 {
+		model.TaxLines = []taxLineModel{{Name: "Tax", TaxableAmount: subtotal, Amount: tax}}
+	}
+	if order.ShippingMinor > 0 {
+		model.Shipping = &shippingOptionModel{ID: "imported", Name: "Shipping", Cost: shipping}
+	}
+	return model, nil
+}
+
+// exists reports whether the user or product with the given id exists, asking get only
+// the first time. Ids the service rejects as invalid don't exist.
+func (l *importLookups) exists(known map[string]bool, id string, get func() error) (bool, error) {
+	if exists, ok := known[id]; ok {
+		return exists, nil
+	}
+	err := get()
+	if code := status.Code(err); code == codes.NotFound || code == codes.InvalidArgument {
+		known[id] = false
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	known[id] = true
+	return true, nil
+}
+
+func addImportError(response *pb.ImportOrdersResponse, row int, externalID string, messages []string) {
+	if len(response.Errors) >= maxReportedImportErrors {
+		response.ErrorsTruncated = true
+		return
+	}
+	response.Errors = append(response.Errors, &pb.ImportError{
+		Row:        int32(row),
+		ExternalId: externalID,
+		Messages:   messages,
+	})
+}
+
+// readImportCSV reads the orders of a CSV file with a header row and one row per
+// order item. Consecutive rows with the same external_id make up one order.
+func readImportCSV(r io.Reader, emit func(order *importOrder) error) error {
+	reader := csv.NewReader(r)
+	// A row with too few or too many fields fails its order, not the file
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return importReadError(1, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range importCSVColumns {
+		if _, ok := columns[name]; !ok {
+			return &importFileError{row: 1, message: "missing column " + name}
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var current *importOrder
+	var orderFields []string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			err = importReadError(0, err)
+			var fileErr *importFileError
+			if current != nil && errors.As(err, &fileErr) {
+				// The rows read so far may not hold all of the current order
+				fileErr.message += fmt.Sprintf("; orders from row %d on were not read", current.row)
+			}
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+
+		if current == nil || field(record, "external_id") != current.ExternalID {
+			if current != nil {
+				if err := emit(current); err != nil {
+					return err
+				}
+			}
+			current = &importOrder{
+				ExternalID: field(record, "external_id"),
+				UserID:     field(record, "user_id"),
+				Status:     field(record, "status"),
+				CreatedAt:  field(record, "created_at"),
+				UpdatedAt:  field(record, "updated_at"),
+				Currency:   field(record, "currency"),
+				row:        line,
+			}
+			for name, target := range map[string]*int64{"shipping_minor": &current.ShippingMinor, "tax_minor": &current.TaxMinor} {
+				if value := field(record, name); value != "" {
+					if *target, err = strconv.ParseInt(value, 10, 64); err != nil {
+						current.errorf("line %d: %s must be an integer", line, name)
+					}
+				}
+			}
+			orderFields = orderFields[:0]
+			for _, name := range importCSVOrderColumns {
+				orderFields = append(orderFields, field(record, name))
+			}
+		} else {
+			for i, name := range importCSVOrderColumns {
+				if field(record, name) != orderFields[i] {
+					current.errorf("line %d: %s differs from the first row of the order", line, name)
+				}
+			}
+		}
+		if len(record) != len(header) {
+			current.errorf("line %d: has %d fields, the header has %d", line, len(record), len(header))
+		}
+
+		item := importItem{ProductID: field(record, "product_id")}
+		quantity, err := strconv.ParseInt(field(record, "quantity"), 10, 32)
+		if err != nil {
+			current.errorf("line %d: quantity must be an integer", line)
+		}
+		item.Quantity = int32(quantity)
+		if item.UnitPriceMinor, err = strconv.ParseInt(field(record, "unit_price_minor"), 10, 64); err != nil {
+			current.errorf("line %d: unit_price_minor must be an integer", line)
+		}
+		current.Items = append(current.Items, item)
+	}
+
+	if current != nil {
+		return emit(current)
+	}
+	return nil
+}
+
+// readImportJSON reads the orders of a JSON file holding an array of importOrder
+// objects, one at a time.
+func readImportJSON(r io.Reader, emit func(order *importOrder) error) error {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return importReadError(1, err)
+	}
+	if token != json.Delim('[') {
+		return &importFileError{row: 1, message: "the file must hold a JSON array of orders"}
+	}
+
+	for row := 1; decoder.More(); row++ {
+		order := &importOrder{row: row}
+		if err := decoder.Decode(order); err != nil {
+			// The decoder carries on after a value of the wrong type
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) {
+				return importReadError(row, err)
+			}
+			order.errorf("%s must be a JSON %s", typeErr.Field, typeErr.Type)
+		}
+		if err := emit(order); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importReadError turns an error from reading an import file into an importFileError,
+// unless it came from the stream rather than from the file's contents.
+func importReadError(row int, err error) error {
+	var parseErr *csv.ParseError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &parseErr):
+		return &importFileError{row: parseErr.StartLine, message: parseErr.Err.Error()}
+	case errors.As(err, &syntaxErr), err == io.ErrUnexpectedEOF:
+		return &importFileError{row: row, message: "invalid JSON: " + err.Error()}
+	}
+	return err
+}
+
+// importReader reads the data of the chunks of an ImportOrders stream in turn.
+type importReader struct {
+	stream pb.OrderService_ImportOrdersServer
+	buf    []byte
+}
+
+func (r *importReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readAll reads an import file with read and returns the orders it emitted.
+func readAll(t *testing.T, read func(r io.Reader, emit func(order *importOrder) error) error, file string) ([]*importOrder, error) {
+	t.Helper()
+	var orders []*importOrder
+	err := read(strings.NewReader(file), func(order *importOrder) error {
+		orders = append(orders, order)
+		return nil
+	})
+	return orders, err
+}
+
+// importSummary is the part of an importOrder the tests compare.
+type importSummary struct {
+	ExternalID string
+	Row        int
+	Items      []importItem
+	Errors     []string
+}
+
+func summarize(orders []*importOrder) []importSummary {
+	summaries := []importSummary{}
+	for _, order := range orders {
+		summaries = append(summaries, importSummary{
+			ExternalID: order.ExternalID,
+			Row:        order.row,
+			Items:      order.Items,
+			Errors:     order.errors,
+		})
+	}
+	return summaries
+}
+
+const importCSVHeader = "external_id,user_id,status,created_at,currency,product_id,quantity,unit_price_minor,shipping_minor\n"
+
+func TestReadImportCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want []importSummary
+	}{
+		{
+			name: "rows of an order are grouped",
+			file: importCSVHeader +
+				"A,u1,delivered,2023-01-02T10:00:00Z,USD,p1,2,1250,499\n" +
+				"A,u1,delivered,2023-01-02T10:00:00Z,USD,p2,1,300,499\n" +
+				"B,u2,paid,2023-01-03T10:00:00Z,USD,p1,1,1250,\n",
+			want: []importSummary{
+				{ExternalID: "A", Row: 2, Items: []importItem{{"p1", 2, 1250}, {"p2", 1, 300}}},
+				{ExternalID: "B", Row: 4, Items: []importItem{{"p1", 1, 1250}}},
+			},
+		},
+		{
+			name: "rows of an order not next to each other are separate orders",
+			file: importCSVHeader +
+				"A,u1,delivered,2023-01-02T10:00:00Z,USD,p1,2,1250,\n" +
+				"B,u2,paid,2023-01-03T10:00:00Z,USD,p1,1,1250,\n" +
+				"A,u1,delivered,2023-01-02T10:00:00Z,USD,p2,1,300,\n",
+			want: []importSummary{
+				{ExternalID: "A", Row: 2, Items: []importItem{{"p1", 2, 1250}}},
+				{ExternalID: "B", Row: 3, Items: []importItem{{"p1", 1, 1250}}},
+				{ExternalID: "A", Row: 4, Items: []importItem{{"p2", 1, 300}}},
+			},
+		},
+		{
+			name: "order columns must match the first row",
+			file: importCSVHeader +
+				"A,u1,delivered,2023-01-02T10:00:00Z,USD,p1,2,1250,499\n" +
+				"A,u1,shipped,2023-01-02T10:00:00Z,USD,p2,1,300,0\n",
+			want: []importSummary{{
+				ExternalID: "A", Row: 2, Items: []importItem{{"p1", 2, 1250}, {"p2", 1, 300}},
+				Errors: []string{
+					"line 3: status differs from the first row of the order",
+					"line 3: shipping_minor differs from the first row of the order",
+				},
+			}},
+		},
+		{
+			name: "numbers that don't parse",
+			file: importCSVHeader +
+				"A,u1,delivered,2023-01-02T10:00:00Z,USD,p1,two,12.50,free\n",
+			want: []importSummary{{
+				ExternalID: "A", Row: 2, Items: []importItem{{"p1", 0, 0}},
+				Errors: []string{
+					"line 2: shipping_minor must be an integer",
+					"line 2: quantity must be an integer",
+					"line 2: unit_price_minor must be an integer",
+				},
+			}},
+		},
+		{
+			name: "header is case and space insensitive, other columns are ignored",
+			file: " External_ID ,USER_ID,Status,created_at,currency,notes,product_id,quantity,unit_price_minor\n" +
+				"A,u1,delivered,2023-01-02T10:00:00Z,USD,gift,p1,1,100\n",
+			want: []importSummary{{ExternalID: "A", Row: 2, Items: []importItem{{"p1", 1, 100}}}},
+		},
+		{
+			name: "quoted fields spanning lines",
+			file: importCSVHeader +
+				"A,u1,delivered,2023-01-02T10:00:00Z,USD,\"p\n1\",1,100,\n" +
+				"B,u1,delivered,2023-01-02T10:00:00Z,USD,p2,1,100,\n",
+			want: []importSummary{
+				{ExternalID: "A", Row: 2, Items: []importItem{{"p\n1", 1, 100}}},
+				{ExternalID: "B", Row: 4, Items: []importItem{{"p2", 1, 100}}},
+			},
+		},
+		{
+			name: "wrong number of fields fails the order and reading goes on",
+			file: importCSVHeader +
+				"A,u1,delivered,2023-01-02T10:00:00Z,USD,p1,1,100,,gift\n" +
+				"B,u1,delivered\n" +
+				"C,u1,delivered,2023-01-02T10:00:00Z,USD,p1,1,100,\n",
+			want: []importSummary{
+				{ExternalID: "A", Row: 2, Items: []importItem{{"p1", 1, 100}},
+					Errors: []string{"line 2: has 10 fields, the header has 9"}},
+				{ExternalID: "B", Row: 3, Items: []importItem{{"", 0, 0}},
+					Errors: []string{
+						"line 3: has 3 fields, the header has 9",
+						"line 3: quantity must be an integer",
+						"line 3: unit_price_minor must be an integer",
+					}},
+				{ExternalID: "C", Row: 4, Items: []importItem{{"p1", 1, 100}}},
+			},
+		},
+		{
+			name: "empty file",
+			file: "",
+			want: []importSummary{},
+		},
+		{
+			name: "header only",
+			file: importCSVHeader,
+			want: []importSummary{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders, err := readAll(t, readImportCSV, tt.file)
+			if err != nil {
+				t.Fatalf("readImportCSV() returned error %v", err)
+			}
+			if got := summarize(orders); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readImportCSV() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadImportCSVFileErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		wantOrders []string
+		wantErr    importFileError
+	}{
+		{
+			name:    "missing column",
+			file:    "external_id,user_id,status,created_at,currency,product_id,quantity\n",
+			wantErr: importFileError{row: 1, message: "missing column unit_price_minor"},
+		},
+		{
+			name: "bare quote",
+			file: importCSVHeader +
+				"A,u1,delivered,2023-01-02T10:00:00Z,USD,p\"1,1,100,\n",
+			wantErr: importFileError{row: 2, message: `bare " in non-quoted-field`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders, err := readAll(t, readImportCSV, tt.file)
+			var fileErr *importFileError
+			if !errors.As(err, &fileErr) {
+				t.Fatalf("readImportCSV() error = %v, want an importFileError", err)
+			}
+			if *fileErr != tt.wantErr {
+				t.Errorf("readImportCSV() error = %+v, want %+v", *fileErr, tt.wantErr)
+			}
+			var ids []string
+			for _, order := range orders {
+				ids = append(ids, order.ExternalID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantOrders) {
+				t.Errorf("orders read before the error = %v, want %v", ids, tt.wantOrders)
+			}
+		})
+	}
+}
+
+func TestReadImportJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want []importSummary
+	}{
+		{
+			name: "orders in turn",
+			file: `[
+				{"external_id": "A", "items": [{"product_id": "p1", "quantity": 2, "unit_price_minor": 1250}]},
+				{"external_id": "B", "items": [{"product_id": "p2", "quantity": 1, "unit_price_minor": 300}]}
+			]`,
+			want: []importSummary{
+				{ExternalID: "A", Row: 1, Items: []importItem{{"p1", 2, 1250}}},
+				{ExternalID: "B", Row: 2, Items: []importItem{{"p2", 1, 300}}},
+			},
+		},
+		{
+			name: "a value of the wrong type fails only its order",
+			file: `[
+				{"external_id": "A", "shipping_minor": "free", "items": [{"product_id": "p1", "quantity": 2, "unit_price_minor": 1250}]},
+				{"external_id": "B", "items": [{"product_id": "p2", "quantity": 1, "unit_price_minor": 300}]}
+			]`,
+			want: []importSummary{
+				{ExternalID: "A", Row: 1, Items: []importItem{{"p1", 2, 1250}},
+					Errors: []string{"shipping_minor must be a JSON int64"}},
+				{ExternalID: "B", Row: 2, Items: []importItem{{"p2", 1, 300}}},
+			},
+		},
+		{
+			name: "empty array",
+			file: `[]`,
+			want: []importSummary{},
+		},
+		{
+			name: "empty file",
+			file: ``,
+			want: []importSummary{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders, err := readAll(t, readImportJSON, tt.file)
+			if err != nil {
+				t.Fatalf("readImportJSON() returned error %v", err)
+			}
+			if got := summarize(orders); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readImportJSON() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadImportJSONFileErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		wantOrders []string
+		wantRow    int
+	}{
+		{name: "not an array", file: `{"external_id": "A"}`, wantRow: 1},
+		{name: "not JSON", file: `external_id,user_id`, wantRow: 1},
+		{
+			name:       "syntax error in a later order",
+			file:       `[{"external_id": "A"}, {"external_id": "B",}]`,
+			wantOrders: []string{"A"},
+			wantRow:    2,
+		},
+		{
+			name:       "cut off",
+			file:       `[{"external_id": "A"}, {"external_id": "B"`,
+			wantOrders: []string{"A"},
+			wantRow:    2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders, err := readAll(t, readImportJSON, tt.file)
+			var fileErr *importFileError
+			if !errors.As(err, &fileErr) {
+				t.Fatalf("readImportJSON() error = %v, want an importFileError", err)
+			}
+			if fileErr.row != tt.wantRow {
+				t.Errorf("readImportJSON() error at row %d, want row %d", fileErr.row, tt.wantRow)
+			}
+			var ids []string
+			for _, order := range orders {
+				ids = append(ids, order.ExternalID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantOrders) {
+				t.Errorf("orders read before the error = %v, want %v", ids, tt.wantOrders)
+			}
+		})
+	}
+}
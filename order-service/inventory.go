@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// checkItems confirms that every requested product exists and has enough stock, and
+// returns a copy of the items priced from the catalog along with the products by ID.
+// Prices sent by the client are ignored. All problems are reported together as
+// PreconditionFailure violations, one per item.
+func (s *server) checkItems(ctx context.Context, items []*pb.OrderItem) ([]*pb.OrderItem, map[string]*pb.Product, error) {
+	priced := make([]*pb.OrderItem, 0, len(items))
+	products := map[string]*pb.Product{}
+	var violations []*errdetails.PreconditionFailure_Violation
+	for i, item := range items {
+		product, err := s.productClient.GetProduct(ctx, &pb.GetProductRequest{Id: item.ProductId})
+		if err != nil {
+			switch status.Code(err) {
+			case codes.NotFound, codes.InvalidArgument:
+				violations = append(violations, itemViolation(i, item.ProductId, "product not found"))
+				continue
+			default:
+				return nil, nil, status.Errorf(codes.Unavailable, "failed to look up product %s: %v", item.ProductId, err)
+			}
+		}
+
+		if product.Price == nil {
+			violations = append(violations, itemViolation(i, item.ProductId, "product has no price"))
+			continue
+		}
+		if problem := stockProblem(product, item.Quantity); problem != "" {
+			violations = append(violations, itemViolation(i, item.ProductId, problem))
+		}
+
+		products[item.ProductId] = product
+		priced = append(priced, &pb.OrderItem{
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+			Price:     product.Price,
+		})
+	}
+
+	if len(violations) > 0 {
+		return nil, nil, itemsError(violations)
+	}
+	return priced, products, nil
+}
+
+// stockProblem describes why quantity units of product cannot be ordered, or returns
+// an empty string if they can, from stock or as a backorder its policy allows.
+func stockProblem(product *pb.Product, quantity int32) string {
+	missing := quantity - product.StockQuantity
+	if missing <= 0 {
+		return ""
+	}
+	switch {
+	case product.BackorderPolicy == pb.BackorderPolicy_BACKORDER_POLICY_NONE:
+		return fmt.Sprintf("insufficient stock: requested %d, available %d", quantity, product.StockQuantity)
+	case product.BackorderLimit > 0 && product.BackorderedQuantity+missing > product.BackorderLimit:
+		return fmt.Sprintf("insufficient stock: requested %d, available %d and %d more as backorder",
+			quantity, product.StockQuantity, product.BackorderLimit-product.BackorderedQuantity)
+	}
+	return ""
+}
+
+// reserveItem takes stock for a single order item and returns how many of its units
+// were backordered. A non-empty key makes the reservation idempotent. Stock and lookup
+// problems are reported as a PreconditionFailure for that item.
+func (s *server) reserveItem(ctx context.Context, orderID primitive.ObjectID, index int, item orderItemModel, key string) (int32, error) {
+	reservation, err := s.productClient.ReserveStock(ctx, &pb.ReserveStockRequest{
+		ProductId:      item.ProductID,
+		OrderId:        orderID.Hex(),
+		Quantity:       item.Quantity,
+		ReservationKey: key,
+	})
+	if err != nil {
+		if code := status.Code(err); code == codes.FailedPrecondition || code == codes.NotFound {
+			return 0, itemsError([]*errdetails.PreconditionFailure_Violation{
+				itemViolation(index, item.ProductID, status.Convert(err).Message()),
+			})
+		}
+		return 0, status.Errorf(codes.Unavailable, "failed to reserve stock for product %s: %v", item.ProductID, err)
+	}
+	return reservation.Backordered, nil
+}
+
+// releaseItem gives back the quantity of a single order item. Units still backordered
+// for the order are cancelled first, the rest is returned to inventory. With a key,
+// only the reservation made with that key is released, and only once.
+func (s *server) releaseItem(ctx context.Context, orderID primitive.ObjectID, item orderItemModel, key string) error {
+	_, err := s.productClient.ReleaseStock(ctx, &pb.ReleaseStockRequest{
+		ProductId:      item.ProductID,
+		OrderId:        orderID.Hex(),
+		Quantity:       item.Quantity,
+		ReservationKey: key,
+	})
+	return err
+}
+
+// releaseOrderStock returns the stock held by a cancelled order. While the order's saga
+// is still in flight the saga releases its own reservations when it fails to confirm,
+// so nothing is released here, and imported orders never reserved any. Failures are
+// logged because the cancellation itself has already been recorded.
+func (s *server) releaseOrderStock(ctx context.Context, order *orderModel) {
+	if order.Imported {
+		return
+	}
+
+	var saga sagaModel
+	err := s.db.Collection("order_sagas").FindOne(ctx, bson.M{"order_id": order.ID}).Decode(&saga)
+	if err == nil && saga.State != sagaStateCompleted {
+		return
+	}
+	if err != nil && err != mongo.ErrNoDocuments {
+		log.Printf("Failed to look up saga for order %s, stock not released: %v", order.ID.Hex(), err)
+		return
+	}
+
+	for _, item := range order.Items {
+		if err := s.releaseItem(ctx, order.ID, item, ""); err != nil {
+			log.Printf("Failed to release %d units of product %s for order %s: %v",
+				item.Quantity, item.ProductID, order.ID.Hex(), err)
+		}
+	}
+}
+
+func itemViolation(index int, productID string, description string) *errdetails.PreconditionFailure_Violation {
+	return &errdetails.PreconditionFailure_Violation{
+		Type:        "STOCK",
+		Subject:     fmt.Sprintf("items[%d] product %s", index, productID),
+		Description: description,
+	}
+}
+
+// itemsError builds a FailedPrecondition status carrying the per-item violations.
+func itemsError(violations []*errdetails.PreconditionFailure_Violation) error {
+	return preconditionError("order items are unavailable:", violations)
+}
+
+// preconditionError builds a FailedPrecondition status whose message starts with summary
+// and lists the violations, which are also attached as details.
+func preconditionError(summary string, violations []*errdetails.PreconditionFailure_Violation) error {
+	msg := summary
+	for _, v := range violations {
+		msg += fmt.Sprintf(" %s: %s;", v.Subject, v.Description)
+	}
+
+	st := status.New(codes.FailedPrecondition, msg[:len(msg)-1])
+	detailed, err := st.WithDetails(&errdetails.PreconditionFailure{Violations: violations})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
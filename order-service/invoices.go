@@ -0,0 +1,359 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	htmltemplate "html/template"
+	"math/big"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// An invoice is issued for a delivered order on the first GetInvoice. Its billing
+// details come from the UserService and its lines from the order, with product names
+// from the catalog. It is rendered to HTML and PDF once, when issued, and stored with
+// the data it was rendered from; later changes to the order, the user, the catalog or
+// the templates don't affect it.
+//
+// Numbers are INV-<year>-<sequence>. The sequence of the year is taken from a counter
+// in the same transaction that stores the invoice, so a failed issue gives its number
+// back and the numbers of a year have no gaps.
+
+//go:embed invoice.html
+var defaultInvoiceHTMLTemplate string
+
+//go:embed invoice.txt
+var defaultInvoiceTextTemplate string
+
+// invoiceTemplates render invoices: the HTML template as it is, and the text template
+// typeset as a PDF.
+type invoiceTemplates struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// newInvoiceTemplates parses the HTML and text invoice templates.
+func newInvoiceTemplates(html, text string) (*invoiceTemplates, error) {
+	htmlTemplate, err := htmltemplate.New("invoice.html").Parse(html)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTML invoice template: %v", err)
+	}
+	textTemplate, err := texttemplate.New("invoice.txt").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid text invoice template: %v", err)
+	}
+	return &invoiceTemplates{html: htmlTemplate, text: textTemplate}, nil
+}
+
+type invoiceModel struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty"`
+	Number   string             `bson:"number"`
+	Year     int                `bson:"year"`
+	Sequence int64              `bson:"sequence"`
+	OrderID  primitive.ObjectID `bson:"order_id"`
+	UserID   string             `bson:"user_id"`
+	Total    moneyModel         `bson:"total"`
+	IssuedAt time.Time          `bson:"issued_at"`
+	Data     invoiceData        `bson:"data"`
+	HTML     string             `bson:"html"`
+	PDF      []byte             `bson:"pdf"`
+}
+
+// invoiceData is what the invoice templates are rendered from. Amounts are formatted
+// with their currency.
+type invoiceData struct {
+	Number    string          `bson:"number"`
+	IssuedAt  time.Time       `bson:"issued_at"`
+	OrderID   string          `bson:"order_id"`
+	OrderedAt time.Time       `bson:"ordered_at"`
+	Billing   invoiceBilling  `bson:"billing"`
+	ShipTo    []string        `bson:"ship_to,omitempty"`
+	Lines     []invoiceLine   `bson:"lines"`
+	Discounts []invoiceAmount `bson:"discounts,omitempty"`
+	Subtotal  string          `bson:"subtotal"`
+	Taxes     []invoiceAmount `bson:"taxes,omitempty"`
+	Shipping  *invoiceAmount  `bson:"shipping,omitempty"`
+	Total     string          `bson:"total"`
+}
+
+type invoiceBilling struct {
+	Name    string `bson:"name"`
+	Email   string `bson:"email"`
+	Phone   string `bson:"phone,omitempty"`
+	Address string `bson:"address,omitempty"`
+}
+
+type invoiceLine struct {
+	ProductID   string `bson:"product_id"`
+	Description string `bson:"description"`
+	Quantity    int32  `bson:"quantity"`
+	UnitPrice   string `bson:"unit_price"`
+	Amount      string `bson:"amount"`
+}
+
+type invoiceAmount struct {
+	Description string `bson:"description"`
+	Amount      string `bson:"amount"`
+}
+
+func (s *server) GetInvoice(ctx context.Context, req *pb.GetInvoiceRequest) (*pb.Invoice, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order id is required")
+	}
+	if _, ok := pb.InvoiceFormat_name[int32(req.Format)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown invoice format %d", req.Format)
+	}
+
+	// Convert string ID to ObjectID
+	orderID, err := primitive.ObjectIDFromHex(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order id")
+	}
+
+	invoice, err := s.findInvoice(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		if invoice, err = s.issueInvoice(ctx, orderID); err != nil {
+			return nil, err
+		}
+	}
+
+	return invoice.toProto(req.Format), nil
+}
+
+// issueInvoice issues the invoice of a delivered order. If a concurrent request issued
+// it first, that invoice is returned.
+func (s *server) issueInvoice(ctx context.Context, orderID primitive.ObjectID) (*invoiceModel, error) {
+	var order orderModel
+	err := s.db.Collection("orders").FindOne(ctx, bson.M{"_id": orderID}).Decode(&order)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	}
+	if parseStatus(order.Status) != pb.OrderStatus_ORDER_STATUS_DELIVERED {
+		return nil, status.Errorf(codes.FailedPrecondition, "order is %s, invoices are issued once orders are delivered", order.Status)
+	}
+
+	user, err := s.userClient.GetUser(ctx, &pb.GetUserRequest{Id: order.UserID})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.FailedPrecondition, "the user who placed the order no longer exists")
+		}
+		return nil, status.Errorf(codes.Unavailable, "failed to look up user %s: %v", order.UserID, err)
+	}
+	products, err := s.lookupProducts(ctx, order.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	invoice := &invoiceModel{
+		Year:     now.Year(),
+		OrderID:  order.ID,
+		UserID:   order.UserID,
+		Total:    order.TotalAmount,
+		IssuedAt: now,
+		Data:     newInvoiceData(&order, user, products),
+	}
+	invoice.Data.IssuedAt = now
+
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		var counter struct {
+			Sequence int64 `bson:"sequence"`
+		}
+		err := s.db.Collection("invoice_counters").FindOneAndUpdate(sc,
+			bson.M{"_id": invoice.Year},
+			bson.M{"$inc": bson.M{"sequence": 1}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&counter)
+		if err != nil {
+			return err
+		}
+
+		invoice.ID = primitive.NewObjectID()
+		invoice.Sequence = counter.Sequence
+		invoice.Number = fmt.Sprintf("INV-%d-%06d", invoice.Year, invoice.Sequence)
+		invoice.Data.Number = invoice.Number
+		if err := s.renderInvoice(invoice); err != nil {
+			return err
+		}
+
+		_, err = s.db.Collection("invoices").InsertOne(sc, invoice)
+		return err
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		// A concurrent request issued the invoice first
+		existing, findErr := s.findInvoice(ctx, orderID)
+		if findErr != nil {
+			return nil, findErr
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to issue invoice: %v", err)
+	}
+
+	return invoice, nil
+}
+
+// findInvoice returns the invoice of an order, or nil if none was issued yet.
+func (s *server) findInvoice(ctx context.Context, orderID primitive.ObjectID) (*invoiceModel, error) {
+	var invoice invoiceModel
+	err := s.db.Collection("invoices").FindOne(ctx, bson.M{"order_id": orderID}).Decode(&invoice)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get invoice: %v", err)
+	}
+	return &invoice, nil
+}
+
+// renderInvoice renders the HTML and PDF documents of an invoice from its data.
+func (s *server) renderInvoice(invoice *invoiceModel) error {
+	var html, text bytes.Buffer
+	if err := s.invoices.html.Execute(&html, invoice.Data); err != nil {
+		return status.Errorf(codes.Internal, "failed to render invoice: %v", err)
+	}
+	if err := s.invoices.text.Execute(&text, invoice.Data); err != nil {
+		return status.Errorf(codes.Internal, "failed to render invoice: %v", err)
+	}
+	invoice.HTML = html.String()
+	invoice.PDF = renderPDF(text.String())
+	return nil
+}
+
+// newInvoiceData collects what an invoice shows from an order, the user who placed it
+// and the catalog entries of its products. Products no longer in the catalog are
+// described by their ID.
+func newInvoiceData(order *orderModel, user *pb.User, products map[string]*pb.Product) invoiceData {
+	data := invoiceData{
+		OrderID:   order.ID.Hex(),
+		OrderedAt: order.CreatedAt,
+		Billing: invoiceBilling{
+			Name:    strings.TrimSpace(user.FirstName + " " + user.LastName),
+			Email:   user.Email,
+			Phone:   user.Phone,
+			Address: user.Address,
+		},
+		Total: order.TotalAmount.format(),
+	}
+
+	if a := order.ShippingAddress; a != nil {
+		for _, line := range []string{a.Line1, a.Line2, strings.TrimSpace(a.PostalCode + " " + a.City), a.Region, a.Country} {
+			if line != "" {
+				data.ShipTo = append(data.ShipTo, line)
+			}
+		}
+	}
+
+	for _, item := range order.Items {
+		description := item.ProductID
+		if product, ok := products[item.ProductID]; ok && product.Name != "" {
+			description = product.Name
+		}
+		amount, _ := item.Price.times(item.Quantity)
+		data.Lines = append(data.Lines, invoiceLine{
+			ProductID:   item.ProductID,
+			Description: description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.Price.format(),
+			Amount:      amount.format(),
+		})
+	}
+
+	for _, discount := range order.Discounts {
+		description := discount.Description
+		if description == "" {
+			description = "Discount"
+		}
+		if discount.Code != "" {
+			description += " (" + discount.Code + ")"
+		}
+		data.Discounts = append(data.Discounts, invoiceAmount{Description: description, Amount: discount.Amount.format()})
+	}
+
+	// Orders placed before taxes were calculated have no subtotal
+	subtotal := order.TotalAmount
+	if order.Subtotal != nil {
+		subtotal = *order.Subtotal
+	}
+	data.Subtotal = subtotal.format()
+
+	for _, line := range order.TaxLines {
+		description := line.Name
+		if line.Rate != "" {
+			description += " (" + formatRate(line.Rate) + ")"
+		}
+		if line.Inclusive {
+			description += ", included"
+		}
+		data.Taxes = append(data.Taxes, invoiceAmount{Description: description, Amount: line.Amount.format()})
+	}
+
+	if order.Shipping != nil {
+		data.Shipping = &invoiceAmount{Description: "Shipping: " + order.Shipping.Name, Amount: order.Shipping.Cost.format()}
+	}
+	return data
+}
+
+// formatRate formats a decimal tax rate such as "0.0725" as a percentage, "7.25%".
+func formatRate(rate string) string {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return rate
+	}
+	percent := r.Mul(r, big.NewRat(100, 1)).FloatString(4)
+	return strings.TrimSuffix(strings.TrimRight(percent, "0"), ".") + "%"
+}
+
+// toProto converts a stored invoice into its protobuf representation, with the
+// document in format if one is asked for.
+func (i *invoiceModel) toProto(format pb.InvoiceFormat) *pb.Invoice {
+	invoice := &pb.Invoice{
+		Id:       i.ID.Hex(),
+		Number:   i.Number,
+		OrderId:  i.OrderID.Hex(),
+		UserId:   i.UserID,
+		Total:    i.Total.toProto(),
+		IssuedAt: i.IssuedAt.Format(time.RFC3339),
+		Format:   format,
+	}
+	switch format {
+	case pb.InvoiceFormat_INVOICE_FORMAT_HTML:
+		invoice.Document, invoice.ContentType = []byte(i.HTML), "text/html; charset=utf-8"
+	case pb.InvoiceFormat_INVOICE_FORMAT_PDF:
+		invoice.Document, invoice.ContentType = i.PDF, "application/pdf"
+	}
+	return invoice
+}
+
+// ensureInvoiceIndexes creates the unique indexes that allow one invoice per order and
+// one invoice per number.
+func ensureInvoiceIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("invoices").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "order_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "number", Value: 1}}, Options: options.Index().SetUnique(true)},
+	})
+	return err
+}
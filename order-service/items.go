@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// The items of an order can be changed while it is confirmed, that is once its stock
+// is reserved and until it is paid. Pending orders cannot be edited even though they
+// are not confirmed yet: their saga is still reserving stock item by item, and may
+// release it all again, so an edit would race with it. Each change reserves or releases the difference
+// in stock and prices the order again: the promotions it was placed with are applied
+// to the new items, and taxes and shipping are recalculated. A payment authorized
+// before the change no longer matches the total; the Payment Service voids it when it
+// is captured, and the order has to be authorized again.
+
+func (s *server) AddOrderItem(ctx context.Context, req *pb.AddOrderItemRequest) (*pb.Order, error) {
+	if req.Quantity < 1 {
+		return nil, status.Error(codes.InvalidArgument, "quantity must be at least 1")
+	}
+	return s.changeOrderItem(ctx, req.OrderId, req.ProductId, func(current int32) (int32, error) {
+		return current + req.Quantity, nil
+	})
+}
+
+func (s *server) UpdateOrderItemQuantity(ctx context.Context, req *pb.UpdateOrderItemQuantityRequest) (*pb.Order, error) {
+	if req.Quantity < 1 {
+		return nil, status.Error(codes.InvalidArgument, "quantity must be at least 1; remove the item instead")
+	}
+	return s.changeOrderItem(ctx, req.OrderId, req.ProductId, func(current int32) (int32, error) {
+		if current == 0 {
+			return 0, status.Errorf(codes.NotFound, "product %s is not part of this order", req.ProductId)
+		}
+		return req.Quantity, nil
+	})
+}
+
+func (s *server) RemoveOrderItem(ctx context.Context, req *pb.RemoveOrderItemRequest) (*pb.Order, error) {
+	return s.changeOrderItem(ctx, req.OrderId, req.ProductId, func(current int32) (int32, error) {
+		if current == 0 {
+			return 0, status.Errorf(codes.NotFound, "product %s is not part of this order", req.ProductId)
+		}
+		return 0, nil
+	})
+}
+
+// changeOrderItem sets the quantity of a product in an order to what change returns for
+// the current quantity, which is 0 if the product is not in the order. A new quantity
+// of 0 removes the product. Several lines for the same product, at the same price,
+// are merged into one.
+func (s *server) changeOrderItem(ctx context.Context, orderID, productID string,
+	change func(current int32) (int32, error)) (*pb.Order, error) {
+	if orderID == "" {
+		return nil, status.Error(codes.InvalidArgument, "order id is required")
+	}
+	if productID == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	// Convert string ID to ObjectID
+	id, err := primitive.ObjectIDFromHex(orderID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order id")
+	}
+
+	if _, err := actorFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var order orderModel
+	if err := s.db.Collection("orders").FindOne(ctx, bson.M{"_id": id}).Decode(&order); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	}
+	if parseStatus(order.Status) != pb.OrderStatus_ORDER_STATUS_CONFIRMED {
+		return nil, status.Errorf(codes.FailedPrecondition, "items of an order in status %s can no longer be changed", order.Status)
+	}
+	if order.Imported {
+		// It holds no stock to adjust
+		return nil, status.Error(codes.FailedPrecondition, "items of an imported order cannot be changed")
+	}
+
+	var current, backordered int32
+	var price *moneyModel
+	for _, item := range order.Items {
+		if item.ProductID != productID {
+			continue
+		}
+		if price != nil && item.Price != *price {
+			// Merging them would reprice some of the units
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"product %s is on lines with different prices and cannot be changed", productID)
+		}
+		price = &item.Price
+		current += item.Quantity
+		backordered += item.Backordered
+	}
+	next, err := change(current)
+	if err != nil {
+		return nil, err
+	}
+	if next == current {
+		return order.toProto(), nil
+	}
+
+	// Replace the product's lines by one with the new quantity, keeping the price the
+	// product was ordered at. Removed units are taken from the backordered ones first,
+	// as the Product Service cancels those first.
+	delta := next - current
+	if delta < 0 {
+		backordered -= min(backordered, -delta)
+	}
+	items := make([]orderItemModel, 0, len(order.Items)+1)
+	index := -1
+	for _, item := range order.Items {
+		if item.ProductID != productID {
+			items = append(items, item)
+			continue
+		}
+		if index < 0 && next > 0 {
+			index = len(items)
+			item.Quantity, item.Backordered = next, backordered
+			items = append(items, item)
+		}
+	}
+	if index < 0 && next > 0 {
+		product, err := s.productClient.GetProduct(ctx, &pb.GetProductRequest{Id: productID})
+		if err != nil {
+			if code := status.Code(err); code == codes.NotFound || code == codes.InvalidArgument {
+				return nil, status.Error(codes.NotFound, "product not found")
+			}
+			return nil, status.Errorf(codes.Unavailable, "failed to look up product %s: %v", productID, err)
+		}
+		if product.Price == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "product %s has no price", productID)
+		}
+		index = len(items)
+		items = append(items, orderItemModel{ProductID: productID, Quantity: next, Price: moneyFromProto(product.Price)})
+	}
+	if len(items) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "an order must keep at least one item; cancel it instead")
+	}
+
+	// Price the order with its new items
+	products, err := s.lookupProducts(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+	edited := order
+	edited.Items = items
+	if edited.Discounts, err = s.rediscountItems(ctx, &edited, products); err != nil {
+		return nil, err
+	}
+	if err := s.priceOrder(ctx, &edited, products); err != nil {
+		return nil, err
+	}
+
+	// Reserve added stock before the order shows it, and release removed stock after
+	if delta > 0 {
+		added, err := s.reserveItem(ctx, order.ID, index, orderItemModel{ProductID: productID, Quantity: delta}, "")
+		if err != nil {
+			return nil, err
+		}
+		edited.Items[index].Backordered += added
+	}
+
+	var updated orderModel
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		err := s.db.Collection("orders").FindOneAndUpdate(sc,
+			bson.M{"_id": id, "status": order.Status, "updated_at": order.UpdatedAt},
+			bson.M{"$set": bson.M{
+				"items":        edited.Items,
+				"discounts":    edited.Discounts,
+				"subtotal":     edited.Subtotal,
+				"tax_lines":    edited.TaxLines,
+				"shipping":     edited.Shipping,
+				"total_amount": edited.TotalAmount,
+				"updated_at":   time.Now().UTC(),
+			}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&updated)
+		if err != nil {
+			return err
+		}
+		return s.enqueueEvent(sc, eventOrderItemsChanged, &updated, "", "")
+	})
+	if err != nil {
+		if delta > 0 {
+			if releaseErr := s.releaseItem(ctx, order.ID, orderItemModel{ProductID: productID, Quantity: delta}, ""); releaseErr != nil {
+				log.Printf("Failed to release %d units of product %s for order %s: %v",
+					delta, productID, order.ID.Hex(), releaseErr)
+			}
+		}
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Error(codes.Aborted, "order changed concurrently, retry the change")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update order: %v", err)
+	}
+
+	if delta < 0 {
+		if err := s.releaseItem(ctx, order.ID, orderItemModel{ProductID: productID, Quantity: -delta}, ""); err != nil {
+			log.Printf("Failed to release %d units of product %s for order %s: %v",
+				-delta, productID, order.ID.Hex(), err)
+		}
+	}
+
+	return updated.toProto(), nil
+}
+
+// lookupProducts returns the catalog entries of the products of items by ID. Products
+// no longer in the catalog are left out.
+func (s *server) lookupProducts(ctx context.Context, items []orderItemModel) (map[string]*pb.Product, error) {
+	products := map[string]*pb.Product{}
+	for _, item := range items {
+		if _, ok := products[item.ProductID]; ok {
+			continue
+		}
+		product, err := s.productClient.GetProduct(ctx, &pb.GetProductRequest{Id: item.ProductID})
+		if err != nil {
+			if code := status.Code(err); code == codes.NotFound || code == codes.InvalidArgument {
+				continue
+			}
+			return nil, status.Errorf(codes.Unavailable, "failed to look up product %s: %v", item.ProductID, err)
+		}
+		products[item.ProductID] = product
+	}
+	return products, nil
+}
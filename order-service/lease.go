@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Background jobs that must run on only one replica at a time take a lease: a document
+// in the leases collection naming the replica holding it and when it expires. The
+// holder renews the lease every run; once it lapses, for example because the holder
+// died, any replica may take it over.
+
+// newInstanceID returns an identifier for this replica, unique across restarts.
+func newInstanceID() string {
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	hostname, _ := os.Hostname()
+	return hostname + "-" + hex.EncodeToString(suffix)
+}
+
+// acquireLease takes or renews the lease called name for holder until now + duration.
+// It returns false if another holder has the lease and it has not expired yet.
+func acquireLease(ctx context.Context, db *mongo.Database, name, holder string, duration time.Duration) (bool, error) {
+	now := time.Now().UTC()
+	_, err := db.Collection("leases").UpdateOne(ctx,
+		bson.M{
+			"_id": name,
+			"$or": bson.A{
+				bson.M{"holder": holder},
+				bson.M{"expires_at": bson.M{"$lte": now}},
+			},
+		},
+		bson.M{"$set": bson.M{"holder": holder, "expires_at": now.Add(duration)}},
+		options.Update().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		// The lease exists and is held by someone else, so the upsert collided with it
+		return false, nil
+	}
+	return err == nil, err
+}
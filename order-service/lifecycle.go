@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	pb "github.com/order-management/proto"
+)
+
+// orderTransitions lists, for every status, the statuses an order may move to next.
+// Statuses without an entry are terminal.
+var orderTransitions = map[pb.OrderStatus][]pb.OrderStatus{
+	pb.OrderStatus_ORDER_STATUS_PENDING: {
+		pb.OrderStatus_ORDER_STATUS_CONFIRMED,
+		pb.OrderStatus_ORDER_STATUS_CANCELLED,
+	},
+	pb.OrderStatus_ORDER_STATUS_CONFIRMED: {
+		pb.OrderStatus_ORDER_STATUS_PAID,
+		pb.OrderStatus_ORDER_STATUS_CANCELLED,
+	},
+	pb.OrderStatus_ORDER_STATUS_PAID: {
+		pb.OrderStatus_ORDER_STATUS_PARTIALLY_SHIPPED,
+		pb.OrderStatus_ORDER_STATUS_SHIPPED,
+		pb.OrderStatus_ORDER_STATUS_REFUNDED,
+	},
+	pb.OrderStatus_ORDER_STATUS_PARTIALLY_SHIPPED: {
+		pb.OrderStatus_ORDER_STATUS_SHIPPED,
+	},
+	pb.OrderStatus_ORDER_STATUS_SHIPPED: {
+		pb.OrderStatus_ORDER_STATUS_DELIVERED,
+	},
+	pb.OrderStatus_ORDER_STATUS_DELIVERED: {
+		pb.OrderStatus_ORDER_STATUS_REFUNDED,
+	},
+}
+
+// canTransition reports whether an order in status from may move to status to.
+func canTransition(from, to pb.OrderStatus) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// statusName returns the lowercase name stored in MongoDB for a status, e.g. "pending".
+func statusName(st pb.OrderStatus) string {
+	return strings.ToLower(strings.TrimPrefix(st.String(), "ORDER_STATUS_"))
+}
+
+// parseStatus converts a stored status name back to its enum value.
+// Unknown names map to ORDER_STATUS_UNSPECIFIED.
+func parseStatus(name string) pb.OrderStatus {
+	return pb.OrderStatus(pb.OrderStatus_value["ORDER_STATUS_"+strings.ToUpper(name)])
+}
+
+// newStatusChange builds the history entry for a move from one stored status name to
+// another, timestamped now.
+func newStatusChange(from, to, actor, reason string) statusChangeModel {
+	return statusChangeModel{
+		OldStatus: from,
+		NewStatus: to,
+		ActorID:   actor,
+		Reason:    reason,
+		ChangedAt: time.Now().UTC(),
+	}
+}
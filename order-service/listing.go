@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	pb "github.com/order-management/proto"
+)
+
+// orderSortFields maps the sort_by values of ListOrders to the fields sorted on.
+var orderSortFields = map[string]string{
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+	"total_amount": "total_amount.units_minor",
+}
+
+// listOrdersFilter builds the MongoDB filter for the criteria of a ListOrders request.
+func listOrdersFilter(req *pb.ListOrdersRequest) (bson.M, error) {
+	filter := bson.M{}
+	if req.UserId != "" {
+		filter["user_id"] = req.UserId
+	}
+	if req.ProductId != "" {
+		filter["items.product_id"] = req.ProductId
+	}
+
+	if len(req.Statuses) > 0 {
+		statuses := make([]string, 0, len(req.Statuses))
+		for _, st := range req.Statuses {
+			if st == pb.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+				return nil, status.Error(codes.InvalidArgument, "statuses cannot contain ORDER_STATUS_UNSPECIFIED")
+			}
+			statuses = append(statuses, statusName(st))
+		}
+		filter["status"] = bson.M{"$in": statuses}
+	}
+
+	for _, bounds := range []struct {
+		field         string
+		after, before string
+	}{
+		{"created_at", req.CreatedAfter, req.CreatedBefore},
+		{"updated_at", req.UpdatedAfter, req.UpdatedBefore},
+	} {
+		condition := bson.M{}
+		for op, value := range map[string]string{"$gte": bounds.after, "$lte": bounds.before} {
+			if value == "" {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "%s bounds must be RFC 3339 timestamps", bounds.field)
+			}
+			condition[op] = t.UTC()
+		}
+		if len(condition) > 0 {
+			filter[bounds.field] = condition
+		}
+	}
+
+	if req.MinTotal != nil || req.MaxTotal != nil {
+		if req.MinTotal != nil && req.MaxTotal != nil && req.MinTotal.Currency != req.MaxTotal.Currency {
+			return nil, status.Error(codes.InvalidArgument, "min_total and max_total must be in the same currency")
+		}
+		amount := bson.M{}
+		if req.MinTotal != nil {
+			filter["total_amount.currency"] = req.MinTotal.Currency
+			amount["$gte"] = req.MinTotal.UnitsMinor
+		}
+		if req.MaxTotal != nil {
+			filter["total_amount.currency"] = req.MaxTotal.Currency
+			amount["$lte"] = req.MaxTotal.UnitsMinor
+		}
+		filter["total_amount.units_minor"] = amount
+	}
+
+	return filter, nil
+}
+
+// listOrdersSort returns the sort order of a ListOrders request. Ties are broken by
+// _id in the same direction, so the order is stable between calls.
+func listOrdersSort(req *pb.ListOrdersRequest) (bson.D, error) {
+	sortBy := req.SortBy
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	field, ok := orderSortFields[sortBy]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot sort orders by %q", req.SortBy)
+	}
+
+	direction := -1
+	switch req.SortOrder {
+	case "", "desc":
+	case "asc":
+		direction = 1
+	default:
+		return nil, status.Error(codes.InvalidArgument, "sort_order must be asc or desc")
+	}
+
+	return bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}}, nil
+}
+
+// listOrdersQuery describes the filter and sort of a ListOrders request, leaving out
+// the pagination, for the page tokens of its results.
+func listOrdersQuery(req *pb.ListOrdersRequest) string {
+	query := proto.Clone(req).(*pb.ListOrdersRequest)
+	query.Page, query.Limit, query.PageToken, query.IncludeTotal = 0, 0, "", false
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(query)
+	return string(data)
+}
+
+// sortValue returns the value of an order's sort field as kept in page tokens: dates
+// in milliseconds, as MongoDB stores them, and amounts in minor units.
+func (o *orderModel) sortValue(field string) int64 {
+	switch field {
+	case "updated_at":
+		return int64(primitive.NewDateTimeFromTime(o.UpdatedAt))
+	case "total_amount.units_minor":
+		return o.TotalAmount.UnitsMinor
+	}
+	return int64(primitive.NewDateTimeFromTime(o.CreatedAt))
+}
+
+// orderSortFilterValue converts a page token value back to the stored type of field.
+func orderSortFilterValue(field string, value int64) interface{} {
+	if field == "total_amount.units_minor" {
+		return value
+	}
+	return primitive.DateTime(value)
+}
+
+// ensureOrderListIndexes creates the compound indexes behind the ListOrders filters and
+// sort orders. Equality filters come first and the sort field last; MongoDB walks each
+// index in either direction.
+func ensureOrderListIndexes(ctx context.Context, db *mongo.Database) error {
+	var indexes []mongo.IndexModel
+	for _, prefix := range []string{"", "user_id", "status", "items.product_id"} {
+		for _, field := range []string{"created_at", "updated_at"} {
+			keys := bson.D{}
+			if prefix != "" {
+				keys = append(keys, bson.E{Key: prefix, Value: 1})
+			}
+			keys = append(keys, bson.E{Key: field, Value: -1}, bson.E{Key: "_id", Value: -1})
+			indexes = append(indexes, mongo.IndexModel{Keys: keys})
+		}
+	}
+	indexes = append(indexes,
+		mongo.IndexModel{Keys: bson.D{
+			{Key: "total_amount.currency", Value: 1},
+			{Key: "total_amount.units_minor", Value: -1},
+			{Key: "_id", Value: -1},
+		}},
+		mongo.IndexModel{Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "total_amount.currency", Value: 1},
+			{Key: "total_amount.units_minor", Value: -1},
+			{Key: "_id", Value: -1},
+		}},
+	)
+
+	_, err := db.Collection("orders").Indexes().CreateMany(ctx, indexes)
+	return err
+}
@@ -13,13 +13,57 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 	pb "github.com/order-management/proto"
 )
 
 type server struct {
 	pb.UnimplementedOrderServiceServer
-	db *mongo.Database
+	db            *mongo.Database
+	productClient pb.ProductServiceClient
+	userClient    pb.UserServiceClient
+	publisher     EventPublisher
+	taxes         TaxCalculator
+	shipping      *shippingRateTable
+	invoices      *invoiceTemplates
+}
+
+type orderItemModel struct {
+	ProductID   string     `bson:"product_id"`
+	Quantity    int32      `bson:"quantity"`
+	Price       moneyModel `bson:"price"`
+	Backordered int32      `bson:"backordered,omitempty"` // Units of Quantity still waiting for stock
+}
+
+type statusChangeModel struct {
+	OldStatus string    `bson:"old_status"`
+	NewStatus string    `bson:"new_status"`
+	ActorID   string    `bson:"actor_id"`
+	Reason    string    `bson:"reason,omitempty"`
+	ChangedAt time.Time `bson:"changed_at"`
+}
+
+type orderModel struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	UserID      string             `bson:"user_id"`
+	Items       []orderItemModel   `bson:"items"`
+	Status      string             `bson:"status"`
+	TotalAmount moneyModel         `bson:"total_amount"`
+	Discounts   []discountModel    `bson:"discounts,omitempty"`
+	Subtotal    *moneyModel        `bson:"subtotal,omitempty"`
+	TaxLines    []taxLineModel     `bson:"tax_lines,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+
+	CancellationReason string               `bson:"cancellation_reason,omitempty"`
+	IdempotencyKey     string               `bson:"idempotency_key,omitempty"`
+	RequestHash        string               `bson:"request_hash,omitempty"`
+	StatusHistory      []statusChangeModel  `bson:"status_history"`
+	ShippingAddress    *addressModel        `bson:"shipping_address,omitempty"`
+	Shipping           *shippingOptionModel `bson:"shipping,omitempty"`
+	SubscriptionID     string               `bson:"subscription_id,omitempty"`
+	Imported           bool                 `bson:"imported,omitempty"` // Holds no stock reservations
 }
 
 func main() {
@@ -38,16 +82,174 @@ func main() {
 	}
 	defer client.Disconnect(ctx)
 
+	// Create unique indexes for idempotency keys and coupon codes, and the indexes for
+	// the outbox relay and order listings
+	if err := ensureIdempotencyIndex(ctx, client.Database("order_management")); err != nil {
+		log.Fatalf("Failed to create index: %v", err)
+	}
+	if err := ensureOutboxIndex(ctx, client.Database("order_management")); err != nil {
+		log.Fatalf("Failed to create index: %v", err)
+	}
+	if err := ensurePromotionIndexes(ctx, client.Database("order_management")); err != nil {
+		log.Fatalf("Failed to create index: %v", err)
+	}
+	if err := ensureOrderListIndexes(ctx, client.Database("order_management")); err != nil {
+		log.Fatalf("Failed to create index: %v", err)
+	}
+	if err := ensureBackorderIndexes(ctx, client.Database("order_management")); err != nil {
+		log.Fatalf("Failed to create index: %v", err)
+	}
+	if err := ensureSubscriptionIndexes(ctx, client.Database("order_management")); err != nil {
+		log.Fatalf("Failed to create index: %v", err)
+	}
+	if err := ensureCartIndexes(ctx, client.Database("order_management")); err != nil {
+		log.Fatalf("Failed to create index: %v", err)
+	}
+	if err := ensureInvoiceIndexes(ctx, client.Database("order_management")); err != nil {
+		log.Fatalf("Failed to create index: %v", err)
+	}
+
+	// Convert amounts stored as floats by earlier versions to Money
+	migrated, err := migrateLegacyAmounts(context.Background(), client.Database("order_management"))
+	if err != nil {
+		log.Fatalf("Failed to migrate order amounts: %v", err)
+	}
+	if migrated > 0 {
+		log.Printf("Migrated amounts of %d orders and sagas to Money", migrated)
+	}
+
+	// Load the tax rule table, falling back to the built-in one
+	taxRules := defaultTaxRules
+	if taxRulesFile := os.Getenv("TAX_RULES_FILE"); taxRulesFile != "" {
+		taxRules, err = os.ReadFile(taxRulesFile)
+		if err != nil {
+			log.Fatalf("Failed to read tax rules: %v", err)
+		}
+	}
+	taxes, err := newRuleTableCalculator(taxRules)
+	if err != nil {
+		log.Fatalf("Failed to load tax rules: %v", err)
+	}
+
+	// Load the shipping rate table, falling back to the built-in one
+	shippingRates := defaultShippingRates
+	if shippingRatesFile := os.Getenv("SHIPPING_RATES_FILE"); shippingRatesFile != "" {
+		shippingRates, err = os.ReadFile(shippingRatesFile)
+		if err != nil {
+			log.Fatalf("Failed to read shipping rates: %v", err)
+		}
+	}
+	shipping, err := newShippingRateTable(shippingRates)
+	if err != nil {
+		log.Fatalf("Failed to load shipping rates: %v", err)
+	}
+
+	// Load the invoice templates, falling back to the built-in ones
+	invoiceHTML, invoiceText := defaultInvoiceHTMLTemplate, defaultInvoiceTextTemplate
+	if templateFile := os.Getenv("INVOICE_HTML_TEMPLATE_FILE"); templateFile != "" {
+		data, err := os.ReadFile(templateFile)
+		if err != nil {
+			log.Fatalf("Failed to read invoice template: %v", err)
+		}
+		invoiceHTML = string(data)
+	}
+	if templateFile := os.Getenv("INVOICE_TEXT_TEMPLATE_FILE"); templateFile != "" {
+		data, err := os.ReadFile(templateFile)
+		if err != nil {
+			log.Fatalf("Failed to read invoice template: %v", err)
+		}
+		invoiceText = string(data)
+	}
+	invoices, err := newInvoiceTemplates(invoiceHTML, invoiceText)
+	if err != nil {
+		log.Fatalf("Failed to load invoice templates: %v", err)
+	}
+
+	// Pending orders older than this are cancelled automatically; 0 turns that off
+	pendingOrderTTL := time.Hour
+	if ttl := os.Getenv("PENDING_ORDER_TTL"); ttl != "" {
+		pendingOrderTTL, err = time.ParseDuration(ttl)
+		if err != nil || pendingOrderTTL < 0 {
+			log.Fatalf("Invalid PENDING_ORDER_TTL %q, expected a duration such as 30m", ttl)
+		}
+	}
+
+	// Set up the publisher for order events
+	var publisher EventPublisher
+	switch os.Getenv("EVENT_PUBLISHER") {
+	case "", "memory":
+		publisher = newMemoryPublisher()
+	case "nats":
+		natsURL := os.Getenv("NATS_URL")
+		if natsURL == "" {
+			natsURL = "nats://localhost:4222"
+		}
+		publisher, err = newNATSPublisher(natsURL)
+		if err != nil {
+			log.Fatalf("Failed to configure NATS publisher: %v", err)
+		}
+	default:
+		log.Fatalf("Unknown EVENT_PUBLISHER %q, expected memory or nats", os.Getenv("EVENT_PUBLISHER"))
+	}
+	defer publisher.Close()
+
+	// Connect to Product Service
+	productServiceURL := os.Getenv("PRODUCT_SERVICE_URL")
+	if productServiceURL == "" {
+		productServiceURL = "localhost:50052"
+	}
+
+	productConn, err := grpc.Dial(productServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to Product service: %v", err)
+	}
+	defer productConn.Close()
+
+	// Connect to User Service
+	userServiceURL := os.Getenv("USER_SERVICE_URL")
+	if userServiceURL == "" {
+		userServiceURL = "localhost:50053"
+	}
+
+	userConn, err := grpc.Dial(userServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to User service: %v", err)
+	}
+	defer userConn.Close()
+
 	// Initialize gRPC server
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
+	srv := &server{
+		db:            client.Database("order_management"),
+		productClient: pb.NewProductServiceClient(productConn),
+		userClient:    pb.NewUserServiceClient(userConn),
+		publisher:     publisher,
+		taxes:         taxes,
+		shipping:      shipping,
+		invoices:      invoices,
+	}
+
+	subscriptions := &subscriptionServer{db: srv.db, orders: srv}
+
+	// Resume order sagas interrupted by a previous shutdown, relay order events, follow
+	// backorders, place subscription orders and cancel stale pending orders
+	go srv.watchSagas(context.Background())
+	go srv.relayEvents(context.Background())
+	go srv.syncBackorders(context.Background())
+	go subscriptions.runSubscriptions(context.Background())
+	if pendingOrderTTL > 0 {
+		go srv.expirePendingOrders(context.Background(), pendingOrderTTL)
+	}
+
 	s := grpc.NewServer()
-	pb.RegisterOrderServiceServer(s, &server{
-		db: client.Database("order_management"),
-	})
+	pb.RegisterOrderServiceServer(s, srv)
+	pb.RegisterPromotionServiceServer(s, &promotionServer{db: srv.db})
+	pb.RegisterSubscriptionServiceServer(s, subscriptions)
+	pb.RegisterCartServiceServer(s, &cartServer{db: srv.db, orders: srv})
 
 	log.Printf("Order service listening on :50051")
 	if err := s.Serve(lis); err != nil {
@@ -56,48 +258,139 @@ func main() {
 }
 
 func (s *server) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.Order, error) {
+	if err := validateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idempotencyKey, err := idempotencyKeyFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createOrder(ctx, req, actor, idempotencyKey, "")
+}
+
+// validateOrderRequest checks the parts of a CreateOrderRequest that don't depend on
+// the catalog.
+func validateOrderRequest(req *pb.CreateOrderRequest) error {
 	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		return status.Error(codes.InvalidArgument, "user_id is required")
 	}
 	if len(req.Items) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "order must contain at least one item")
+		return status.Error(codes.InvalidArgument, "order must contain at least one item")
 	}
-
-	// Calculate total amount
-	var totalAmount float64
 	for _, item := range req.Items {
-		totalAmount += float64(item.Quantity) * item.Price
+		if item.ProductId == "" {
+			return status.Error(codes.InvalidArgument, "product_id is required for every item")
+		}
+		if item.Quantity < 1 {
+			return status.Error(codes.InvalidArgument, "quantity must be at least 1 for every item")
+		}
 	}
 
-	// Create order document
-	order := bson.M{
-		"user_id": req.UserId,
-		"items":   req.Items,
-		"status": "pending",
-		"total_amount": totalAmount,
-		"created_at": time.Now().UTC(),
-		"updated_at": time.Now().UTC(),
+	shippingAddress, err := addressFromProto(req.ShippingAddress)
+	if err != nil {
+		return err
+	}
+	if req.ShippingOption != "" && shippingAddress == nil {
+		return status.Error(codes.InvalidArgument, "shipping_address is required with a shipping_option")
 	}
+	return nil
+}
 
-	// Insert into MongoDB
-	result, err := s.db.Collection("orders").InsertOne(ctx, order)
+// createOrder places the order described by a validated request on behalf of actor.
+// subscriptionID is set for orders placed by a subscription.
+func (s *server) createOrder(ctx context.Context, req *pb.CreateOrderRequest,
+	actor, idempotencyKey, subscriptionID string) (*pb.Order, error) {
+	shippingAddress, err := addressFromProto(req.ShippingAddress)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create order: %v", err)
+		return nil, err
 	}
 
-	// Get the inserted ID
-	id := result.InsertedID.(primitive.ObjectID)
+	// A retried request with the same idempotency key returns the original order
+	hash := requestHash(req)
+	if idempotencyKey != "" {
+		existing, err := s.findIdempotentOrder(ctx, idempotencyKey, hash)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing.toProto(), nil
+		}
+	}
 
-	// Return the created order
-	return &pb.Order{
-		Id:          id.Hex(),
-		UserId:      req.UserId,
-		Items:       req.Items,
-		Status:      "pending",
-		TotalAmount: totalAmount,
-		CreatedAt:   order["created_at"].(time.Time).Format(time.RFC3339),
-		UpdatedAt:   order["updated_at"].(time.Time).Format(time.RFC3339),
-	}, nil
+	// Check every product exists and has stock. Prices come from the catalog and are
+	// snapshotted into the order so later changes don't affect it.
+	pricedItems, products, err := s.checkItems(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]orderItemModel, 0, len(pricedItems))
+	for _, item := range pricedItems {
+		items = append(items, orderItemModel{
+			ProductID: item.ProductId,
+			Quantity:  item.Quantity,
+			Price:     moneyFromProto(item.Price),
+		})
+	}
+	if _, err := itemsTotal(items); err != nil {
+		return nil, err
+	}
+
+	// Apply automatic promotions and the requested coupons. Their uses are counted when
+	// the order is stored.
+	discounts, err := s.applyPromotions(ctx, req.UserId, req.CouponCodes, items, products)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	order := orderModel{
+		UserID:         req.UserId,
+		Items:          items,
+		Discounts:      discounts,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		IdempotencyKey: idempotencyKey,
+		RequestHash:    hash,
+		StatusHistory: []statusChangeModel{
+			newStatusChange("", statusName(pb.OrderStatus_ORDER_STATUS_PENDING), actor, ""),
+		},
+		ShippingAddress: shippingAddress,
+		SubscriptionID:  subscriptionID,
+	}
+
+	// Calculate the subtotal, taxes and total in minor units. The chosen shipping
+	// option is priced now, so later rate changes don't affect the order.
+	if req.ShippingOption != "" {
+		order.Shipping = &shippingOptionModel{ID: req.ShippingOption}
+	}
+	if err := s.priceOrder(ctx, &order, products); err != nil {
+		return nil, err
+	}
+
+	// Create the order, reserve its stock and confirm it as one saga
+	placed, err := s.placeOrder(ctx, order)
+	if err != nil {
+		// A concurrent request with the same key won the race to insert the order
+		if status.Code(err) == codes.AlreadyExists && idempotencyKey != "" {
+			existing, findErr := s.findIdempotentOrder(ctx, idempotencyKey, hash)
+			if findErr != nil {
+				return nil, findErr
+			}
+			if existing != nil {
+				return existing.toProto(), nil
+			}
+		}
+		return nil, err
+	}
+
+	return placed.toProto(), nil
 }
 
 func (s *server) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
@@ -112,7 +405,7 @@ func (s *server) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Ord
 	}
 
 	// Find order in MongoDB
-	var order bson.M
+	var order orderModel
 	err = s.db.Collection("orders").FindOne(ctx, bson.M{"_id": id}).Decode(&order)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
@@ -121,39 +414,22 @@ func (s *server) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Ord
 		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
 	}
 
-	// Convert items to proto format
-	items := make([]*pb.OrderItem, 0)
-	if itemsArray, ok := order["items"].(primitive.A); ok {
-		for _, item := range itemsArray {
-			if itemMap, ok := item.(primitive.M); ok {
-				items = append(items, &pb.OrderItem{
-					ProductId: itemMap["product_id"].(string),
-					Quantity:  int32(itemMap["quantity"].(int64)),
-					Price:     itemMap["price"].(float64),
-				})
-			}
-		}
-	}
-
-	// Return the order
-	return &pb.Order{
-		Id:          req.Id,
-		UserId:      order["user_id"].(string),
-		Items:       items,
-		Status:      order["status"].(string),
-		TotalAmount: order["total_amount"].(float64),
-		CreatedAt:   order["created_at"].(primitive.DateTime).Time().Format(time.RFC3339),
-		UpdatedAt:   order["updated_at"].(primitive.DateTime).Time().Format(time.RFC3339),
-	}, nil
+	return order.toProto(), nil
 }
 
 func (s *server) UpdateOrder(ctx context.Context, req *pb.UpdateOrderRequest) (*pb.Order, error) {
 	if req.Id == "" {
 		return nil, status.Error(codes.InvalidArgument, "order id is required")
 	}
-	if req.Status == "" {
+	if req.Status == pb.OrderStatus_ORDER_STATUS_UNSPECIFIED {
 		return nil, status.Error(codes.InvalidArgument, "status is required")
 	}
+	if _, ok := pb.OrderStatus_name[int32(req.Status)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown status %d", req.Status)
+	}
+	if req.Status == pb.OrderStatus_ORDER_STATUS_PARTIALLY_SHIPPED || req.Status == pb.OrderStatus_ORDER_STATUS_SHIPPED {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is derived from shipments and cannot be set directly", statusName(req.Status))
+	}
 
 	// Convert string ID to ObjectID
 	id, err := primitive.ObjectIDFromHex(req.Id)
@@ -161,127 +437,303 @@ func (s *server) UpdateOrder(ctx context.Context, req *pb.UpdateOrderRequest) (*
 		return nil, status.Error(codes.InvalidArgument, "invalid order id")
 	}
 
-	// Update order in MongoDB
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cancellation also has to release stock, so it goes through the cancel path
+	if req.Status == pb.OrderStatus_ORDER_STATUS_CANCELLED {
+		order, err := s.cancelOrder(ctx, id, actor, req.Reason)
+		if err != nil {
+			return nil, err
+		}
+		return order.toProto(), nil
+	}
+
+	// Load the current status so the transition can be checked
+	var current orderModel
+	err = s.db.Collection("orders").FindOne(ctx, bson.M{"_id": id}).Decode(&current)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	}
+
+	from := parseStatus(current.Status)
+	if !canTransition(from, req.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot change order status from %s to %s",
+			current.Status, statusName(req.Status))
+	}
+
+	// Update order in MongoDB, appending the transition to its history
+	change := newStatusChange(current.Status, statusName(req.Status), actor, req.Reason)
 	update := bson.M{
 		"$set": bson.M{
-			"status":     req.Status,
-			"updated_at": time.Now().UTC(),
+			"status":     change.NewStatus,
+			"updated_at": change.ChangedAt,
 		},
+		"$push": bson.M{"status_history": change},
 	}
 
-	// Find and update the order
-	var updatedOrder bson.M
-	err = s.db.Collection("orders").FindOneAndUpdate(
-		ctx,
-		bson.M{"_id": id},
-		update,
-		options.FindOneAndUpdate().SetReturnDocument(options.After),
-	).Decode(&updatedOrder)
+	// Find and update the order, guarding against a concurrent status change, and
+	// record the change in the outbox in the same transaction
+	var updatedOrder orderModel
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		err := s.db.Collection("orders").FindOneAndUpdate(
+			sc,
+			bson.M{"_id": id, "status": current.Status},
+			update,
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&updatedOrder)
+		if err != nil {
+			return err
+		}
+		return s.enqueueEvent(sc, eventOrderStatusChanged, &updatedOrder, current.Status, req.Reason)
+	})
 
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, status.Error(codes.NotFound, "order not found")
+			return nil, status.Error(codes.Aborted, "order status changed concurrently, retry the update")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to update order: %v", err)
 	}
 
-	// Convert items to proto format
-	items := make([]*pb.OrderItem, 0)
-	if itemsArray, ok := updatedOrder["items"].(primitive.A); ok {
-		for _, item := range itemsArray {
-			if itemMap, ok := item.(primitive.M); ok {
-				items = append(items, &pb.OrderItem{
-					ProductId: itemMap["product_id"].(string),
-					Quantity:  int32(itemMap["quantity"].(int64)),
-					Price:     itemMap["price"].(float64),
-				})
-			}
+	return updatedOrder.toProto(), nil
+}
+
+func (s *server) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.Order, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "order id is required")
+	}
+
+	// Convert string ID to ObjectID
+	id, err := primitive.ObjectIDFromHex(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order id")
+	}
+
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.cancelOrder(ctx, id, actor, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return order.toProto(), nil
+}
+
+func (s *server) GetOrderHistory(ctx context.Context, req *pb.GetOrderRequest) (*pb.OrderHistory, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "order id is required")
+	}
+
+	// Convert string ID to ObjectID
+	id, err := primitive.ObjectIDFromHex(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order id")
+	}
+
+	// Only the history is needed
+	var order orderModel
+	err = s.db.Collection("orders").FindOne(ctx, bson.M{"_id": id},
+		options.FindOne().SetProjection(bson.M{"status_history": 1}),
+	).Decode(&order)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Error(codes.NotFound, "order not found")
 		}
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
 	}
 
-	// Return the updated order
-	return &pb.Order{
-		Id:          req.Id,
-		UserId:      updatedOrder["user_id"].(string),
-		Items:       items,
-		Status:      updatedOrder["status"].(string),
-		TotalAmount: updatedOrder["total_amount"].(float64),
-		CreatedAt:   updatedOrder["created_at"].(primitive.DateTime).Time().Format(time.RFC3339),
-		UpdatedAt:   updatedOrder["updated_at"].(primitive.DateTime).Time().Format(time.RFC3339),
+	return &pb.OrderHistory{
+		OrderId: req.Id,
+		Entries: statusHistoryToProto(order.StatusHistory),
 	}, nil
 }
 
+// cancelOrder moves a cancellable order to cancelled, records the reason and actor, and
+// returns its reserved stock to inventory.
+func (s *server) cancelOrder(ctx context.Context, id primitive.ObjectID, actor, reason string) (*orderModel, error) {
+	var current orderModel
+	err := s.db.Collection("orders").FindOne(ctx, bson.M{"_id": id}).Decode(&current)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	}
+
+	if !canTransition(parseStatus(current.Status), pb.OrderStatus_ORDER_STATUS_CANCELLED) {
+		return nil, status.Errorf(codes.FailedPrecondition, "order in status %s can no longer be cancelled", current.Status)
+	}
+
+	// Find and update the order, guarding against a concurrent status change, and
+	// record the cancellation in the outbox in the same transaction
+	change := newStatusChange(current.Status, statusName(pb.OrderStatus_ORDER_STATUS_CANCELLED), actor, reason)
+	var cancelled orderModel
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		err := s.db.Collection("orders").FindOneAndUpdate(
+			sc,
+			bson.M{"_id": id, "status": current.Status},
+			bson.M{
+				"$set": bson.M{
+					"status":              change.NewStatus,
+					"cancellation_reason": reason,
+					"updated_at":          change.ChangedAt,
+				},
+				"$push": bson.M{"status_history": change},
+			},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&cancelled)
+		if err != nil {
+			return err
+		}
+		if err := s.releasePromotions(sc, id); err != nil {
+			return err
+		}
+		return s.enqueueEvent(sc, eventOrderCancelled, &cancelled, current.Status, reason)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Error(codes.Aborted, "order status changed concurrently, retry the cancellation")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to cancel order: %v", err)
+	}
+
+	s.releaseOrderStock(ctx, &cancelled)
+	return &cancelled, nil
+}
+
 func (s *server) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
 	// Set default values for pagination
-	if req.Page < 1 {
-		req.Page = 1
-	}
 	if req.Limit < 1 || req.Limit > 100 {
 		req.Limit = 10
 	}
 
-	// Create filter
-	filter := bson.M{}
-	if req.UserId != "" {
-		filter["user_id"] = req.UserId
+	// Create filter and sort order
+	filter, err := listOrdersFilter(req)
+	if err != nil {
+		return nil, err
 	}
-
-	// Calculate skip value for pagination
-	skip := (req.Page - 1) * req.Limit
-
-	// Get total count
-	total, err := s.db.Collection("orders").CountDocuments(ctx, filter)
+	sort, err := listOrdersSort(req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to count orders: %v", err)
+		return nil, err
+	}
+	sortField, direction := sort[0].Key, sort[0].Value.(int)
+
+	// Get total count, only when asked for or for clients paging by page number
+	var total int64
+	if req.IncludeTotal || (req.PageToken == "" && req.Page > 0) {
+		total, err = s.db.Collection("orders").CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to count orders: %v", err)
+		}
+	}
+
+	// Continue after the page token, or skip to the requested page. One order more
+	// than the limit is read to tell whether there is a next page.
+	query := listOrdersQuery(req)
+	findOptions := options.Find().SetLimit(int64(req.Limit) + 1).SetSort(sort)
+	if req.PageToken != "" {
+		token, err := decodePageToken(req.PageToken, query)
+		if err != nil {
+			return nil, err
+		}
+		filter = bson.M{"$and": bson.A{filter,
+			afterFilter(sortField, direction, orderSortFilterValue(sortField, token.Value), token.ID)}}
+	} else if req.Page > 1 {
+		findOptions.SetSkip(int64((req.Page - 1) * req.Limit))
 	}
 
 	// Find orders
-	cursor, err := s.db.Collection("orders").Find(ctx, filter,
-		options.Find().
-			SetSkip(int64(skip)).
-			SetLimit(int64(req.Limit)).
-			SetSort(bson.M{"created_at": -1}),
-	)
+	cursor, err := s.db.Collection("orders").Find(ctx, filter, findOptions)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list orders: %v", err)
 	}
 	defer cursor.Close(ctx)
 
 	// Process results
-	orders := make([]*pb.Order, 0)
+	var models []orderModel
 	for cursor.Next(ctx) {
-		var order bson.M
+		var order orderModel
 		if err := cursor.Decode(&order); err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to decode order: %v", err)
 		}
+		models = append(models, order)
+	}
 
-		// Convert items to proto format
-		items := make([]*pb.OrderItem, 0)
-		if itemsArray, ok := order["items"].(primitive.A); ok {
-			for _, item := range itemsArray {
-				if itemMap, ok := item.(primitive.M); ok {
-					items = append(items, &pb.OrderItem{
-						ProductId: itemMap["product_id"].(string),
-						Quantity:  int32(itemMap["quantity"].(int64)),
-						Price:     itemMap["price"].(float64),
-					})
-				}
-			}
-		}
+	var nextPageToken string
+	if len(models) > int(req.Limit) {
+		models = models[:req.Limit]
+		last := models[len(models)-1]
+		nextPageToken = encodePageToken(query, last.sortValue(sortField), last.ID)
+	}
 
-		orders = append(orders, &pb.Order{
-			Id:          order["_id"].(primitive.ObjectID).Hex(),
-			UserId:      order["user_id"].(string),
-			Items:       items,
-			Status:      order["status"].(string),
-			TotalAmount: order["total_amount"].(float64),
-			CreatedAt:   order["created_at"].(primitive.DateTime).Time().Format(time.RFC3339),
-			UpdatedAt:   order["updated_at"].(primitive.DateTime).Time().Format(time.RFC3339),
-		})
+	orders := make([]*pb.Order, 0, len(models))
+	for i := range models {
+		orders = append(orders, models[i].toProto())
 	}
 
 	return &pb.ListOrdersResponse{
-		Orders: orders,
-		Total:  int32(total),
+		Orders:        orders,
+		Total:         int32(total),
+		NextPageToken: nextPageToken,
 	}, nil
 }
+
+// toProto converts a stored order into its protobuf representation.
+func (o *orderModel) toProto() *pb.Order {
+	items := make([]*pb.OrderItem, 0, len(o.Items))
+	for _, item := range o.Items {
+		items = append(items, &pb.OrderItem{
+			ProductId:           item.ProductID,
+			Quantity:            item.Quantity,
+			Price:               item.Price.toProto(),
+			BackorderedQuantity: item.Backordered,
+		})
+	}
+
+	// Orders placed before taxes were calculated have no subtotal
+	subtotal := o.TotalAmount
+	if o.Subtotal != nil {
+		subtotal = *o.Subtotal
+	}
+
+	return &pb.Order{
+		Id:          o.ID.Hex(),
+		UserId:      o.UserID,
+		Items:       items,
+		Status:      parseStatus(o.Status),
+		TotalAmount: o.TotalAmount.toProto(),
+		Discounts:   discountsToProto(o.Discounts),
+		Subtotal:    subtotal.toProto(),
+		TaxLines:    taxLinesToProto(o.TaxLines),
+		GrandTotal:  o.TotalAmount.toProto(),
+		Shipping:    o.Shipping.toProto(),
+		CreatedAt:   o.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   o.UpdatedAt.Format(time.RFC3339),
+
+		CancellationReason: o.CancellationReason,
+		StatusHistory:      statusHistoryToProto(o.StatusHistory),
+		ShippingAddress:    o.ShippingAddress.toProto(),
+		SubscriptionId:     o.SubscriptionID,
+	}
+}
+
+func statusHistoryToProto(history []statusChangeModel) []*pb.StatusChange {
+	entries := make([]*pb.StatusChange, 0, len(history))
+	for _, change := range history {
+		entries = append(entries, &pb.StatusChange{
+			OldStatus: parseStatus(change.OldStatus),
+			NewStatus: parseStatus(change.NewStatus),
+			ActorId:   change.ActorID,
+			Reason:    change.Reason,
+			ChangedAt: change.ChangedAt.Format(time.RFC3339),
+		})
+	}
+	return entries
+}
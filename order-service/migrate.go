@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// legacyOrderAmounts holds the amounts of an order stored before they became Money,
+// when prices and totals were floats in major units.
+type legacyOrderAmounts struct {
+	Items []struct {
+		ProductID string `bson:"product_id"`
+		// The first versions stored the request items as they were, and the driver
+		// lowercases untagged field names
+		LegacyProductID string  `bson:"productid"`
+		Quantity        int32   `bson:"quantity"`
+		Price           float64 `bson:"price"`
+	} `bson:"items"`
+	TotalAmount float64 `bson:"total_amount"`
+}
+
+// migrateLegacyAmounts converts the float amounts of orders, and of the order copies
+// kept by sagas, to Money. Totals are recomputed from the converted item prices rather
+// than converted themselves, so they match their items exactly. Each update is
+// conditional on the old total, so the migration can be re-run, and run by several
+// replicas at once. It returns the number of documents converted.
+func migrateLegacyAmounts(ctx context.Context, db *mongo.Database) (int, error) {
+	migrated := 0
+	for _, target := range []struct {
+		collection string
+		field      string // Where the order is embedded, empty for the order itself
+	}{
+		{"orders", ""},
+		{"order_sagas", "order"},
+	} {
+		prefix := ""
+		if target.field != "" {
+			prefix = target.field + "."
+		}
+
+		coll := db.Collection(target.collection)
+		cursor, err := coll.Find(ctx, bson.M{prefix + "total_amount": bson.M{"$type": "number"}})
+		if err != nil {
+			return migrated, err
+		}
+
+		for cursor.Next(ctx) {
+			doc := cursor.Current
+			if target.field != "" {
+				doc = cursor.Current.Lookup(target.field).Document()
+			}
+			var legacy legacyOrderAmounts
+			if err := bson.Unmarshal(doc, &legacy); err != nil {
+				cursor.Close(ctx)
+				return migrated, err
+			}
+
+			items, total, err := convertLegacyAmounts(&legacy)
+			if err != nil {
+				cursor.Close(ctx)
+				return migrated, fmt.Errorf("%s %s: %v", target.collection, cursor.Current.Lookup("_id").ObjectID().Hex(), err)
+			}
+
+			_, err = coll.UpdateOne(ctx,
+				bson.M{"_id": cursor.Current.Lookup("_id").ObjectID(), prefix + "total_amount": legacy.TotalAmount},
+				bson.M{"$set": bson.M{
+					prefix + "items":        items,
+					prefix + "total_amount": total,
+				}},
+			)
+			if err != nil {
+				cursor.Close(ctx)
+				return migrated, err
+			}
+			migrated++
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return migrated, err
+		}
+	}
+	return migrated, nil
+}
+
+func convertLegacyAmounts(legacy *legacyOrderAmounts) ([]orderItemModel, moneyModel, error) {
+	items := make([]orderItemModel, 0, len(legacy.Items))
+	total := moneyModel{Currency: legacyCurrency}
+	for i, item := range legacy.Items {
+		productID := item.ProductID
+		if productID == "" {
+			productID = item.LegacyProductID
+		}
+		if productID == "" {
+			// Writing the item back without it would lose which product it was
+			return nil, moneyModel{}, fmt.Errorf("item %d has no product id", i)
+		}
+
+		units, err := legacyMinorUnits(item.Price)
+		if err != nil {
+			return nil, moneyModel{}, err
+		}
+		price := moneyModel{UnitsMinor: units, Currency: legacyCurrency}
+
+		line, err := price.times(item.Quantity)
+		if err != nil {
+			return nil, moneyModel{}, err
+		}
+		if total, err = total.add(line); err != nil {
+			return nil, moneyModel{}, err
+		}
+
+		items = append(items, orderItemModel{
+			ProductID: productID,
+			Quantity:  item.Quantity,
+			Price:     price,
+		})
+	}
+	return items, total, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestConvertLegacyAmounts(t *testing.T) {
+	tests := []struct {
+		name      string
+		doc       bson.M
+		wantItems []orderItemModel
+		wantTotal int64
+		wantErr   bool
+	}{
+		{
+			name: "tagged items",
+			doc: bson.M{"total_amount": 21.5, "items": bson.A{
+				bson.M{"product_id": "p1", "quantity": int32(2), "price": 10.25},
+				bson.M{"product_id": "p2", "quantity": int32(1), "price": 1.0},
+			}},
+			wantItems: []orderItemModel{
+				{ProductID: "p1", Quantity: 2, Price: moneyModel{UnitsMinor: 1025, Currency: "USD"}},
+				{ProductID: "p2", Quantity: 1, Price: moneyModel{UnitsMinor: 100, Currency: "USD"}},
+			},
+			wantTotal: 2150,
+		},
+		{
+			name: "request items stored untagged",
+			doc: bson.M{"total_amount": 0.57, "items": bson.A{
+				bson.M{"productid": "p1", "quantity": int32(3), "price": 0.19},
+			}},
+			wantItems: []orderItemModel{
+				{ProductID: "p1", Quantity: 3, Price: moneyModel{UnitsMinor: 19, Currency: "USD"}},
+			},
+			wantTotal: 57,
+		},
+		{
+			name: "total recomputed from items",
+			doc: bson.M{"total_amount": 0.3, "items": bson.A{
+				bson.M{"product_id": "p1", "quantity": int32(3), "price": 0.1},
+			}},
+			wantItems: []orderItemModel{
+				{ProductID: "p1", Quantity: 3, Price: moneyModel{UnitsMinor: 10, Currency: "USD"}},
+			},
+			wantTotal: 30,
+		},
+		{
+			name: "item without product id",
+			doc: bson.M{"total_amount": 5.0, "items": bson.A{
+				bson.M{"quantity": int32(1), "price": 5.0},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := bson.Marshal(tt.doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var legacy legacyOrderAmounts
+			if err := bson.Unmarshal(data, &legacy); err != nil {
+				t.Fatal(err)
+			}
+
+			items, total, err := convertLegacyAmounts(&legacy)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("convertLegacyAmounts() = %v, want an error", items)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convertLegacyAmounts() returned error %v", err)
+			}
+			if !reflect.DeepEqual(items, tt.wantItems) {
+				t.Errorf("items = %+v, want %+v", items, tt.wantItems)
+			}
+			if want := (moneyModel{UnitsMinor: tt.wantTotal, Currency: "USD"}); total != want {
+				t.Errorf("total = %+v, want %+v", total, want)
+			}
+		})
+	}
+}
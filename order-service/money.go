@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+
+	pb "github.com/order-management/proto"
+)
+
+// Amounts are Money: an int64 count of the minor unit of a currency, e.g. cents for
+// USD. All arithmetic is done on these integers and amounts in different currencies
+// are never combined. Wherever a fraction of a minor unit has to be dropped, it is
+// rounded half away from zero.
+
+// legacyCurrency is the currency of amounts stored as floats before they became Money.
+const legacyCurrency = "USD"
+
+var (
+	errCurrencyMismatch = errors.New("amounts are in different currencies")
+	errAmountOverflow   = errors.New("amount is out of range")
+)
+
+type moneyModel struct {
+	UnitsMinor int64  `bson:"units_minor"`
+	Currency   string `bson:"currency"`
+}
+
+func moneyFromProto(m *pb.Money) moneyModel {
+	if m == nil {
+		return moneyModel{}
+	}
+	return moneyModel{UnitsMinor: m.UnitsMinor, Currency: m.Currency}
+}
+
+func (m moneyModel) toProto() *pb.Money {
+	return &pb.Money{UnitsMinor: m.UnitsMinor, Currency: m.Currency}
+}
+
+// add returns m + other. The zero value adopts the currency of the other amount.
+func (m moneyModel) add(other moneyModel) (moneyModel, error) {
+	if m.Currency == "" && m.UnitsMinor == 0 {
+		m.Currency = other.Currency
+	}
+	if m.Currency != other.Currency {
+		return moneyModel{}, errCurrencyMismatch
+	}
+	sum := m.UnitsMinor + other.UnitsMinor
+	if (other.UnitsMinor > 0 && sum < m.UnitsMinor) || (other.UnitsMinor < 0 && sum > m.UnitsMinor) {
+		return moneyModel{}, errAmountOverflow
+	}
+	return moneyModel{UnitsMinor: sum, Currency: m.Currency}, nil
+}
+
+// sub returns m - other.
+func (m moneyModel) sub(other moneyModel) (moneyModel, error) {
+	return m.add(moneyModel{UnitsMinor: -other.UnitsMinor, Currency: other.Currency})
+}
+
+// times returns m multiplied by a quantity.
+func (m moneyModel) times(quantity int32) (moneyModel, error) {
+	if quantity != 0 && (m.UnitsMinor > math.MaxInt64/int64(quantity) || m.UnitsMinor < math.MinInt64/int64(quantity)) {
+		return moneyModel{}, errAmountOverflow
+	}
+	return moneyModel{UnitsMinor: m.UnitsMinor * int64(quantity), Currency: m.Currency}, nil
+}
+
+// format returns the amount in major units followed by its currency, e.g. "12.50 USD".
+func (m moneyModel) format() string {
+	digits := currencyDigits(m.Currency)
+	if digits == 0 {
+		return fmt.Sprintf("%d %s", m.UnitsMinor, m.Currency)
+	}
+	r := new(big.Rat).SetFrac(big.NewInt(m.UnitsMinor), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil))
+	return fmt.Sprintf("%s %s", r.FloatString(digits), m.Currency)
+}
+
+// currencyDigits returns the number of decimal places of the minor unit of a
+// currency: 2 for most, 0 or 3 for the few listed here.
+func currencyDigits(currency string) int {
+	switch currency {
+	case "BIF", "CLP", "DJF", "GNF", "ISK", "JPY", "KMF", "KRW", "PYG", "RWF", "UGX", "VND", "VUV", "XAF", "XOF", "XPF":
+		return 0
+	case "BHD", "IQD", "JOD", "KWD", "LYD", "OMR", "TND":
+		return 3
+	}
+	return 2
+}
+
+// mulDiv returns amount * num / den, rounded half away from zero.
+func mulDiv(amount, num, den int64) (int64, error) {
+	product := new(big.Int).Mul(big.NewInt(amount), big.NewInt(num))
+	return roundRat(new(big.Rat).SetFrac(product, big.NewInt(den)))
+}
+
+// allocate splits a non-negative amount over non-negative weights in proportion to
+// each weight. Every share is rounded down, then the units left over go one each to
+// the shares with the largest remainders, earlier shares first on ties. The shares
+// add up to amount exactly, and none exceeds its weight while amount is at most the
+// sum of the weights.
+func allocate(amount int64, weights []int64) []int64 {
+	shares := make([]int64, len(weights))
+	total := new(big.Int)
+	for _, w := range weights {
+		total.Add(total, big.NewInt(w))
+	}
+	if total.Sign() == 0 {
+		return shares
+	}
+
+	remainders := make([]*big.Int, len(weights))
+	left := amount
+	for i, w := range weights {
+		q, r := new(big.Int).QuoRem(new(big.Int).Mul(big.NewInt(amount), big.NewInt(w)), total, new(big.Int))
+		shares[i], remainders[i] = q.Int64(), r
+		left -= shares[i]
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+	for _, i := range order[:left] {
+		shares[i]++
+	}
+	return shares
+}
+
+// legacyMinorUnits converts a float amount in major units of legacyCurrency to minor
+// units. The float is read as the shortest decimal that represents it, so 0.285 is
+// 29 cents rather than the 28 its binary value 0.28499999... would round to.
+func legacyMinorUnits(amount float64) (int64, error) {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	if !ok {
+		return 0, fmt.Errorf("invalid amount %v", amount)
+	}
+	return roundRat(r.Mul(r, big.NewRat(100, 1)))
+}
+
+// roundRat rounds r half away from zero to an integer.
+func roundRat(r *big.Rat) (int64, error) {
+	half := big.NewRat(1, 2)
+	if r.Sign() < 0 {
+		half.Neg(half)
+	}
+	r = new(big.Rat).Add(r, half)
+
+	// Quo truncates toward zero
+	q := new(big.Int).Quo(r.Num(), r.Denom())
+	if !q.IsInt64() {
+		return 0, errAmountOverflow
+	}
+	return q.Int64(), nil
+}
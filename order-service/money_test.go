@@ -0,0 +1,160 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestRoundRat(t *testing.T) {
+	tests := []struct {
+		num, den int64
+		want     int64
+	}{
+		{5, 2, 3},
+		{-5, 2, -3},
+		{3, 2, 2},
+		{-3, 2, -2},
+		{7, 3, 2},
+		{-7, 3, -2},
+		{8, 3, 3},
+		{-8, 3, -3},
+		{1, 2, 1},
+		{-1, 2, -1},
+		{1, 3, 0},
+		{-1, 3, 0},
+		{0, 1, 0},
+		{42, 1, 42},
+	}
+	for _, tt := range tests {
+		got, err := roundRat(big.NewRat(tt.num, tt.den))
+		if err != nil {
+			t.Errorf("roundRat(%d/%d) returned error %v", tt.num, tt.den, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("roundRat(%d/%d) = %d, want %d", tt.num, tt.den, got, tt.want)
+		}
+	}
+}
+
+func TestRoundRatOverflow(t *testing.T) {
+	r := new(big.Rat).SetInt64(math.MaxInt64)
+	r.Add(r, big.NewRat(1, 1))
+	if _, err := roundRat(r); err != errAmountOverflow {
+		t.Errorf("roundRat(MaxInt64 + 1) error = %v, want %v", err, errAmountOverflow)
+	}
+}
+
+func TestMulDiv(t *testing.T) {
+	tests := []struct {
+		amount, num, den int64
+		want             int64
+	}{
+		{7, 1, 2, 4},
+		{-7, 1, 2, -4},
+		{10, 1, 3, 3},
+		{-10, 2, 3, -7},
+		{1999, 15, 100, 300},   // 299.85
+		{1000, 725, 10000, 73}, // 72.5
+		{-1000, 725, 10000, -73},
+		{0, 5, 7, 0},
+		{math.MaxInt64, 2, 2, math.MaxInt64}, // The product is not cut to 64 bits
+	}
+	for _, tt := range tests {
+		got, err := mulDiv(tt.amount, tt.num, tt.den)
+		if err != nil {
+			t.Errorf("mulDiv(%d, %d, %d) returned error %v", tt.amount, tt.num, tt.den, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("mulDiv(%d, %d, %d) = %d, want %d", tt.amount, tt.num, tt.den, got, tt.want)
+		}
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int64
+		weights []int64
+		want    []int64
+	}{
+		{"exact", 100, []int64{50, 30, 20}, []int64{50, 30, 20}},
+		{"ties go to earlier shares", 10, []int64{1, 1, 1}, []int64{4, 3, 3}},
+		{"two left over on ties", 11, []int64{1, 1, 1}, []int64{4, 4, 3}},
+		{"largest remainder first", 10, []int64{1, 2}, []int64{3, 7}},
+		{"largest remainder not first", 5, []int64{2, 3, 3}, []int64{1, 2, 2}},
+		{"zero amount", 0, []int64{3, 4}, []int64{0, 0}},
+		{"zero weights", 10, []int64{0, 0}, []int64{0, 0}},
+		{"zero weight gets nothing", 7, []int64{0, 5, 5}, []int64{0, 4, 3}},
+		{"amount equals weights", 15, []int64{5, 4, 6}, []int64{5, 4, 6}},
+		{"no weights", 10, nil, []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := allocate(tt.amount, tt.weights)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("allocate(%d, %v) = %v, want %v", tt.amount, tt.weights, got, tt.want)
+			}
+			var sum int64
+			for _, share := range got {
+				sum += share
+			}
+			if !allZero(tt.weights) && sum != tt.amount {
+				t.Errorf("shares add up to %d, want %d", sum, tt.amount)
+			}
+		})
+	}
+}
+
+func allZero(values []int64) bool {
+	for _, v := range values {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLegacyMinorUnits(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   int64
+	}{
+		{19.99, 1999},
+		{0.285, 29},
+		{-0.285, -29},
+		{0.1 + 0.2, 30},
+		{1e-9, 0},
+		{12, 1200},
+	}
+	for _, tt := range tests {
+		got, err := legacyMinorUnits(tt.amount)
+		if err != nil {
+			t.Errorf("legacyMinorUnits(%v) returned error %v", tt.amount, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("legacyMinorUnits(%v) = %d, want %d", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	tests := []struct {
+		money moneyModel
+		want  string
+	}{
+		{moneyModel{UnitsMinor: 1250, Currency: "USD"}, "12.50 USD"},
+		{moneyModel{UnitsMinor: -5, Currency: "EUR"}, "-0.05 EUR"},
+		{moneyModel{UnitsMinor: 1250, Currency: "JPY"}, "1250 JPY"},
+		{moneyModel{UnitsMinor: 1250, Currency: "KWD"}, "1.250 KWD"},
+	}
+	for _, tt := range tests {
+		if got := tt.money.format(); got != tt.want {
+			t.Errorf("%+v.format() = %q, want %q", tt.money, got, tt.want)
+		}
+	}
+}
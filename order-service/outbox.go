@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Order events are written to the order_outbox collection in the same transaction as
+// the order change that caused them. A relay goroutine publishes pending events and
+// marks them published, retrying with backoff until the publisher accepts them, so
+// every event is delivered at least once. Consumers should dedupe on event_id. The
+// events of an order are published in the order they were written: while one is
+// waiting to be retried, the later events of its order wait too. Only the replica
+// holding the outbox lease relays events.
+
+const (
+	eventOrderCreated            = "OrderCreated"
+	eventOrderStatusChanged      = "OrderStatusChanged"
+	eventOrderCancelled          = "OrderCancelled"
+	eventOrderItemsChanged       = "OrderItemsChanged"
+	eventOrderBackorderAllocated = "OrderBackorderAllocated"
+)
+
+const (
+	outboxPollInterval = time.Second
+	outboxBatchSize    = 100
+	outboxMaxBackoff   = time.Minute
+)
+
+// outboxLease is the lease that lets one replica at a time relay events. A run stops
+// publishing once half of outboxLeaseDuration has passed, so it ends while the lease
+// is still held even when publishes time out.
+const (
+	outboxLease         = "relay_order_events"
+	outboxLeaseDuration = 30 * time.Second
+)
+
+type outboxModel struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	Type          string             `bson:"type"`
+	Subject       string             `bson:"subject"`
+	OrderID       primitive.ObjectID `bson:"order_id"`
+	Payload       []byte             `bson:"payload"`
+	Attempts      int                `bson:"attempts"`
+	LastError     string             `bson:"last_error,omitempty"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at"`
+	PublishedAt   *time.Time         `bson:"published_at"`
+	CreatedAt     time.Time          `bson:"created_at"`
+}
+
+// orderEvent is the JSON payload published for every order event.
+type orderEvent struct {
+	EventID    string          `json:"event_id"`
+	Type       string          `json:"type"`
+	OrderID    string          `json:"order_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	OldStatus  string          `json:"old_status,omitempty"`
+	NewStatus  string          `json:"new_status,omitempty"`
+	Reason     string          `json:"reason,omitempty"`
+	Order      json.RawMessage `json:"order"`
+}
+
+// withTransaction runs fn inside a MongoDB transaction, retrying on transient errors.
+// fn must use the session context it is given for every operation.
+func (s *server) withTransaction(ctx context.Context, fn func(sc mongo.SessionContext) error) error {
+	session, err := s.db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
+// enqueueEvent records an event for order in the outbox. oldStatus is empty for
+// events that are not status changes.
+func (s *server) enqueueEvent(sc mongo.SessionContext, eventType string, order *orderModel, oldStatus, reason string) error {
+	orderJSON, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(order.toProto())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	event := outboxModel{
+		ID:            primitive.NewObjectID(),
+		Type:          eventType,
+		Subject:       "orders." + eventType,
+		OrderID:       order.ID,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+
+	payload := orderEvent{
+		EventID:    event.ID.Hex(),
+		Type:       eventType,
+		OrderID:    order.ID.Hex(),
+		OccurredAt: now,
+		OldStatus:  oldStatus,
+		NewStatus:  order.Status,
+		Reason:     reason,
+		Order:      orderJSON,
+	}
+	if event.Payload, err = json.Marshal(payload); err != nil {
+		return err
+	}
+
+	_, err = s.db.Collection("order_outbox").InsertOne(sc, event)
+	return err
+}
+
+// relayEvents publishes pending outbox events until ctx is done. Only the replica
+// holding the outbox lease does so.
+func (s *server) relayEvents(ctx context.Context) {
+	holder := newInstanceID()
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		held, err := acquireLease(ctx, s.db, outboxLease, holder, outboxLeaseDuration)
+		if err != nil {
+			log.Printf("Failed to acquire the %s lease: %v", outboxLease, err)
+		} else if held {
+			s.publishPendingEvents(ctx, time.Now().Add(outboxLeaseDuration/2))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishPendingEvents publishes up to outboxBatchSize unpublished events in creation
+// order, until deadline. An event is only published once every earlier event of its
+// order has been: when one fails, or is not due for its next attempt yet, the later
+// events of the same order are skipped, while those of other orders still go out.
+func (s *server) publishPendingEvents(ctx context.Context, deadline time.Time) {
+	cursor, err := s.db.Collection("order_outbox").Find(ctx,
+		bson.M{"published_at": nil},
+		options.Find().SetSort(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		log.Printf("Failed to read order outbox: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	// Orders with an earlier event still unpublished
+	waiting := map[primitive.ObjectID]bool{}
+	for published := 0; published < outboxBatchSize && time.Now().Before(deadline) && cursor.Next(ctx); {
+		var event outboxModel
+		if err := cursor.Decode(&event); err != nil {
+			log.Printf("Failed to decode outbox event: %v", err)
+			return
+		}
+		if waiting[event.OrderID] {
+			continue
+		}
+		if event.NextAttemptAt.After(time.Now()) {
+			waiting[event.OrderID] = true
+			continue
+		}
+
+		publishCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		err := s.publisher.Publish(publishCtx, event.Subject, event.Payload)
+		cancel()
+		published++
+
+		if err != nil {
+			s.deferEvent(ctx, &event, err)
+			waiting[event.OrderID] = true
+			continue
+		}
+
+		_, err = s.db.Collection("order_outbox").UpdateOne(ctx,
+			bson.M{"_id": event.ID},
+			bson.M{"$set": bson.M{"published_at": time.Now().UTC()}, "$inc": bson.M{"attempts": 1}},
+		)
+		if err != nil {
+			// The event will be published again, which at-least-once delivery allows
+			log.Printf("Failed to mark outbox event %s published: %v", event.ID.Hex(), err)
+			return
+		}
+	}
+}
+
+// deferEvent records a failed publish and schedules the next attempt with exponential backoff.
+func (s *server) deferEvent(ctx context.Context, event *outboxModel, publishErr error) {
+	backoff := outboxPollInterval << uint(minInt(event.Attempts, 10))
+	if backoff > outboxMaxBackoff {
+		backoff = outboxMaxBackoff
+	}
+	log.Printf("Failed to publish outbox event %s (attempt %d), retrying in %s: %v",
+		event.ID.Hex(), event.Attempts+1, backoff, publishErr)
+
+	_, err := s.db.Collection("order_outbox").UpdateOne(ctx,
+		bson.M{"_id": event.ID},
+		bson.M{
+			"$set": bson.M{
+				"last_error":      publishErr.Error(),
+				"next_attempt_at": time.Now().UTC().Add(backoff),
+			},
+			"$inc": bson.M{"attempts": 1},
+		},
+	)
+	if err != nil {
+		log.Printf("Failed to record publish failure for outbox event %s: %v", event.ID.Hex(), err)
+	}
+}
+
+// ensureOutboxIndex creates the index the relay uses to find pending events in order.
+func ensureOutboxIndex(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("order_outbox").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "published_at", Value: 1}, {Key: "_id", Value: 1}},
+	})
+	return err
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
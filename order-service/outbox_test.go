@@ -0,0 +1,334 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// flakyPublisher publishes to a memoryPublisher, except for the payloads in fail.
+type flakyPublisher struct {
+	*memoryPublisher
+	fail      map[string]bool
+	attempted []string
+	onPublish func()
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, subject string, payload []byte) error {
+	p.attempted = append(p.attempted, string(payload))
+	if p.onPublish != nil {
+		p.onPublish()
+	}
+	if p.fail[string(payload)] {
+		return errors.New("broker unavailable")
+	}
+	return p.memoryPublisher.Publish(ctx, subject, payload)
+}
+
+func (p *flakyPublisher) payloads() []string {
+	var payloads []string
+	for _, event := range p.Events() {
+		payloads = append(payloads, string(event.Payload))
+	}
+	return payloads
+}
+
+// outboxDocs returns events as the outbox cursor returns them.
+func outboxDocs(t *testing.T, events []outboxModel) []bson.D {
+	t.Helper()
+	var docs []bson.D
+	for _, event := range events {
+		data, err := bson.Marshal(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var doc bson.D
+		if err := bson.Unmarshal(data, &doc); err != nil {
+			t.Fatal(err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// outboxUpdate is an update the relay sent for one event.
+type outboxUpdate struct {
+	ID  primitive.ObjectID
+	Set bson.Raw
+	Inc bson.Raw
+}
+
+// outboxUpdates returns the updates sent to the outbox, in order.
+func outboxUpdates(mt *mtest.T) []outboxUpdate {
+	var updates []outboxUpdate
+	for _, started := range mt.GetAllStartedEvents() {
+		if started.CommandName != "update" || started.Command.Lookup("update").StringValue() != "order_outbox" {
+			continue
+		}
+		update := started.Command.Lookup("updates").Array().Index(0).Value().Document()
+		id, _ := update.Lookup("q", "_id").ObjectIDOK()
+		set, _ := update.Lookup("u", "$set").DocumentOK()
+		inc, _ := update.Lookup("u", "$inc").DocumentOK()
+		updates = append(updates, outboxUpdate{ID: id, Set: set, Inc: inc})
+	}
+	return updates
+}
+
+func TestPublishPendingEvents(t *testing.T) {
+	orderA, orderB := primitive.NewObjectID(), primitive.NewObjectID()
+	now := time.Now().UTC()
+	event := func(orderID primitive.ObjectID, payload string, nextAttemptAt time.Time) outboxModel {
+		return outboxModel{
+			ID:            primitive.NewObjectID(),
+			Type:          eventOrderStatusChanged,
+			Subject:       "orders." + eventOrderStatusChanged,
+			OrderID:       orderID,
+			Payload:       []byte(payload),
+			NextAttemptAt: nextAttemptAt,
+			CreatedAt:     now,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		events        []outboxModel
+		fail          map[string]bool
+		deadline      time.Time
+		wantAttempted []string
+		wantPublished []string
+		wantDeferred  []string
+	}{
+		{
+			name:          "events are published in order",
+			events:        []outboxModel{event(orderA, "a1", now), event(orderB, "b1", now), event(orderA, "a2", now)},
+			wantAttempted: []string{"a1", "b1", "a2"},
+			wantPublished: []string{"a1", "b1", "a2"},
+		},
+		{
+			name:          "a failed event holds back the later events of its order only",
+			events:        []outboxModel{event(orderA, "a1", now), event(orderA, "a2", now), event(orderB, "b1", now)},
+			fail:          map[string]bool{"a1": true},
+			wantAttempted: []string{"a1", "b1"},
+			wantPublished: []string{"b1"},
+			wantDeferred:  []string{"a1"},
+		},
+		{
+			name: "an event waiting for its retry holds back the later events of its order",
+			events: []outboxModel{event(orderA, "a1", now.Add(time.Minute)), event(orderA, "a2", now),
+				event(orderB, "b1", now)},
+			wantAttempted: []string{"b1"},
+			wantPublished: []string{"b1"},
+		},
+		{
+			name:          "an event due for its retry is published",
+			events:        []outboxModel{event(orderA, "a1", now.Add(-time.Second)), event(orderA, "a2", now)},
+			wantAttempted: []string{"a1", "a2"},
+			wantPublished: []string{"a1", "a2"},
+		},
+		{
+			name:     "nothing is published once the run's share of the lease is over",
+			events:   []outboxModel{event(orderA, "a1", now)},
+			deadline: now.Add(-time.Second),
+		},
+	}
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "order_management.order_outbox", mtest.FirstBatch,
+				outboxDocs(mt.T, tt.events)...))
+			for range tt.wantAttempted {
+				mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+			}
+			publisher := &flakyPublisher{memoryPublisher: newMemoryPublisher(), fail: tt.fail}
+			srv := &server{db: mt.Client.Database("order_management"), publisher: publisher}
+
+			deadline := tt.deadline
+			if deadline.IsZero() {
+				deadline = time.Now().Add(time.Minute)
+			}
+			srv.publishPendingEvents(context.Background(), deadline)
+
+			if !reflect.DeepEqual(publisher.attempted, tt.wantAttempted) {
+				t.Errorf("attempted %q, want %q", publisher.attempted, tt.wantAttempted)
+			}
+			if got := publisher.payloads(); !reflect.DeepEqual(got, tt.wantPublished) {
+				t.Errorf("published %q, want %q", got, tt.wantPublished)
+			}
+
+			payloads := map[primitive.ObjectID]string{}
+			for _, event := range tt.events {
+				payloads[event.ID] = string(event.Payload)
+			}
+			var published, deferred []string
+			for _, update := range outboxUpdates(mt) {
+				switch {
+				case update.Set.Lookup("published_at").Type == bson.TypeDateTime:
+					published = append(published, payloads[update.ID])
+				case update.Set.Lookup("next_attempt_at").Type == bson.TypeDateTime:
+					deferred = append(deferred, payloads[update.ID])
+				default:
+					t.Errorf("unexpected update of event %s: $set %s", payloads[update.ID], update.Set)
+				}
+			}
+			if !reflect.DeepEqual(published, tt.wantPublished) {
+				t.Errorf("marked published %q, want %q", published, tt.wantPublished)
+			}
+			if !reflect.DeepEqual(deferred, tt.wantDeferred) {
+				t.Errorf("deferred %q, want %q", deferred, tt.wantDeferred)
+			}
+		})
+	}
+}
+
+func TestDeferEventBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{3, 8 * time.Second},
+		{5, 32 * time.Second},
+		{6, time.Minute},
+		{10, time.Minute},
+		{40, time.Minute},
+	}
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	for _, tt := range tests {
+		mt.Run(tt.want.String(), func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+			srv := &server{db: mt.Client.Database("order_management")}
+			event := &outboxModel{ID: primitive.NewObjectID(), Attempts: tt.attempts}
+
+			before := time.Now().Truncate(time.Millisecond)
+			srv.deferEvent(context.Background(), event, errors.New("broker unavailable"))
+			after := time.Now()
+
+			updates := outboxUpdates(mt)
+			if len(updates) != 1 || updates[0].ID != event.ID {
+				t.Fatalf("updates = %+v, want one of event %s", updates, event.ID.Hex())
+			}
+			next := updates[0].Set.Lookup("next_attempt_at").Time()
+			if next.Before(before.Add(tt.want)) || next.After(after.Add(tt.want)) {
+				t.Errorf("next attempt after %d attempts in %s, want %s", tt.attempts, next.Sub(before), tt.want)
+			}
+			if got := updates[0].Set.Lookup("last_error").StringValue(); got != "broker unavailable" {
+				t.Errorf("last_error = %q, want %q", got, "broker unavailable")
+			}
+			if got := updates[0].Inc.Lookup("attempts").AsInt64(); got != 1 {
+				t.Errorf("attempts incremented by %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestAcquireLease(t *testing.T) {
+	tests := []struct {
+		name     string
+		response bson.D
+		wantHeld bool
+		wantErr  bool
+	}{
+		{
+			name:     "taken or renewed",
+			response: mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+			wantHeld: true,
+		},
+		{
+			name: "held by another replica",
+			response: mtest.CreateWriteErrorsResponse(mtest.WriteError{
+				Code: 11000, Message: "E11000 duplicate key error collection: order_management.leases index: _id_"}),
+		},
+		{
+			name:     "database error",
+			response: mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 91, Name: "ShutdownInProgress", Message: "shutting down"}),
+			wantErr:  true,
+		},
+	}
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			mt.AddMockResponses(tt.response)
+			held, err := acquireLease(context.Background(), mt.Client.Database("order_management"),
+				outboxLease, "replica-1", outboxLeaseDuration)
+			if held != tt.wantHeld || (err != nil) != tt.wantErr {
+				t.Fatalf("acquireLease() = %v, %v; want %v, error %v", held, err, tt.wantHeld, tt.wantErr)
+			}
+
+			started := mt.GetStartedEvent()
+			if started == nil || started.CommandName != "update" || started.Command.Lookup("update").StringValue() != "leases" {
+				t.Fatalf("acquireLease() sent %v, want an update of the leases", started)
+			}
+			update := started.Command.Lookup("updates").Array().Index(0).Value().Document()
+			if id := update.Lookup("q", "_id").StringValue(); id != outboxLease {
+				t.Errorf("lease id = %q, want %q", id, outboxLease)
+			}
+			if holder := update.Lookup("u", "$set", "holder").StringValue(); holder != "replica-1" {
+				t.Errorf("holder = %q, want replica-1", holder)
+			}
+			if !update.Lookup("upsert").Boolean() {
+				t.Errorf("lease update is not an upsert")
+			}
+		})
+	}
+}
+
+func TestRelayEventsOnlyWithLease(t *testing.T) {
+	tests := []struct {
+		name          string
+		lease         bson.D
+		wantPublished []string
+	}{
+		{
+			name:          "lease held",
+			lease:         mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+			wantPublished: []string{"a1"},
+		},
+		{
+			name:  "lease held by another replica",
+			lease: mtest.CreateWriteErrorsResponse(mtest.WriteError{Code: 11000, Message: "E11000 duplicate key error"}),
+		},
+	}
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			event := outboxModel{
+				ID:            primitive.NewObjectID(),
+				Subject:       "orders." + eventOrderCreated,
+				OrderID:       primitive.NewObjectID(),
+				Payload:       []byte("a1"),
+				NextAttemptAt: time.Now().UTC(),
+			}
+			mt.AddMockResponses(tt.lease,
+				mtest.CreateCursorResponse(0, "order_management.order_outbox", mtest.FirstBatch, outboxDocs(mt.T, []outboxModel{event})...))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			// Stop the relay once it publishes, or before its next run
+			publisher := &flakyPublisher{memoryPublisher: newMemoryPublisher(), onPublish: cancel}
+			srv := &server{db: mt.Client.Database("order_management"), publisher: publisher}
+			time.AfterFunc(outboxPollInterval/2, cancel)
+
+			done := make(chan struct{})
+			go func() {
+				srv.relayEvents(ctx)
+				close(done)
+			}()
+			<-done
+
+			if got := publisher.payloads(); !reflect.DeepEqual(got, tt.wantPublished) {
+				t.Errorf("published %q, want %q", got, tt.wantPublished)
+			}
+		})
+	}
+}
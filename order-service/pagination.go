@@ -0,0 +1,64 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Lists are paged by keyset rather than by skipping: a page token holds the sort value
+// and _id of the last item of a page, and the next page starts right after it, so
+// items added or removed meanwhile don't shift the pages. Tokens are opaque to clients
+// and only accepted for the query they were issued for.
+
+type pageToken struct {
+	Query string             `json:"q"`
+	Value int64              `json:"v"`
+	ID    primitive.ObjectID `json:"id"`
+}
+
+// queryFingerprint identifies the filter and sort of a list request.
+func queryFingerprint(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:8])
+}
+
+func encodePageToken(query string, value int64, id primitive.ObjectID) string {
+	data, _ := json.Marshal(pageToken{Query: queryFingerprint(query), Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken parses a page token issued for query.
+func decodePageToken(token, query string) (*pageToken, error) {
+	var decoded pageToken
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, &decoded)
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	if decoded.Query != queryFingerprint(query) {
+		return nil, status.Error(codes.InvalidArgument, "page_token was issued for a different query")
+	}
+	return &decoded, nil
+}
+
+// afterFilter matches the items that come after the one with the given sort value and
+// _id, in a sort by field and then _id, both in direction.
+func afterFilter(field string, direction int, value interface{}, id primitive.ObjectID) bson.M {
+	op := "$lt"
+	if direction > 0 {
+		op = "$gt"
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{op: value}},
+		bson.M{field: value, "_id": bson.M{op: id}},
+	}}
+}
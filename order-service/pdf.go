@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Invoices are typeset as plain text in Courier, one of the standard PDF fonts every
+// reader has, so no font has to be embedded and the columns of a text template line
+// up. Text outside Latin-1 is replaced by "?".
+
+const (
+	pdfPageWidth    = 595 // A4, in points
+	pdfPageHeight   = 842
+	pdfMargin       = 50
+	pdfFontSize     = 10
+	pdfLineHeight   = 12
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLineHeight
+
+	// Courier glyphs are 0.6 of the font size wide
+	pdfLineLength = (pdfPageWidth - 2*pdfMargin) * 10 / (6 * pdfFontSize)
+)
+
+// renderPDF returns a PDF document showing text, wrapping lines longer than
+// pdfLineLength and starting a new page every pdfLinesPerPage lines.
+func renderPDF(text string) []byte {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		runes := []rune(strings.ReplaceAll(line, "\t", "    "))
+		for len(runes) > pdfLineLength {
+			lines = append(lines, string(runes[:pdfLineLength]))
+			runes = runes[pdfLineLength:]
+		}
+		lines = append(lines, string(runes))
+	}
+
+	var pages [][]string
+	for len(lines) > pdfLinesPerPage {
+		pages = append(pages, lines[:pdfLinesPerPage])
+		lines = lines[pdfLinesPerPage:]
+	}
+	pages = append(pages, lines)
+
+	// Objects 1 to 3 are the catalog, the page tree and the font; each page is
+	// followed by its content stream
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range pages {
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLineHeight,
+			pdfMargin, pdfPageHeight-pdfMargin-pdfFontSize)
+		for _, line := range page {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", pdfString(line))
+		}
+		content.WriteString("ET")
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 5+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+// pdfString encodes s for a PDF literal string: Latin-1 bytes, with the delimiters
+// and the escape character escaped.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < ' ' || r > 0xff || (r >= 0x7f && r < 0xa0):
+			b.WriteByte('?')
+		default:
+			b.WriteByte(byte(r))
+		}
+	}
+	return b.String()
+}
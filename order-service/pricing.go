@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// itemsTotal adds up the amounts of items. All items must be priced in one currency.
+func itemsTotal(items []orderItemModel) (moneyModel, error) {
+	var total moneyModel
+	for _, item := range items {
+		line, err := item.Price.times(item.Quantity)
+		if err == nil {
+			total, err = total.add(line)
+		}
+		if err == errCurrencyMismatch {
+			return moneyModel{}, status.Error(codes.FailedPrecondition, "order items are priced in different currencies")
+		}
+		if err != nil {
+			return moneyModel{}, status.Error(codes.InvalidArgument, "order total is out of range")
+		}
+	}
+	return total, nil
+}
+
+// priceOrder works out the amounts of an order from its items, discounts, shipping
+// address and shipping option: the subtotal, the taxes, the shipping cost and the
+// total. The shipping option is priced from the current rates.
+func (s *server) priceOrder(ctx context.Context, order *orderModel, products map[string]*pb.Product) error {
+	total, err := itemsTotal(order.Items)
+	if err != nil {
+		return err
+	}
+	for _, discount := range order.Discounts {
+		total.UnitsMinor -= discount.Amount.UnitsMinor
+	}
+	subtotal := total
+
+	// Tax what is charged for each product at the shipping address. Inclusive taxes
+	// are already part of the prices; exclusive ones are added to the total.
+	var taxLines []taxLineModel
+	if order.ShippingAddress != nil {
+		taxLines, err = s.taxes.Calculate(ctx, *order.ShippingAddress, taxableItems(order.Items, order.Discounts, products))
+		if err == nil {
+			for _, line := range taxLines {
+				if !line.Inclusive {
+					if total, err = total.add(line.Amount); err != nil {
+						break
+					}
+				}
+			}
+		}
+		if err == errAmountOverflow {
+			return status.Error(codes.InvalidArgument, "order total is out of range")
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to calculate taxes: %v", err)
+		}
+	}
+
+	var shipping *shippingOptionModel
+	if order.Shipping != nil {
+		shipping, err = s.chooseShipping(order.Shipping.ID, *order.ShippingAddress, subtotal.Currency, order.Items, products)
+		if err != nil {
+			return err
+		}
+		if total, err = total.add(shipping.Cost); err != nil {
+			return status.Error(codes.InvalidArgument, "order total is out of range")
+		}
+	}
+
+	order.Subtotal = &subtotal
+	order.TaxLines = taxLines
+	order.Shipping = shipping
+	order.TotalAmount = total
+	return nil
+}
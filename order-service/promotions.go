@@ -0,0 +1,799 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// Promotions are evaluated when an order is created. Promotions without a code apply
+// to every order, followed by the coupons sent with the order in the order given. Each
+// promotion discounts what is left of an item's amount after the promotions before it,
+// so discounts never take an item below zero. Percentages are rounded half away from
+// zero per item, and fixed amounts are split over the eligible items with allocate.
+//
+// Uses are counted when the order is stored, in the same transaction, and given back
+// when the order fails or is cancelled. promotion_redemptions records which order used
+// which promotion, and promotion_usage counts the uses per promotion and user.
+
+type promotionModel struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	Code           string             `bson:"code"`
+	Description    string             `bson:"description"`
+	Type           string             `bson:"type"`
+	PercentOff     int32              `bson:"percent_off,omitempty"`
+	AmountOff      *moneyModel        `bson:"amount_off,omitempty"`
+	BuyQuantity    int32              `bson:"buy_quantity,omitempty"`
+	GetQuantity    int32              `bson:"get_quantity,omitempty"`
+	ProductIDs     []string           `bson:"product_ids"`
+	Categories     []string           `bson:"categories"`
+	StartsAt       *time.Time         `bson:"starts_at,omitempty"`
+	EndsAt         *time.Time         `bson:"ends_at,omitempty"`
+	MaxUses        int32              `bson:"max_uses"`
+	MaxUsesPerUser int32              `bson:"max_uses_per_user"`
+	UsedCount      int32              `bson:"used_count"`
+	Active         bool               `bson:"active"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at"`
+}
+
+type discountModel struct {
+	PromotionID primitive.ObjectID `bson:"promotion_id"`
+	Code        string             `bson:"code,omitempty"`
+	Description string             `bson:"description,omitempty"`
+	ProductID   string             `bson:"product_id"`
+	Amount      moneyModel         `bson:"amount"`
+}
+
+type redemptionModel struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	PromotionID primitive.ObjectID `bson:"promotion_id"`
+	UserID      string             `bson:"user_id"`
+	OrderID     primitive.ObjectID `bson:"order_id"`
+	CreatedAt   time.Time          `bson:"created_at"`
+}
+
+// promotionServer implements the PromotionService CRUD RPCs.
+type promotionServer struct {
+	pb.UnimplementedPromotionServiceServer
+	db *mongo.Database
+}
+
+func (s *promotionServer) CreatePromotion(ctx context.Context, req *pb.Promotion) (*pb.Promotion, error) {
+	promotion, err := promotionFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	promotion.ID = primitive.NewObjectID()
+	promotion.CreatedAt = time.Now().UTC()
+	promotion.UpdatedAt = promotion.CreatedAt
+
+	if _, err := s.db.Collection("promotions").InsertOne(ctx, promotion); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, status.Error(codes.AlreadyExists, "a promotion with this code already exists")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create promotion: %v", err)
+	}
+
+	return promotion.toProto(), nil
+}
+
+func (s *promotionServer) GetPromotion(ctx context.Context, req *pb.GetPromotionRequest) (*pb.Promotion, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "promotion id is required")
+	}
+
+	// Convert string ID to ObjectID
+	id, err := primitive.ObjectIDFromHex(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid promotion id")
+	}
+
+	var promotion promotionModel
+	err = s.db.Collection("promotions").FindOne(ctx, bson.M{"_id": id}).Decode(&promotion)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Error(codes.NotFound, "promotion not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get promotion: %v", err)
+	}
+
+	return promotion.toProto(), nil
+}
+
+func (s *promotionServer) UpdatePromotion(ctx context.Context, req *pb.Promotion) (*pb.Promotion, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "promotion id is required")
+	}
+
+	// Convert string ID to ObjectID
+	id, err := primitive.ObjectIDFromHex(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid promotion id")
+	}
+
+	promotion, err := promotionFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Everything but the usage count and creation time is replaced
+	update := bson.M{
+		"$set": bson.M{
+			"code":              promotion.Code,
+			"description":       promotion.Description,
+			"type":              promotion.Type,
+			"product_ids":       promotion.ProductIDs,
+			"categories":        promotion.Categories,
+			"max_uses":          promotion.MaxUses,
+			"max_uses_per_user": promotion.MaxUsesPerUser,
+			"active":            promotion.Active,
+			"updated_at":        time.Now().UTC(),
+		},
+		"$unset": bson.M{},
+	}
+	optional := map[string]interface{}{
+		"percent_off":  promotion.PercentOff,
+		"amount_off":   promotion.AmountOff,
+		"buy_quantity": promotion.BuyQuantity,
+		"get_quantity": promotion.GetQuantity,
+		"starts_at":    promotion.StartsAt,
+		"ends_at":      promotion.EndsAt,
+	}
+	for field, value := range optional {
+		if isZeroField(value) {
+			update["$unset"].(bson.M)[field] = ""
+		} else {
+			update["$set"].(bson.M)[field] = value
+		}
+	}
+
+	var updated promotionModel
+	err = s.db.Collection("promotions").FindOneAndUpdate(ctx, bson.M{"_id": id}, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Error(codes.NotFound, "promotion not found")
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, status.Error(codes.AlreadyExists, "a promotion with this code already exists")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update promotion: %v", err)
+	}
+
+	return updated.toProto(), nil
+}
+
+func (s *promotionServer) ListPromotions(ctx context.Context, req *pb.ListPromotionsRequest) (*pb.ListPromotionsResponse, error) {
+	// Set default values for pagination
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 10
+	}
+
+	// Create filter
+	filter := bson.M{}
+	if req.ActiveOnly {
+		filter["active"] = true
+	}
+
+	// Calculate skip value for pagination
+	skip := (req.Page - 1) * req.Limit
+
+	// Get total count
+	total, err := s.db.Collection("promotions").CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count promotions: %v", err)
+	}
+
+	// Find promotions
+	cursor, err := s.db.Collection("promotions").Find(ctx, filter,
+		options.Find().
+			SetSkip(int64(skip)).
+			SetLimit(int64(req.Limit)).
+			SetSort(bson.M{"created_at": -1}),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list promotions: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	promotions := make([]*pb.Promotion, 0)
+	for cursor.Next(ctx) {
+		var promotion promotionModel
+		if err := cursor.Decode(&promotion); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to decode promotion: %v", err)
+		}
+		promotions = append(promotions, promotion.toProto())
+	}
+
+	return &pb.ListPromotionsResponse{
+		Promotions: promotions,
+		Total:      int32(total),
+	}, nil
+}
+
+func (s *promotionServer) DeletePromotion(ctx context.Context, req *pb.DeletePromotionRequest) (*pb.DeletePromotionResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "promotion id is required")
+	}
+
+	// Convert string ID to ObjectID
+	id, err := primitive.ObjectIDFromHex(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid promotion id")
+	}
+
+	result, err := s.db.Collection("promotions").DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete promotion: %v", err)
+	}
+	if result.DeletedCount == 0 {
+		return nil, status.Error(codes.NotFound, "promotion not found")
+	}
+
+	return &pb.DeletePromotionResponse{
+		Success: true,
+		Message: "promotion deleted successfully",
+	}, nil
+}
+
+// applyPromotions evaluates the automatic promotions and the given coupon codes against
+// the items of a new order and returns the discounts, one per promotion and item.
+// Coupons that cannot be used are reported together as PreconditionFailure violations.
+func (s *server) applyPromotions(ctx context.Context, userID string, couponCodes []string,
+	items []orderItemModel, products map[string]*pb.Product) ([]discountModel, error) {
+	now := time.Now().UTC()
+
+	// Automatic promotions outside their validity window are skipped silently
+	cursor, err := s.db.Collection("promotions").Find(ctx,
+		bson.M{"code": "", "active": true},
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to look up promotions: %v", err)
+	}
+	var promotions []promotionModel
+	for cursor.Next(ctx) {
+		var promotion promotionModel
+		if err := cursor.Decode(&promotion); err != nil {
+			cursor.Close(ctx)
+			return nil, status.Errorf(codes.Internal, "failed to decode promotion: %v", err)
+		}
+		if promotion.validityProblem(now) == "" {
+			promotions = append(promotions, promotion)
+		}
+	}
+	cursor.Close(ctx)
+
+	var violations []*errdetails.PreconditionFailure_Violation
+	couponIndex := map[primitive.ObjectID]int{}
+	seen := map[string]bool{}
+	for i, raw := range couponCodes {
+		code := normalizeCouponCode(raw)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		var promotion promotionModel
+		err := s.db.Collection("promotions").FindOne(ctx, bson.M{"code": code}).Decode(&promotion)
+		if err == mongo.ErrNoDocuments {
+			violations = append(violations, couponViolation(i, code, "unknown coupon code"))
+			continue
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to look up coupon: %v", err)
+		}
+
+		problem, err := s.couponProblem(ctx, &promotion, userID, now)
+		if err != nil {
+			return nil, err
+		}
+		if problem != "" {
+			violations = append(violations, couponViolation(i, code, problem))
+			continue
+		}
+		couponIndex[promotion.ID] = i
+		promotions = append(promotions, promotion)
+	}
+
+	discounts, skipped, err := discountItems(promotions, items, products)
+	if err != nil {
+		return nil, err
+	}
+	for _, promotion := range promotions {
+		if i, ok := couponIndex[promotion.ID]; ok && skipped[promotion.ID] != "" {
+			violations = append(violations, couponViolation(i, promotion.Code, skipped[promotion.ID]))
+		}
+	}
+
+	if len(violations) > 0 {
+		return nil, preconditionError("coupons cannot be applied:", violations)
+	}
+	return discounts, nil
+}
+
+// discountItems applies promotions to items one after the other and returns the
+// discounts, one per promotion and item. Promotions that give no discount are left out
+// and returned in skipped with the reason.
+func discountItems(promotions []promotionModel, items []orderItemModel,
+	products map[string]*pb.Product) ([]discountModel, map[primitive.ObjectID]string, error) {
+	// What is left of each item's amount after the promotions applied so far
+	remaining := make([]int64, len(items))
+	currency := ""
+	for i, item := range items {
+		line, err := item.Price.times(item.Quantity)
+		if err != nil {
+			return nil, nil, status.Error(codes.InvalidArgument, "order total is out of range")
+		}
+		remaining[i], currency = line.UnitsMinor, line.Currency
+	}
+
+	var discounts []discountModel
+	skipped := map[primitive.ObjectID]string{}
+	for _, promotion := range promotions {
+		amounts, problem, err := promotion.itemDiscounts(items, products, remaining, currency)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to apply promotion %s: %v", promotion.ID.Hex(), err)
+		}
+		if problem != "" {
+			skipped[promotion.ID] = problem
+			continue
+		}
+
+		for i, amount := range amounts {
+			if amount == 0 {
+				continue
+			}
+			remaining[i] -= amount
+			discounts = append(discounts, discountModel{
+				PromotionID: promotion.ID,
+				Code:        promotion.Code,
+				Description: promotion.Description,
+				ProductID:   items[i].ProductID,
+				Amount:      moneyModel{UnitsMinor: amount, Currency: currency},
+			})
+		}
+	}
+	return discounts, skipped, nil
+}
+
+// rediscountItems applies the promotions an order was placed with to its current
+// items, in the order they were first applied. Limits and validity are not checked
+// again, as the uses were counted when the order was placed; promotions deleted since
+// are dropped.
+func (s *server) rediscountItems(ctx context.Context, order *orderModel, products map[string]*pb.Product) ([]discountModel, error) {
+	var promotions []promotionModel
+	seen := map[primitive.ObjectID]bool{}
+	for _, discount := range order.Discounts {
+		if seen[discount.PromotionID] {
+			continue
+		}
+		seen[discount.PromotionID] = true
+
+		var promotion promotionModel
+		err := s.db.Collection("promotions").FindOne(ctx, bson.M{"_id": discount.PromotionID}).Decode(&promotion)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to look up promotion: %v", err)
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	discounts, _, err := discountItems(promotions, order.Items, products)
+	return discounts, err
+}
+
+// couponProblem returns why a coupon cannot be used by userID right now, or "" if it can.
+func (s *server) couponProblem(ctx context.Context, promotion *promotionModel, userID string, now time.Time) (string, error) {
+	if problem := promotion.validityProblem(now); problem != "" {
+		return problem, nil
+	}
+	if promotion.MaxUses > 0 && promotion.UsedCount >= promotion.MaxUses {
+		return "coupon has reached its usage limit", nil
+	}
+	if promotion.MaxUsesPerUser > 0 {
+		var usage struct {
+			Count int32 `bson:"count"`
+		}
+		err := s.db.Collection("promotion_usage").FindOne(ctx, bson.M{"_id": usageKey(promotion.ID, userID)}).Decode(&usage)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return "", status.Errorf(codes.Internal, "failed to look up coupon usage: %v", err)
+		}
+		if usage.Count >= promotion.MaxUsesPerUser {
+			return "coupon has already been used the maximum number of times", nil
+		}
+	}
+	return "", nil
+}
+
+// validityProblem returns why a promotion is not in effect at now, or "" if it is.
+func (p *promotionModel) validityProblem(now time.Time) string {
+	switch {
+	case !p.Active:
+		return "coupon is not active"
+	case p.StartsAt != nil && now.Before(*p.StartsAt):
+		return "coupon is not valid yet"
+	case p.EndsAt != nil && !now.Before(*p.EndsAt):
+		return "coupon has expired"
+	}
+	return ""
+}
+
+// itemDiscounts returns the discount the promotion gives on each item, given what is
+// left of each item's amount. If it gives nothing, the reason is returned instead.
+func (p *promotionModel) itemDiscounts(items []orderItemModel, products map[string]*pb.Product,
+	remaining []int64, currency string) ([]int64, string, error) {
+	eligible := make([]int64, len(items))
+	found := false
+	for i, item := range items {
+		if p.appliesTo(item.ProductID, products[item.ProductID]) && remaining[i] > 0 {
+			eligible[i] = remaining[i]
+			found = true
+		}
+	}
+	if !found {
+		return nil, "coupon does not apply to any item in the order", nil
+	}
+
+	amounts := make([]int64, len(items))
+	switch parsePromotionType(p.Type) {
+	case pb.PromotionType_PROMOTION_TYPE_PERCENTAGE:
+		for i := range items {
+			amount, err := mulDiv(eligible[i], int64(p.PercentOff), 100)
+			if err != nil {
+				return nil, "", err
+			}
+			amounts[i] = amount
+		}
+
+	case pb.PromotionType_PROMOTION_TYPE_FIXED_AMOUNT:
+		if p.AmountOff == nil || p.AmountOff.Currency != currency {
+			return nil, "coupon is in a different currency than the order", nil
+		}
+		var total int64
+		for _, amount := range eligible {
+			total += amount
+		}
+		off := p.AmountOff.UnitsMinor
+		if off > total {
+			off = total
+		}
+		amounts = allocate(off, eligible)
+
+	case pb.PromotionType_PROMOTION_TYPE_BUY_X_GET_Y:
+		group := p.BuyQuantity + p.GetQuantity
+		for i, item := range items {
+			if eligible[i] == 0 || group <= 0 {
+				continue
+			}
+			free, err := item.Price.times((item.Quantity / group) * p.GetQuantity)
+			if err != nil {
+				return nil, "", err
+			}
+			amounts[i] = free.UnitsMinor
+			if amounts[i] > eligible[i] {
+				amounts[i] = eligible[i]
+			}
+		}
+
+	default:
+		return nil, "", fmt.Errorf("unknown promotion type %q", p.Type)
+	}
+
+	for _, amount := range amounts {
+		if amount > 0 {
+			return amounts, "", nil
+		}
+	}
+	return nil, "coupon does not apply to any item in the order", nil
+}
+
+// appliesTo reports whether the promotion covers a product.
+func (p *promotionModel) appliesTo(productID string, product *pb.Product) bool {
+	if len(p.ProductIDs) == 0 && len(p.Categories) == 0 {
+		return true
+	}
+	for _, id := range p.ProductIDs {
+		if id == productID {
+			return true
+		}
+	}
+	if product != nil {
+		for _, category := range p.Categories {
+			if category == product.Category {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// redeemPromotions counts one use of every promotion discounting order. It must run in
+// the transaction that stores the order; a limit reached since the order was priced
+// fails it with FailedPrecondition.
+func (s *server) redeemPromotions(sc mongo.SessionContext, order *orderModel) error {
+	seen := map[primitive.ObjectID]bool{}
+	for _, discount := range order.Discounts {
+		if seen[discount.PromotionID] {
+			continue
+		}
+		seen[discount.PromotionID] = true
+
+		name := discount.Code
+		if name == "" {
+			name = discount.PromotionID.Hex()
+		}
+
+		// Writing the documents that were read makes concurrent redemptions conflict,
+		// so the transaction is retried against the new counts
+		var promotion promotionModel
+		err := s.db.Collection("promotions").FindOneAndUpdate(sc,
+			bson.M{"_id": discount.PromotionID},
+			bson.M{"$inc": bson.M{"used_count": 1}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&promotion)
+		if err == mongo.ErrNoDocuments {
+			return status.Errorf(codes.FailedPrecondition, "promotion %s no longer exists", name)
+		}
+		if err != nil {
+			return err
+		}
+		if promotion.MaxUses > 0 && promotion.UsedCount > promotion.MaxUses {
+			return status.Errorf(codes.FailedPrecondition, "coupon %s has reached its usage limit", name)
+		}
+
+		var usage struct {
+			Count int32 `bson:"count"`
+		}
+		err = s.db.Collection("promotion_usage").FindOneAndUpdate(sc,
+			bson.M{"_id": usageKey(discount.PromotionID, order.UserID)},
+			bson.M{"$inc": bson.M{"count": 1}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&usage)
+		if err != nil {
+			return err
+		}
+		if promotion.MaxUsesPerUser > 0 && usage.Count > promotion.MaxUsesPerUser {
+			return status.Errorf(codes.FailedPrecondition, "coupon %s has already been used the maximum number of times", name)
+		}
+
+		_, err = s.db.Collection("promotion_redemptions").InsertOne(sc, redemptionModel{
+			PromotionID: discount.PromotionID,
+			UserID:      order.UserID,
+			OrderID:     order.ID,
+			CreatedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releasePromotions gives back the promotion uses counted for an order. It is safe to
+// call more than once.
+func (s *server) releasePromotions(sc mongo.SessionContext, orderID primitive.ObjectID) error {
+	cursor, err := s.db.Collection("promotion_redemptions").Find(sc, bson.M{"order_id": orderID})
+	if err != nil {
+		return err
+	}
+	var redemptions []redemptionModel
+	if err := cursor.All(sc, &redemptions); err != nil {
+		return err
+	}
+
+	for _, redemption := range redemptions {
+		result, err := s.db.Collection("promotion_redemptions").DeleteOne(sc, bson.M{"_id": redemption.ID})
+		if err != nil {
+			return err
+		}
+		if result.DeletedCount == 0 {
+			continue
+		}
+		_, err = s.db.Collection("promotions").UpdateOne(sc,
+			bson.M{"_id": redemption.PromotionID},
+			bson.M{"$inc": bson.M{"used_count": -1}},
+		)
+		if err != nil {
+			return err
+		}
+		_, err = s.db.Collection("promotion_usage").UpdateOne(sc,
+			bson.M{"_id": usageKey(redemption.PromotionID, redemption.UserID)},
+			bson.M{"$inc": bson.M{"count": -1}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensurePromotionIndexes creates the unique index on coupon codes, which leaves out
+// automatic promotions, and the index used to release an order's redemptions.
+func ensurePromotionIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("promotions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "code", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"code": bson.M{"$gt": ""}}),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection("promotion_redemptions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "order_id", Value: 1}},
+	})
+	return err
+}
+
+// promotionFromProto validates a promotion sent by a client and converts it for storage.
+func promotionFromProto(req *pb.Promotion) (*promotionModel, error) {
+	promotion := &promotionModel{
+		Code:           normalizeCouponCode(req.Code),
+		Description:    req.Description,
+		Type:           promotionTypeName(req.Type),
+		ProductIDs:     append([]string{}, req.ProductIds...),
+		Categories:     append([]string{}, req.Categories...),
+		MaxUses:        req.MaxUses,
+		MaxUsesPerUser: req.MaxUsesPerUser,
+		Active:         req.Active,
+	}
+	if strings.ContainsAny(promotion.Code, " \t\r\n") {
+		return nil, status.Error(codes.InvalidArgument, "coupon code cannot contain spaces")
+	}
+
+	switch req.Type {
+	case pb.PromotionType_PROMOTION_TYPE_PERCENTAGE:
+		if req.PercentOff < 1 || req.PercentOff > 100 {
+			return nil, status.Error(codes.InvalidArgument, "percent_off must be between 1 and 100")
+		}
+		promotion.PercentOff = req.PercentOff
+	case pb.PromotionType_PROMOTION_TYPE_FIXED_AMOUNT:
+		if req.AmountOff == nil || req.AmountOff.UnitsMinor <= 0 || len(req.AmountOff.Currency) != 3 {
+			return nil, status.Error(codes.InvalidArgument, "amount_off must be a positive amount with a currency")
+		}
+		amount := moneyFromProto(req.AmountOff)
+		promotion.AmountOff = &amount
+	case pb.PromotionType_PROMOTION_TYPE_BUY_X_GET_Y:
+		if req.BuyQuantity < 1 || req.GetQuantity < 1 {
+			return nil, status.Error(codes.InvalidArgument, "buy_quantity and get_quantity must be at least 1")
+		}
+		promotion.BuyQuantity, promotion.GetQuantity = req.BuyQuantity, req.GetQuantity
+	default:
+		return nil, status.Error(codes.InvalidArgument, "promotion type is required")
+	}
+
+	for _, window := range []struct {
+		value  string
+		target **time.Time
+		name   string
+	}{
+		{req.StartsAt, &promotion.StartsAt, "starts_at"},
+		{req.EndsAt, &promotion.EndsAt, "ends_at"},
+	} {
+		if window.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, window.value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s must be an RFC 3339 timestamp", window.name)
+		}
+		t = t.UTC()
+		*window.target = &t
+	}
+	if promotion.StartsAt != nil && promotion.EndsAt != nil && !promotion.EndsAt.After(*promotion.StartsAt) {
+		return nil, status.Error(codes.InvalidArgument, "ends_at must be after starts_at")
+	}
+
+	if promotion.MaxUses < 0 || promotion.MaxUsesPerUser < 0 {
+		return nil, status.Error(codes.InvalidArgument, "usage limits cannot be negative")
+	}
+	if promotion.Code == "" && (promotion.MaxUses > 0 || promotion.MaxUsesPerUser > 0) {
+		return nil, status.Error(codes.InvalidArgument, "promotions without a code cannot have usage limits")
+	}
+	return promotion, nil
+}
+
+// toProto converts a stored promotion into its protobuf representation.
+func (p *promotionModel) toProto() *pb.Promotion {
+	promotion := &pb.Promotion{
+		Id:             p.ID.Hex(),
+		Code:           p.Code,
+		Description:    p.Description,
+		Type:           parsePromotionType(p.Type),
+		PercentOff:     p.PercentOff,
+		BuyQuantity:    p.BuyQuantity,
+		GetQuantity:    p.GetQuantity,
+		ProductIds:     p.ProductIDs,
+		Categories:     p.Categories,
+		MaxUses:        p.MaxUses,
+		MaxUsesPerUser: p.MaxUsesPerUser,
+		UsedCount:      p.UsedCount,
+		Active:         p.Active,
+		CreatedAt:      p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      p.UpdatedAt.Format(time.RFC3339),
+	}
+	if p.AmountOff != nil {
+		promotion.AmountOff = p.AmountOff.toProto()
+	}
+	if p.StartsAt != nil {
+		promotion.StartsAt = p.StartsAt.Format(time.RFC3339)
+	}
+	if p.EndsAt != nil {
+		promotion.EndsAt = p.EndsAt.Format(time.RFC3339)
+	}
+	return promotion
+}
+
+func discountsToProto(discounts []discountModel) []*pb.AppliedDiscount {
+	applied := make([]*pb.AppliedDiscount, 0, len(discounts))
+	for _, discount := range discounts {
+		applied = append(applied, &pb.AppliedDiscount{
+			PromotionId: discount.PromotionID.Hex(),
+			Code:        discount.Code,
+			Description: discount.Description,
+			ProductId:   discount.ProductID,
+			Amount:      discount.Amount.toProto(),
+		})
+	}
+	return applied
+}
+
+// normalizeCouponCode makes coupon codes case-insensitive.
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// promotionTypeName returns the lowercase name stored in MongoDB for a promotion type.
+func promotionTypeName(t pb.PromotionType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "PROMOTION_TYPE_"))
+}
+
+// parsePromotionType converts a stored promotion type name back to its enum value.
+func parsePromotionType(name string) pb.PromotionType {
+	return pb.PromotionType(pb.PromotionType_value["PROMOTION_TYPE_"+strings.ToUpper(name)])
+}
+
+// usageKey identifies the promotion_usage document counting one user's uses of a promotion.
+func usageKey(promotionID primitive.ObjectID, userID string) bson.D {
+	return bson.D{{Key: "promotion_id", Value: promotionID}, {Key: "user_id", Value: userID}}
+}
+
+func couponViolation(index int, code string, description string) *errdetails.PreconditionFailure_Violation {
+	return &errdetails.PreconditionFailure_Violation{
+		Type:        "COUPON",
+		Subject:     fmt.Sprintf("coupon_codes[%d] %s", index, code),
+		Description: description,
+	}
+}
+
+// isZeroField reports whether an optional promotion field is unset.
+func isZeroField(value interface{}) bool {
+	switch v := value.(type) {
+	case int32:
+		return v == 0
+	case *moneyModel:
+		return v == nil
+	case *time.Time:
+		return v == nil
+	}
+	return value == nil
+}
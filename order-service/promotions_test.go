@@ -0,0 +1,195 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	pb "github.com/order-management/proto"
+)
+
+func TestPromotionItemDiscounts(t *testing.T) {
+	usd := func(units int64) moneyModel { return moneyModel{UnitsMinor: units, Currency: "USD"} }
+	items := []orderItemModel{
+		{ProductID: "p1", Quantity: 3, Price: usd(1000)},
+		{ProductID: "p2", Quantity: 1, Price: usd(500)},
+		{ProductID: "p3", Quantity: 5, Price: usd(199)},
+	}
+	products := map[string]*pb.Product{
+		"p1": {Id: "p1", Category: "books"},
+		"p2": {Id: "p2", Category: "toys"},
+		"p3": {Id: "p3", Category: "books"},
+	}
+	amountOff := func(money moneyModel) *moneyModel { return &money }
+
+	tests := []struct {
+		name        string
+		promotion   promotionModel
+		remaining   []int64
+		want        []int64
+		wantProblem string
+	}{
+		{
+			name:      "percentage rounds per item",
+			promotion: promotionModel{Type: "percentage", PercentOff: 15},
+			remaining: []int64{3000, 500, 995},
+			want:      []int64{450, 75, 149}, // 149.25
+		},
+		{
+			name:      "percentage of what earlier promotions left",
+			promotion: promotionModel{Type: "percentage", PercentOff: 10},
+			remaining: []int64{2550, 425, 0},
+			want:      []int64{255, 43, 0}, // 42.5 rounds up
+		},
+		{
+			name:      "percentage on a category",
+			promotion: promotionModel{Type: "percentage", PercentOff: 50, Categories: []string{"books"}},
+			remaining: []int64{3000, 500, 995},
+			want:      []int64{1500, 0, 498}, // 497.5 rounds up
+		},
+		{
+			name:      "fixed amount split by what is left of each item",
+			promotion: promotionModel{Type: "fixed_amount", AmountOff: amountOff(usd(1000))},
+			remaining: []int64{3000, 500, 1500},
+			want:      []int64{600, 100, 300},
+		},
+		{
+			name:      "fixed amount remainder goes to the largest remainders",
+			promotion: promotionModel{Type: "fixed_amount", AmountOff: amountOff(usd(10))},
+			remaining: []int64{1000, 1000, 1000},
+			want:      []int64{4, 3, 3},
+		},
+		{
+			name:      "fixed amount is capped at what is left",
+			promotion: promotionModel{Type: "fixed_amount", AmountOff: amountOff(usd(10000)), ProductIDs: []string{"p2"}},
+			remaining: []int64{3000, 500, 995},
+			want:      []int64{0, 500, 0},
+		},
+		{
+			name:        "fixed amount in another currency",
+			promotion:   promotionModel{Type: "fixed_amount", AmountOff: amountOff(moneyModel{UnitsMinor: 1000, Currency: "EUR"})},
+			remaining:   []int64{3000, 500, 995},
+			wantProblem: "coupon is in a different currency than the order",
+		},
+		{
+			name:      "buy two get one per full group",
+			promotion: promotionModel{Type: "buy_x_get_y", BuyQuantity: 2, GetQuantity: 1},
+			remaining: []int64{3000, 500, 995},
+			want:      []int64{1000, 0, 199},
+		},
+		{
+			name:      "buy x get y is capped at what is left",
+			promotion: promotionModel{Type: "buy_x_get_y", BuyQuantity: 2, GetQuantity: 1},
+			remaining: []int64{600, 500, 995},
+			want:      []int64{600, 0, 199},
+		},
+		{
+			name:        "no eligible item",
+			promotion:   promotionModel{Type: "percentage", PercentOff: 10, Categories: []string{"garden"}},
+			remaining:   []int64{3000, 500, 995},
+			wantProblem: "coupon does not apply to any item in the order",
+		},
+		{
+			name:        "eligible items already fully discounted",
+			promotion:   promotionModel{Type: "percentage", PercentOff: 10, ProductIDs: []string{"p2"}},
+			remaining:   []int64{3000, 0, 995},
+			wantProblem: "coupon does not apply to any item in the order",
+		},
+		{
+			name:        "no group is complete",
+			promotion:   promotionModel{Type: "buy_x_get_y", BuyQuantity: 3, GetQuantity: 3},
+			remaining:   []int64{3000, 500, 995},
+			wantProblem: "coupon does not apply to any item in the order",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, problem, err := tt.promotion.itemDiscounts(items, products, tt.remaining, "USD")
+			if err != nil {
+				t.Fatalf("itemDiscounts() returned error %v", err)
+			}
+			if problem != tt.wantProblem {
+				t.Fatalf("itemDiscounts() problem = %q, want %q", problem, tt.wantProblem)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("itemDiscounts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscountItems(t *testing.T) {
+	usd := func(units int64) moneyModel { return moneyModel{UnitsMinor: units, Currency: "USD"} }
+	items := []orderItemModel{
+		{ProductID: "p1", Quantity: 3, Price: usd(1000)},
+		{ProductID: "p2", Quantity: 1, Price: usd(500)},
+	}
+	products := map[string]*pb.Product{"p1": {Id: "p1"}, "p2": {Id: "p2"}}
+	percent := promotionModel{ID: primitive.NewObjectID(), Type: "percentage", PercentOff: 10}
+	fixed := promotionModel{ID: primitive.NewObjectID(), Code: "FIVE", Type: "fixed_amount", AmountOff: &moneyModel{UnitsMinor: 500, Currency: "USD"}}
+	free := promotionModel{ID: primitive.NewObjectID(), Code: "3FOR2", Type: "buy_x_get_y", BuyQuantity: 2, GetQuantity: 1}
+	euro := promotionModel{ID: primitive.NewObjectID(), Code: "EURO", Type: "fixed_amount", AmountOff: &moneyModel{UnitsMinor: 500, Currency: "EUR"}}
+
+	type discount struct {
+		PromotionID primitive.ObjectID
+		ProductID   string
+		Amount      int64
+	}
+	tests := []struct {
+		name        string
+		promotions  []promotionModel
+		want        []discount
+		wantSkipped map[primitive.ObjectID]string
+	}{
+		{
+			name:       "each promotion discounts what the ones before left",
+			promotions: []promotionModel{percent, fixed, free},
+			want: []discount{
+				{percent.ID, "p1", 300},
+				{percent.ID, "p2", 50},
+				{fixed.ID, "p1", 429}, // 500 split over 2700 and 450
+				{fixed.ID, "p2", 71},
+				{free.ID, "p1", 1000},
+			},
+		},
+		{
+			name:       "buy x get y first leaves less for a percentage",
+			promotions: []promotionModel{free, percent},
+			want: []discount{
+				{free.ID, "p1", 1000},
+				{percent.ID, "p1", 200},
+				{percent.ID, "p2", 50},
+			},
+		},
+		{
+			name:        "promotion in another currency is skipped",
+			promotions:  []promotionModel{euro, percent},
+			want:        []discount{{percent.ID, "p1", 300}, {percent.ID, "p2", 50}},
+			wantSkipped: map[primitive.ObjectID]string{euro.ID: "coupon is in a different currency than the order"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discounts, skipped, err := discountItems(tt.promotions, items, products)
+			if err != nil {
+				t.Fatalf("discountItems() returned error %v", err)
+			}
+			var got []discount
+			for _, d := range discounts {
+				if d.Amount.Currency != "USD" {
+					t.Errorf("discount %+v is not in USD", d)
+				}
+				got = append(got, discount{d.PromotionID, d.ProductID, d.Amount.UnitsMinor})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("discountItems() = %v, want %v", got, tt.want)
+			}
+			if tt.wantSkipped == nil {
+				tt.wantSkipped = map[primitive.ObjectID]string{}
+			}
+			if !reflect.DeepEqual(skipped, tt.wantSkipped) {
+				t.Errorf("skipped = %v, want %v", skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
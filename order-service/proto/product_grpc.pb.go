@@ -19,11 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ProductService_CreateProduct_FullMethodName = "/proto.ProductService/CreateProduct"
-	ProductService_GetProduct_FullMethodName    = "/proto.ProductService/GetProduct"
-	ProductService_UpdateProduct_FullMethodName = "/proto.ProductService/UpdateProduct"
-	ProductService_ListProducts_FullMethodName  = "/proto.ProductService/ListProducts"
-	ProductService_UpdateStock_FullMethodName   = "/proto.ProductService/UpdateStock"
+	ProductService_CreateProduct_FullMethodName  = "/proto.ProductService/CreateProduct"
+	ProductService_GetProduct_FullMethodName     = "/proto.ProductService/GetProduct"
+	ProductService_UpdateProduct_FullMethodName  = "/proto.ProductService/UpdateProduct"
+	ProductService_ListProducts_FullMethodName   = "/proto.ProductService/ListProducts"
+	ProductService_UpdateStock_FullMethodName    = "/proto.ProductService/UpdateStock"
+	ProductService_ReserveStock_FullMethodName   = "/proto.ProductService/ReserveStock"
+	ProductService_ReleaseStock_FullMethodName   = "/proto.ProductService/ReleaseStock"
+	ProductService_ListBackorders_FullMethodName = "/proto.ProductService/ListBackorders"
 )
 
 // ProductServiceClient is the client API for ProductService service.
@@ -35,6 +38,9 @@ type ProductServiceClient interface {
 	UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*Product, error)
 	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
 	UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*Product, error)
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*StockReservation, error)
+	ReleaseStock(ctx context.Context, in *ReleaseStockRequest, opts ...grpc.CallOption) (*StockRelease, error)
+	ListBackorders(ctx context.Context, in *ListBackordersRequest, opts ...grpc.CallOption) (*ListBackordersResponse, error)
 }
 
 type productServiceClient struct {
@@ -90,6 +96,33 @@ func (c *productServiceClient) UpdateStock(ctx context.Context, in *UpdateStockR
 	return out, nil
 }
 
+func (c *productServiceClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*StockReservation, error) {
+	out := new(StockReservation)
+	err := c.cc.Invoke(ctx, ProductService_ReserveStock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ReleaseStock(ctx context.Context, in *ReleaseStockRequest, opts ...grpc.CallOption) (*StockRelease, error) {
+	out := new(StockRelease)
+	err := c.cc.Invoke(ctx, ProductService_ReleaseStock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListBackorders(ctx context.Context, in *ListBackordersRequest, opts ...grpc.CallOption) (*ListBackordersResponse, error) {
+	out := new(ListBackordersResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListBackorders_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProductServiceServer is the server API for ProductService service.
 // All implementations must embed UnimplementedProductServiceServer
 // for forward compatibility
@@ -99,6 +132,9 @@ type ProductServiceServer interface {
 	UpdateProduct(context.Context, *UpdateProductRequest) (*Product, error)
 	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
 	UpdateStock(context.Context, *UpdateStockRequest) (*Product, error)
+	ReserveStock(context.Context, *ReserveStockRequest) (*StockReservation, error)
+	ReleaseStock(context.Context, *ReleaseStockRequest) (*StockRelease, error)
+	ListBackorders(context.Context, *ListBackordersRequest) (*ListBackordersResponse, error)
 	mustEmbedUnimplementedProductServiceServer()
 }
 
@@ -121,6 +157,15 @@ func (UnimplementedProductServiceServer) ListProducts(context.Context, *ListProd
 func (UnimplementedProductServiceServer) UpdateStock(context.Context, *UpdateStockRequest) (*Product, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateStock not implemented")
 }
+func (UnimplementedProductServiceServer) ReserveStock(context.Context, *ReserveStockRequest) (*StockReservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedProductServiceServer) ReleaseStock(context.Context, *ReleaseStockRequest) (*StockRelease, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseStock not implemented")
+}
+func (UnimplementedProductServiceServer) ListBackorders(context.Context, *ListBackordersRequest) (*ListBackordersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBackorders not implemented")
+}
 func (UnimplementedProductServiceServer) mustEmbedUnimplementedProductServiceServer() {}
 
 // UnsafeProductServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -224,6 +269,60 @@ func _ProductService_UpdateStock_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductService_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ReserveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ReleaseStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ReleaseStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ReleaseStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ReleaseStock(ctx, req.(*ReleaseStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListBackorders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBackordersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListBackorders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListBackorders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListBackorders(ctx, req.(*ListBackordersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProductService_ServiceDesc is the grpc.ServiceDesc for ProductService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -251,6 +350,18 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateStock",
 			Handler:    _ProductService_UpdateStock_Handler,
 		},
+		{
+			MethodName: "ReserveStock",
+			Handler:    _ProductService_ReserveStock_Handler,
+		},
+		{
+			MethodName: "ReleaseStock",
+			Handler:    _ProductService_ReleaseStock_Handler,
+		},
+		{
+			MethodName: "ListBackorders",
+			Handler:    _ProductService_ListBackorders_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "product.proto",
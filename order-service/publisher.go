@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventPublisher delivers order events to downstream consumers. Publish must only
+// return nil once the event has been accepted by the transport.
+type EventPublisher interface {
+	Publish(ctx context.Context, subject string, payload []byte) error
+	Close() error
+}
+
+// publishedEvent is an event recorded by memoryPublisher.
+type publishedEvent struct {
+	Subject string
+	Payload []byte
+}
+
+// memoryPublisher keeps events in memory. It is used for local runs without a broker.
+type memoryPublisher struct {
+	mu     sync.Mutex
+	events []publishedEvent
+}
+
+func newMemoryPublisher() *memoryPublisher {
+	return &memoryPublisher{}
+}
+
+func (p *memoryPublisher) Publish(ctx context.Context, subject string, payload []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, publishedEvent{Subject: subject, Payload: append([]byte(nil), payload...)})
+	return nil
+}
+
+// Events returns a copy of everything published so far.
+func (p *memoryPublisher) Events() []publishedEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]publishedEvent(nil), p.events...)
+}
+
+func (p *memoryPublisher) Close() error {
+	return nil
+}
+
+// natsPublisher publishes to a NATS server using the core NATS text protocol. Every
+// publish is followed by a PING, and it only succeeds once the matching PONG arrives,
+// so the server has processed the message. The connection is re-established lazily
+// after any error.
+type natsPublisher struct {
+	addr string
+	name string
+	dial func(ctx context.Context, network, addr string) (net.Conn, error)
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func newNATSPublisher(natsURL string) (*natsPublisher, error) {
+	u, err := url.Parse(natsURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NATS url %q: %w", natsURL, err)
+	}
+	if u.Scheme != "nats" {
+		return nil, fmt.Errorf("invalid NATS url %q: scheme must be nats", natsURL)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	var d net.Dialer
+	return &natsPublisher{addr: addr, name: "order-service", dial: d.DialContext}, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, subject string, payload []byte) error {
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return fmt.Errorf("invalid NATS subject %q", subject)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(5 * time.Second)
+	}
+	p.conn.SetDeadline(deadline)
+
+	msg := fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", subject, len(payload), payload)
+	if _, err := p.conn.Write([]byte(msg)); err != nil {
+		p.reset()
+		return fmt.Errorf("failed to publish to NATS: %w", err)
+	}
+	if err := p.awaitPong(); err != nil {
+		p.reset()
+		return err
+	}
+	return nil
+}
+
+func (p *natsPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn, p.reader = nil, nil
+	return err
+}
+
+// connect dials the server, reads its INFO banner and sends CONNECT.
+func (p *natsPublisher) connect(ctx context.Context) error {
+	conn, err := p.dial(ctx, "tcp", p.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS at %s: %w", p.addr, err)
+	}
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "INFO ") {
+		conn.Close()
+		return fmt.Errorf("unexpected greeting from NATS at %s: %q", p.addr, line)
+	}
+
+	options, _ := json.Marshal(map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     p.name,
+		"lang":     "go",
+		"protocol": 1,
+	})
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\n", options); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to send CONNECT to NATS: %w", err)
+	}
+
+	p.conn, p.reader = conn, reader
+	return nil
+}
+
+// awaitPong reads until the PONG answering our PING, replying to server PINGs on the way.
+func (p *natsPublisher) awaitPong() error {
+	for {
+		line, err := p.reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read from NATS: %w", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return fmt.Errorf("failed to answer NATS ping: %w", err)
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+		// +OK and INFO updates need no action
+	}
+}
+
+func (p *natsPublisher) reset() {
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.conn, p.reader = nil, nil
+}
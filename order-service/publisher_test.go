@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeNATSServer serves the connections a natsPublisher dials over net.Pipe. For each
+// connection it sends INFO, reads CONNECT and one published message with its PING,
+// writes reply and then records everything else the publisher sends.
+type fakeNATSServer struct {
+	reply  string
+	hangUp bool // Close the connection instead of replying
+
+	connections int
+	received    chan []string
+}
+
+func (f *fakeNATSServer) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	client, server := net.Pipe()
+	f.connections++
+	go f.serve(server)
+	return client, nil
+}
+
+func (f *fakeNATSServer) serve(conn net.Conn) {
+	defer conn.Close()
+	var lines []string
+	defer func() { f.received <- lines }()
+
+	reader := bufio.NewReader(conn)
+	if _, err := conn.Write([]byte(`INFO {"server_id":"fake","max_payload":1048576}` + "\r\n")); err != nil {
+		return
+	}
+	// CONNECT, PUB, the payload and PING
+	for len(lines) < 4 {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		lines = append(lines, strings.TrimRight(line, "\r\n"))
+	}
+	if f.hangUp {
+		return
+	}
+	if _, err := conn.Write([]byte(f.reply)); err != nil {
+		return
+	}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		lines = append(lines, strings.TrimRight(line, "\r\n"))
+	}
+}
+
+func TestNATSPublisherPublish(t *testing.T) {
+	tests := []struct {
+		name     string
+		reply    string
+		hangUp   bool
+		wantErr  string
+		wantSent []string // Sent after the PING
+	}{
+		{name: "pong acknowledges the message", reply: "PONG\r\n"},
+		{name: "other messages before the pong are skipped", reply: "+OK\r\nINFO {\"connect_urls\":[]}\r\nPONG\r\n"},
+		{name: "server ping is answered", reply: "PING\r\nPONG\r\n", wantSent: []string{"PONG"}},
+		{name: "server error", reply: "-ERR 'Permissions Violation for Publish'\r\n",
+			wantErr: "NATS error: 'Permissions Violation for Publish'"},
+		{name: "connection lost before the pong", hangUp: true, wantErr: "failed to read from NATS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &fakeNATSServer{reply: tt.reply, hangUp: tt.hangUp, received: make(chan []string, 1)}
+			publisher := &natsPublisher{addr: "nats:4222", name: "order-service", dial: server.dial}
+
+			err := publisher.Publish(context.Background(), "orders.OrderCreated", []byte(`{"type":"x"}`))
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Publish() returned error %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Fatalf("Publish() error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantErr != "" && publisher.conn != nil {
+				t.Errorf("connection kept after an error")
+			}
+			publisher.Close()
+
+			lines := <-server.received
+			if len(lines) < 4 {
+				t.Fatalf("server received %q, want CONNECT, PUB, payload and PING", lines)
+			}
+			if !strings.HasPrefix(lines[0], "CONNECT {") || !strings.Contains(lines[0], `"verbose":false`) {
+				t.Errorf("CONNECT line = %q", lines[0])
+			}
+			if want := []string{"PUB orders.OrderCreated 12", `{"type":"x"}`, "PING"}; !reflect.DeepEqual(lines[1:4], want) {
+				t.Errorf("message = %q, want %q", lines[1:4], want)
+			}
+			if sent := lines[4:]; len(sent) > 0 || len(tt.wantSent) > 0 {
+				if !reflect.DeepEqual(sent, tt.wantSent) {
+					t.Errorf("sent after the message %q, want %q", sent, tt.wantSent)
+				}
+			}
+		})
+	}
+}
+
+func TestNATSPublisherReconnectsAfterError(t *testing.T) {
+	server := &fakeNATSServer{hangUp: true, received: make(chan []string, 2)}
+	publisher := &natsPublisher{addr: "nats:4222", name: "order-service", dial: server.dial}
+	defer publisher.Close()
+
+	if err := publisher.Publish(context.Background(), "orders.OrderCreated", []byte("{}")); err == nil {
+		t.Fatal("Publish() succeeded on a connection closed before the pong")
+	}
+	<-server.received
+
+	server.hangUp, server.reply = false, "PONG\r\n"
+	if err := publisher.Publish(context.Background(), "orders.OrderCreated", []byte("{}")); err != nil {
+		t.Fatalf("Publish() after reconnecting returned error %v", err)
+	}
+	if server.connections != 2 {
+		t.Errorf("publisher dialed %d times, want 2", server.connections)
+	}
+}
+
+func TestNATSPublisherRejectsInvalidSubjects(t *testing.T) {
+	server := &fakeNATSServer{received: make(chan []string, 1)}
+	publisher := &natsPublisher{addr: "nats:4222", name: "order-service", dial: server.dial}
+	for _, subject := range []string{"", "orders.Order Created", "orders.\r\nPUB x 0"} {
+		if err := publisher.Publish(context.Background(), subject, []byte("{}")); err == nil {
+			t.Errorf("Publish(%q) succeeded", subject)
+		}
+	}
+	if server.connections != 0 {
+		t.Errorf("publisher dialed for an invalid subject")
+	}
+}
+
+func TestNewNATSPublisher(t *testing.T) {
+	tests := []struct {
+		url      string
+		wantAddr string
+	}{
+		{"nats://nats:4222", "nats:4222"},
+		{"nats://localhost", "localhost:4222"},
+		{"nats://10.0.0.1:4333", "10.0.0.1:4333"},
+		{"http://nats:4222", ""},
+		{"nats://[::1", ""},
+	}
+	for _, tt := range tests {
+		publisher, err := newNATSPublisher(tt.url)
+		if tt.wantAddr == "" {
+			if err == nil {
+				t.Errorf("newNATSPublisher(%q) accepted an invalid url", tt.url)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("newNATSPublisher(%q) returned error %v", tt.url, err)
+			continue
+		}
+		if publisher.addr != tt.wantAddr {
+			t.Errorf("newNATSPublisher(%q) address = %q, want %q", tt.url, publisher.addr, tt.wantAddr)
+		}
+	}
+}
+
+func TestMemoryPublisherCopiesPayloads(t *testing.T) {
+	publisher := newMemoryPublisher()
+	payload := []byte(`{"n":1}`)
+	publisher.Publish(context.Background(), "orders.OrderCreated", payload)
+	payload[5] = '2'
+
+	want := []publishedEvent{{Subject: "orders.OrderCreated", Payload: []byte(`{"n":1}`)}}
+	if got := publisher.Events(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Events() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,428 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// A return moves requested -> approved -> received, or requested -> rejected. Receiving
+// puts resellable goods back into stock through the product service; each item is
+// flagged once restocked, so calling ReceiveReturn again on a received return only
+// finishes restocks that failed the first time. Restocks are sent with a key per
+// return item, so one that was made but not flagged is not made twice.
+
+type returnItemModel struct {
+	ProductID        string `bson:"product_id"`
+	Quantity         int32  `bson:"quantity"`
+	ReceivedQuantity int32  `bson:"received_quantity"`
+	Resellable       bool   `bson:"resellable"`
+	Restocked        bool   `bson:"restocked"`
+}
+
+type returnModel struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	OrderID     primitive.ObjectID `bson:"order_id"`
+	Status      string             `bson:"status"`
+	Reason      string             `bson:"reason,omitempty"`
+	Items       []returnItemModel  `bson:"items"`
+	ReviewNote  string             `bson:"review_note,omitempty"`
+	RequestedBy string             `bson:"requested_by,omitempty"`
+	ReviewedBy  string             `bson:"reviewed_by,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+	ReceivedAt  *time.Time         `bson:"received_at,omitempty"`
+}
+
+func (s *server) RequestReturn(ctx context.Context, req *pb.RequestReturnRequest) (*pb.ReturnAuthorization, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order id is required")
+	}
+	if len(req.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "return must contain at least one item")
+	}
+
+	// Merge repeated products so each is checked once
+	var items []returnItemModel
+	index := map[string]int{}
+	for _, item := range req.Items {
+		if item.ProductId == "" || item.Quantity < 1 {
+			return nil, status.Error(codes.InvalidArgument, "every return item needs a product_id and a quantity of at least 1")
+		}
+		if i, ok := index[item.ProductId]; ok {
+			items[i].Quantity += item.Quantity
+			continue
+		}
+		index[item.ProductId] = len(items)
+		items = append(items, returnItemModel{ProductID: item.ProductId, Quantity: item.Quantity})
+	}
+
+	// Convert string ID to ObjectID
+	orderID, err := primitive.ObjectIDFromHex(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order id")
+	}
+
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	rma := returnModel{
+		ID:          primitive.NewObjectID(),
+		OrderID:     orderID,
+		Status:      returnStatusName(pb.ReturnStatus_RETURN_STATUS_REQUESTED),
+		Reason:      req.Reason,
+		Items:       items,
+		RequestedBy: actor,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	// As with shipments, the order is written so concurrent return requests for the
+	// same order conflict and are re-checked on retry.
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		var order orderModel
+		if err := s.db.Collection("orders").FindOne(sc, bson.M{"_id": orderID}).Decode(&order); err != nil {
+			return err
+		}
+
+		returnable, err := s.returnableQuantities(sc, &order)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if _, ok := returnable[item.ProductID]; !ok {
+				return status.Errorf(codes.InvalidArgument, "product %s is not part of this order", item.ProductID)
+			}
+			if item.Quantity > returnable[item.ProductID] {
+				return status.Errorf(codes.FailedPrecondition, "product %s has only %d units that can be returned",
+					item.ProductID, returnable[item.ProductID])
+			}
+		}
+
+		if _, err := s.db.Collection("returns").InsertOne(sc, rma); err != nil {
+			return err
+		}
+
+		_, err = s.db.Collection("orders").UpdateOne(sc,
+			bson.M{"_id": orderID},
+			bson.M{"$set": bson.M{"updated_at": now}},
+		)
+		return err
+	})
+
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to request return: %v", err)
+	}
+
+	return rma.toProto(), nil
+}
+
+func (s *server) ListReturns(ctx context.Context, req *pb.ListReturnsRequest) (*pb.ListReturnsResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order id is required")
+	}
+
+	// Convert string ID to ObjectID
+	orderID, err := primitive.ObjectIDFromHex(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order id")
+	}
+
+	cursor, err := s.db.Collection("returns").Find(ctx, bson.M{"order_id": orderID},
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list returns: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	returns := make([]*pb.ReturnAuthorization, 0)
+	for cursor.Next(ctx) {
+		var rma returnModel
+		if err := cursor.Decode(&rma); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to decode return: %v", err)
+		}
+		returns = append(returns, rma.toProto())
+	}
+
+	return &pb.ListReturnsResponse{Returns: returns}, nil
+}
+
+func (s *server) ApproveReturn(ctx context.Context, req *pb.ReviewReturnRequest) (*pb.ReturnAuthorization, error) {
+	return s.reviewReturn(ctx, req, pb.ReturnStatus_RETURN_STATUS_APPROVED)
+}
+
+func (s *server) RejectReturn(ctx context.Context, req *pb.ReviewReturnRequest) (*pb.ReturnAuthorization, error) {
+	return s.reviewReturn(ctx, req, pb.ReturnStatus_RETURN_STATUS_REJECTED)
+}
+
+// reviewReturn moves a requested return to approved or rejected.
+func (s *server) reviewReturn(ctx context.Context, req *pb.ReviewReturnRequest, next pb.ReturnStatus) (*pb.ReturnAuthorization, error) {
+	orderID, returnID, err := returnIDs(req.OrderId, req.ReturnId)
+	if err != nil {
+		return nil, err
+	}
+
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := returnStatusName(pb.ReturnStatus_RETURN_STATUS_REQUESTED)
+	var rma returnModel
+	err = s.db.Collection("returns").FindOneAndUpdate(ctx,
+		bson.M{"_id": returnID, "order_id": orderID, "status": requested},
+		bson.M{"$set": bson.M{
+			"status":      returnStatusName(next),
+			"review_note": req.Note,
+			"reviewed_by": actor,
+			"updated_at":  time.Now().UTC(),
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&rma)
+	if err == mongo.ErrNoDocuments {
+		current, err := s.findReturn(ctx, orderID, returnID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.FailedPrecondition, "return is %s, only %s returns can be reviewed",
+			current.Status, requested)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update return: %v", err)
+	}
+
+	return rma.toProto(), nil
+}
+
+func (s *server) ReceiveReturn(ctx context.Context, req *pb.ReceiveReturnRequest) (*pb.ReturnAuthorization, error) {
+	orderID, returnID, err := returnIDs(req.OrderId, req.ReturnId)
+	if err != nil {
+		return nil, err
+	}
+
+	rma, err := s.findReturn(ctx, orderID, returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch parseReturnStatus(rma.Status) {
+	case pb.ReturnStatus_RETURN_STATUS_APPROVED:
+		if rma, err = s.markReturnReceived(ctx, rma, req.Items); err != nil {
+			return nil, err
+		}
+	case pb.ReturnStatus_RETURN_STATUS_RECEIVED:
+		// Already received; only pending restocks are retried below
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "return is %s, only approved returns can be received", rma.Status)
+	}
+
+	for i, item := range rma.Items {
+		if !item.Resellable || item.Restocked || item.ReceivedQuantity == 0 {
+			continue
+		}
+		_, err := s.productClient.UpdateStock(ctx, &pb.UpdateStockRequest{
+			Id:             item.ProductID,
+			QuantityChange: item.ReceivedQuantity,
+			UpdateKey:      fmt.Sprintf("return/%s/%d", rma.ID.Hex(), i),
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to restock product %s, receive the return again to retry: %v",
+				item.ProductID, err)
+		}
+
+		_, err = s.db.Collection("returns").UpdateOne(ctx,
+			bson.M{"_id": rma.ID},
+			bson.M{"$set": bson.M{fmt.Sprintf("items.%d.restocked", i): true, "updated_at": time.Now().UTC()}},
+		)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to record restock of product %s: %v", item.ProductID, err)
+		}
+		rma.Items[i].Restocked = true
+	}
+
+	return rma.toProto(), nil
+}
+
+// markReturnReceived records what arrived for an approved return and moves it to received.
+func (s *server) markReturnReceived(ctx context.Context, rma *returnModel, received []*pb.ReceivedItem) (*returnModel, error) {
+	if len(received) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "received items are required")
+	}
+
+	items := append([]returnItemModel(nil), rma.Items...)
+	index := map[string]int{}
+	for i, item := range items {
+		index[item.ProductID] = i
+	}
+	for _, item := range received {
+		i, ok := index[item.ProductId]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "product %s is not part of this return", item.ProductId)
+		}
+		if item.Quantity < 0 {
+			return nil, status.Error(codes.InvalidArgument, "received quantity cannot be negative")
+		}
+		items[i].ReceivedQuantity += item.Quantity
+		items[i].Resellable = item.Resellable
+		if items[i].ReceivedQuantity > items[i].Quantity {
+			return nil, status.Errorf(codes.FailedPrecondition, "product %s was authorized for %d units, received %d",
+				item.ProductId, items[i].Quantity, items[i].ReceivedQuantity)
+		}
+	}
+
+	now := time.Now().UTC()
+	var updated returnModel
+	err := s.db.Collection("returns").FindOneAndUpdate(ctx,
+		bson.M{"_id": rma.ID, "status": rma.Status},
+		bson.M{"$set": bson.M{
+			"status":      returnStatusName(pb.ReturnStatus_RETURN_STATUS_RECEIVED),
+			"items":       items,
+			"received_at": now,
+			"updated_at":  now,
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Error(codes.Aborted, "return changed concurrently, retry")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update return: %v", err)
+	}
+	return &updated, nil
+}
+
+// returnableQuantities returns, per product, how many units of a shipped order can
+// still be returned: the shipped quantity less what open or completed returns claim.
+func (s *server) returnableQuantities(ctx context.Context, order *orderModel) (map[string]int32, error) {
+	current := parseStatus(order.Status)
+	if current != pb.OrderStatus_ORDER_STATUS_PARTIALLY_SHIPPED &&
+		current != pb.OrderStatus_ORDER_STATUS_SHIPPED &&
+		current != pb.OrderStatus_ORDER_STATUS_DELIVERED {
+		return nil, status.Errorf(codes.FailedPrecondition, "order in status %s cannot be returned", order.Status)
+	}
+
+	returnable := map[string]int32{}
+	for _, item := range order.Items {
+		returnable[item.ProductID] += item.Quantity
+	}
+
+	// Imported orders have no shipment records, so only a partial shipment limits
+	// returns to what has actually left the warehouse
+	if current == pb.OrderStatus_ORDER_STATUS_PARTIALLY_SHIPPED {
+		remaining, err := s.remainingToShip(ctx, order)
+		if err != nil {
+			return nil, err
+		}
+		for productID, quantity := range remaining {
+			returnable[productID] -= quantity
+		}
+	}
+
+	cursor, err := s.db.Collection("returns").Find(ctx, bson.M{
+		"order_id": order.ID,
+		"status":   bson.M{"$ne": returnStatusName(pb.ReturnStatus_RETURN_STATUS_REJECTED)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var rma returnModel
+		if err := cursor.Decode(&rma); err != nil {
+			return nil, err
+		}
+		for _, item := range rma.Items {
+			returnable[item.ProductID] -= item.Quantity
+		}
+	}
+	return returnable, cursor.Err()
+}
+
+func (s *server) findReturn(ctx context.Context, orderID, returnID primitive.ObjectID) (*returnModel, error) {
+	var rma returnModel
+	err := s.db.Collection("returns").FindOne(ctx, bson.M{"_id": returnID, "order_id": orderID}).Decode(&rma)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Error(codes.NotFound, "return not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get return: %v", err)
+	}
+	return &rma, nil
+}
+
+// returnIDs parses the order and return ids of a request addressing a single return.
+func returnIDs(orderHex, returnHex string) (primitive.ObjectID, primitive.ObjectID, error) {
+	if orderHex == "" || returnHex == "" {
+		return primitive.NilObjectID, primitive.NilObjectID, status.Error(codes.InvalidArgument, "order id and return id are required")
+	}
+	orderID, err := primitive.ObjectIDFromHex(orderHex)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, status.Error(codes.InvalidArgument, "invalid order id")
+	}
+	returnID, err := primitive.ObjectIDFromHex(returnHex)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, status.Error(codes.InvalidArgument, "invalid return id")
+	}
+	return orderID, returnID, nil
+}
+
+// returnStatusName returns the lowercase name stored in MongoDB for a return status.
+func returnStatusName(st pb.ReturnStatus) string {
+	return strings.ToLower(strings.TrimPrefix(st.String(), "RETURN_STATUS_"))
+}
+
+// parseReturnStatus converts a stored return status name back to its enum value.
+func parseReturnStatus(name string) pb.ReturnStatus {
+	return pb.ReturnStatus(pb.ReturnStatus_value["RETURN_STATUS_"+strings.ToUpper(name)])
+}
+
+// toProto converts a stored return into its protobuf representation.
+func (r *returnModel) toProto() *pb.ReturnAuthorization {
+	items := make([]*pb.ReturnItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, &pb.ReturnItem{
+			ProductId:        item.ProductID,
+			Quantity:         item.Quantity,
+			ReceivedQuantity: item.ReceivedQuantity,
+			Resellable:       item.Resellable,
+			Restocked:        item.Restocked,
+		})
+	}
+
+	rma := &pb.ReturnAuthorization{
+		Id:          r.ID.Hex(),
+		OrderId:     r.OrderID.Hex(),
+		Status:      parseReturnStatus(r.Status),
+		Reason:      r.Reason,
+		Items:       items,
+		ReviewNote:  r.ReviewNote,
+		RequestedBy: r.RequestedBy,
+		ReviewedBy:  r.ReviewedBy,
+		CreatedAt:   r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   r.UpdatedAt.Format(time.RFC3339),
+	}
+	if r.ReceivedAt != nil {
+		rma.ReceivedAt = r.ReceivedAt.Format(time.RFC3339)
+	}
+	return rma
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// Order placement runs as an orchestrated saga: create a pending order, reserve stock
+// for each item, then confirm the order. Progress is persisted in the order_sagas
+// collection after every step so an interrupted saga can be resumed after a restart.
+// When a step fails, the completed steps are compensated by releasing the reserved
+// stock and marking the order failed.
+//
+// Each item is reserved with a key made of the order id and the item's index, so the
+// Product Service applies a reservation once however often it is sent. A reservation
+// whose answer was lost, because the service stopped or the call timed out, is
+// therefore neither made twice on resume nor leaked by compensation, which releases
+// every item by key whether or not the saga recorded its reservation.
+
+const (
+	sagaStepCreateOrder  = "create_order"
+	sagaStepReserveStock = "reserve_stock"
+	sagaStepConfirmOrder = "confirm_order"
+
+	sagaStateRunning      = "running"
+	sagaStateCompensating = "compensating"
+	sagaStateCompleted    = "completed"
+	sagaStateFailed       = "failed"
+)
+
+// sagaTimeout bounds a single saga run, independent of the caller's deadline.
+const sagaTimeout = 30 * time.Second
+
+// sagaResumeAfter is how long a saga must have been idle before a restarted service
+// treats it as abandoned and resumes it.
+const sagaResumeAfter = time.Minute
+
+type sagaModel struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	OrderID   primitive.ObjectID `bson:"order_id"`
+	Order     orderModel         `bson:"order"`
+	Step      string             `bson:"step"`
+	State     string             `bson:"state"`
+	Reserved  []int              `bson:"reserved"`
+	Keyed     bool               `bson:"keyed,omitempty"` // Reservations carry reservation keys
+	Error     string             `bson:"error,omitempty"`
+	CreatedAt time.Time          `bson:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+}
+
+// placeOrder persists a new saga for order and runs it to completion. It returns the
+// confirmed order, or the error of the failed step once compensation has run.
+func (s *server) placeOrder(ctx context.Context, order orderModel) (*orderModel, error) {
+	// The saga must not be abandoned half way because the client went away
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sagaTimeout)
+	defer cancel()
+
+	order.ID = primitive.NewObjectID()
+	saga := &sagaModel{
+		ID:        primitive.NewObjectID(),
+		OrderID:   order.ID,
+		Order:     order,
+		Step:      sagaStepCreateOrder,
+		State:     sagaStateRunning,
+		Reserved:  []int{},
+		Keyed:     true,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+	if _, err := s.db.Collection("order_sagas").InsertOne(ctx, saga); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to start order saga: %v", err)
+	}
+
+	return s.runSaga(ctx, saga)
+}
+
+// runSaga executes the remaining steps of saga starting from its recorded step.
+func (s *server) runSaga(ctx context.Context, saga *sagaModel) (*orderModel, error) {
+	if saga.State == sagaStateCompensating {
+		return nil, s.compensateSaga(ctx, saga, status.Error(codes.Aborted, saga.Error))
+	}
+
+	if saga.Step == sagaStepCreateOrder {
+		saga.Order.Status = statusName(pb.OrderStatus_ORDER_STATUS_PENDING)
+		err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+			if _, err := s.db.Collection("orders").InsertOne(sc, saga.Order); err != nil {
+				return err
+			}
+			if err := s.redeemPromotions(sc, &saga.Order); err != nil {
+				return err
+			}
+			if err := s.enqueueEvent(sc, eventOrderCreated, &saga.Order, "", ""); err != nil {
+				return err
+			}
+			return s.advanceSaga(sc, saga, sagaStepReserveStock)
+		})
+		if mongo.IsDuplicateKeyError(err) {
+			// On resume the order may already exist; otherwise the idempotency key is taken
+			count, countErr := s.db.Collection("orders").CountDocuments(ctx, bson.M{"_id": saga.OrderID})
+			if countErr == nil && count > 0 {
+				err = s.advanceSaga(ctx, saga, sagaStepReserveStock)
+			} else {
+				err = status.Error(codes.AlreadyExists, "an order with this idempotency key already exists")
+			}
+		}
+		if err != nil {
+			saga.Step = sagaStepCreateOrder
+			if status.Code(err) == codes.Unknown {
+				err = status.Errorf(codes.Internal, "failed to create order: %v", err)
+			}
+			return nil, s.compensateSaga(ctx, saga, err)
+		}
+	}
+
+	if saga.Step == sagaStepReserveStock {
+		for i, item := range saga.Order.Items {
+			if containsInt(saga.Reserved, i) {
+				continue
+			}
+			backordered, err := s.reserveItem(ctx, saga.OrderID, i, item, saga.reservationKey(i))
+			if err != nil {
+				return nil, s.compensateSaga(ctx, saga, err)
+			}
+			saga.Reserved = append(saga.Reserved, i)
+			saga.Order.Items[i].Backordered = backordered
+			if err := s.updateSaga(ctx, saga, bson.M{
+				"$addToSet": bson.M{"reserved": i},
+				"$set":      bson.M{fmt.Sprintf("order.items.%d.backordered", i): backordered},
+			}); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.advanceSaga(ctx, saga, sagaStepConfirmOrder); err != nil {
+			return nil, err
+		}
+	}
+
+	if saga.Step == sagaStepConfirmOrder {
+		var confirmed orderModel
+		change := newStatusChange(statusName(pb.OrderStatus_ORDER_STATUS_PENDING),
+			statusName(pb.OrderStatus_ORDER_STATUS_CONFIRMED), systemActor, "")
+		err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+			err := s.db.Collection("orders").FindOneAndUpdate(
+				sc,
+				bson.M{"_id": saga.OrderID, "status": change.OldStatus},
+				bson.M{
+					"$set": bson.M{
+						"status":     change.NewStatus,
+						"items":      saga.Order.Items, // With the units backordered when reserving
+						"updated_at": change.ChangedAt,
+					},
+					"$push": bson.M{"status_history": change},
+				},
+				options.FindOneAndUpdate().SetReturnDocument(options.After),
+			).Decode(&confirmed)
+			if err != nil {
+				return err
+			}
+			if err := s.enqueueEvent(sc, eventOrderStatusChanged, &confirmed,
+				statusName(pb.OrderStatus_ORDER_STATUS_PENDING), ""); err != nil {
+				return err
+			}
+			return s.updateSaga(sc, saga, bson.M{"$set": bson.M{"state": sagaStateCompleted}})
+		})
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				err = status.Error(codes.Aborted, "order was cancelled before it could be confirmed")
+			} else {
+				err = status.Errorf(codes.Internal, "failed to confirm order: %v", err)
+			}
+			return nil, s.compensateSaga(ctx, saga, err)
+		}
+
+		saga.State = sagaStateCompleted
+		return &confirmed, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "order saga %s is in unknown step %q", saga.ID.Hex(), saga.Step)
+}
+
+// compensateSaga undoes the completed steps of saga in reverse order and returns cause,
+// the error that made the saga fail.
+func (s *server) compensateSaga(ctx context.Context, saga *sagaModel, cause error) error {
+	saga.State = sagaStateCompensating
+	saga.Error = status.Convert(cause).Message()
+	if err := s.updateSaga(ctx, saga, bson.M{"$set": bson.M{"state": saga.State, "error": saga.Error}}); err != nil {
+		log.Printf("Failed to record compensation for saga %s: %v", saga.ID.Hex(), err)
+	}
+
+	// Release stock in reverse order of reservation. With keys every item is released,
+	// as its reservation may have been made without the saga recording it.
+	if saga.Keyed && saga.Step != sagaStepCreateOrder {
+		for index := len(saga.Order.Items) - 1; index >= 0; index-- {
+			if err := s.releaseItem(ctx, saga.OrderID, saga.Order.Items[index], saga.reservationKey(index)); err != nil {
+				// Leave the saga compensating so the release is retried on resume
+				log.Printf("Failed to release stock for saga %s item %d: %v", saga.ID.Hex(), index, err)
+				return cause
+			}
+		}
+		saga.Reserved = []int{}
+		if err := s.updateSaga(ctx, saga, bson.M{"$set": bson.M{"reserved": saga.Reserved}}); err != nil {
+			log.Printf("Failed to record stock release for saga %s: %v", saga.ID.Hex(), err)
+			return cause
+		}
+	}
+	for i := len(saga.Reserved) - 1; i >= 0; i-- {
+		index := saga.Reserved[i]
+		if err := s.releaseItem(ctx, saga.OrderID, saga.Order.Items[index], ""); err != nil {
+			// Leave the saga compensating so the release is retried on resume
+			log.Printf("Failed to release stock for saga %s item %d: %v", saga.ID.Hex(), index, err)
+			return cause
+		}
+		saga.Reserved = saga.Reserved[:i]
+		if err := s.updateSaga(ctx, saga, bson.M{"$pull": bson.M{"reserved": index}}); err != nil {
+			log.Printf("Failed to record stock release for saga %s: %v", saga.ID.Hex(), err)
+			return cause
+		}
+	}
+
+	// The order may not exist if the saga failed on its first step, and it is left
+	// alone if it was cancelled while the saga was running
+	change := newStatusChange(statusName(pb.OrderStatus_ORDER_STATUS_PENDING),
+		statusName(pb.OrderStatus_ORDER_STATUS_FAILED), systemActor, saga.Error)
+	err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		var failed orderModel
+		err := s.db.Collection("orders").FindOneAndUpdate(
+			sc,
+			bson.M{"_id": saga.OrderID, "status": change.OldStatus},
+			bson.M{
+				"$set": bson.M{
+					"status":     change.NewStatus,
+					"updated_at": change.ChangedAt,
+				},
+				"$push": bson.M{"status_history": change},
+				// Free the idempotency key so the client can retry the failed order
+				"$unset": bson.M{"idempotency_key": ""},
+			},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&failed)
+		if err == nil {
+			err = s.enqueueEvent(sc, eventOrderStatusChanged, &failed,
+				statusName(pb.OrderStatus_ORDER_STATUS_PENDING), saga.Error)
+		} else if err == mongo.ErrNoDocuments {
+			err = nil
+		}
+		if err != nil {
+			return err
+		}
+		// Give back the coupon uses of the failed order
+		if err := s.releasePromotions(sc, saga.OrderID); err != nil {
+			return err
+		}
+		return s.updateSaga(sc, saga, bson.M{"$set": bson.M{"state": sagaStateFailed}})
+	})
+	if err != nil {
+		log.Printf("Failed to mark order %s failed: %v", saga.OrderID.Hex(), err)
+		return cause
+	}
+
+	saga.State = sagaStateFailed
+	return cause
+}
+
+// watchSagas resumes abandoned sagas at startup and then periodically, until ctx is done.
+func (s *server) watchSagas(ctx context.Context) {
+	ticker := time.NewTicker(sagaResumeAfter)
+	defer ticker.Stop()
+
+	for {
+		s.resumeSagas(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// resumeSagas picks up sagas that were left running or compensating, typically because
+// the service restarted while they were in flight.
+func (s *server) resumeSagas(ctx context.Context) {
+	cursor, err := s.db.Collection("order_sagas").Find(ctx, bson.M{
+		"state":      bson.M{"$in": []string{sagaStateRunning, sagaStateCompensating}},
+		"updated_at": bson.M{"$lt": time.Now().UTC().Add(-sagaResumeAfter)},
+	})
+	if err != nil {
+		log.Printf("Failed to look up unfinished order sagas: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var saga sagaModel
+		if err := cursor.Decode(&saga); err != nil {
+			log.Printf("Failed to decode order saga: %v", err)
+			continue
+		}
+
+		// Claim the saga by bumping updated_at, so another replica skips it
+		result, err := s.db.Collection("order_sagas").UpdateOne(ctx,
+			bson.M{"_id": saga.ID, "updated_at": saga.UpdatedAt},
+			bson.M{"$set": bson.M{"updated_at": time.Now().UTC()}},
+		)
+		if err != nil || result.ModifiedCount == 0 {
+			continue
+		}
+
+		runCtx, cancel := context.WithTimeout(ctx, sagaTimeout)
+		if _, err := s.runSaga(runCtx, &saga); err != nil {
+			log.Printf("Resumed saga %s for order %s failed: %v", saga.ID.Hex(), saga.OrderID.Hex(), err)
+		} else {
+			log.Printf("Resumed saga %s for order %s completed", saga.ID.Hex(), saga.OrderID.Hex())
+		}
+		cancel()
+	}
+}
+
+func (s *server) advanceSaga(ctx context.Context, saga *sagaModel, step string) error {
+	saga.Step = step
+	return s.updateSaga(ctx, saga, bson.M{"$set": bson.M{"step": step}})
+}
+
+// updateSaga applies update to the stored saga and refreshes its updated_at.
+func (s *server) updateSaga(ctx context.Context, saga *sagaModel, update bson.M) error {
+	saga.UpdatedAt = time.Now().UTC()
+	if set, ok := update["$set"].(bson.M); ok {
+		set["updated_at"] = saga.UpdatedAt
+	} else {
+		update["$set"] = bson.M{"updated_at": saga.UpdatedAt}
+	}
+
+	_, err := s.db.Collection("order_sagas").UpdateOne(ctx, bson.M{"_id": saga.ID}, update)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to record order saga progress: %v", err)
+	}
+	return nil
+}
+
+// reservationKey returns the key the stock of item index is reserved with, or an empty
+// string for sagas started before reservations had keys.
+func (s *sagaModel) reservationKey(index int) string {
+	if !s.Keyed {
+		return ""
+	}
+	return fmt.Sprintf("%s/%d", s.OrderID.Hex(), index)
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
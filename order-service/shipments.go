@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+type shipmentItemModel struct {
+	ProductID string `bson:"product_id"`
+	Quantity  int32  `bson:"quantity"`
+}
+
+type shipmentModel struct {
+	ID             primitive.ObjectID  `bson:"_id,omitempty"`
+	OrderID        primitive.ObjectID  `bson:"order_id"`
+	Carrier        string              `bson:"carrier"`
+	TrackingNumber string              `bson:"tracking_number"`
+	Items          []shipmentItemModel `bson:"items"`
+	ShippedAt      time.Time           `bson:"shipped_at"`
+	CreatedAt      time.Time           `bson:"created_at"`
+}
+
+func (s *server) CreateShipment(ctx context.Context, req *pb.CreateShipmentRequest) (*pb.Shipment, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order id is required")
+	}
+	if req.Carrier == "" || req.TrackingNumber == "" {
+		return nil, status.Error(codes.InvalidArgument, "carrier and tracking_number are required")
+	}
+	if len(req.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "shipment must contain at least one item")
+	}
+
+	// Merge repeated products so each is checked once
+	var items []shipmentItemModel
+	index := map[string]int{}
+	for _, item := range req.Items {
+		if item.ProductId == "" || item.Quantity < 1 {
+			return nil, status.Error(codes.InvalidArgument, "every shipment item needs a product_id and a quantity of at least 1")
+		}
+		if i, ok := index[item.ProductId]; ok {
+			items[i].Quantity += item.Quantity
+			continue
+		}
+		index[item.ProductId] = len(items)
+		items = append(items, shipmentItemModel{ProductID: item.ProductId, Quantity: item.Quantity})
+	}
+
+	shippedAt := time.Now().UTC()
+	if req.ShippedAt != "" {
+		t, err := time.Parse(time.RFC3339, req.ShippedAt)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "shipped_at must be an RFC 3339 timestamp")
+		}
+		shippedAt = t.UTC()
+	}
+
+	// Convert string ID to ObjectID
+	orderID, err := primitive.ObjectIDFromHex(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order id")
+	}
+
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shipment := shipmentModel{
+		ID:             primitive.NewObjectID(),
+		OrderID:        orderID,
+		Carrier:        req.Carrier,
+		TrackingNumber: req.TrackingNumber,
+		Items:          items,
+		ShippedAt:      shippedAt,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	// The order is always written, even when its status doesn't change, so concurrent
+	// shipments for the same order conflict and are re-checked on retry.
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		var order orderModel
+		if err := s.db.Collection("orders").FindOne(sc, bson.M{"_id": orderID}).Decode(&order); err != nil {
+			return err
+		}
+
+		current := parseStatus(order.Status)
+		if current != pb.OrderStatus_ORDER_STATUS_PAID && current != pb.OrderStatus_ORDER_STATUS_PARTIALLY_SHIPPED {
+			return status.Errorf(codes.FailedPrecondition, "order in status %s cannot be shipped", order.Status)
+		}
+
+		remaining, err := s.remainingToShip(sc, &order)
+		if err != nil {
+			return err
+		}
+		backordered := map[string]int32{}
+		for _, item := range order.Items {
+			backordered[item.ProductID] += item.Backordered
+		}
+		for _, item := range items {
+			if _, ok := remaining[item.ProductID]; !ok {
+				return status.Errorf(codes.InvalidArgument, "product %s is not part of this order", item.ProductID)
+			}
+			if item.Quantity > remaining[item.ProductID] {
+				return status.Errorf(codes.FailedPrecondition, "product %s has only %d units left to ship",
+					item.ProductID, remaining[item.ProductID])
+			}
+			if item.Quantity > remaining[item.ProductID]-backordered[item.ProductID] {
+				return status.Errorf(codes.FailedPrecondition, "product %s has %d units waiting for stock",
+					item.ProductID, backordered[item.ProductID])
+			}
+			remaining[item.ProductID] -= item.Quantity
+		}
+
+		if _, err := s.db.Collection("shipments").InsertOne(sc, shipment); err != nil {
+			return err
+		}
+
+		// Derive the order status from what is left to ship
+		next := pb.OrderStatus_ORDER_STATUS_SHIPPED
+		for _, quantity := range remaining {
+			if quantity > 0 {
+				next = pb.OrderStatus_ORDER_STATUS_PARTIALLY_SHIPPED
+				break
+			}
+		}
+
+		update := bson.M{"$set": bson.M{"updated_at": time.Now().UTC()}}
+		var change statusChangeModel
+		if next != current {
+			change = newStatusChange(order.Status, statusName(next), actor,
+				fmt.Sprintf("shipment %s via %s", req.TrackingNumber, req.Carrier))
+			update["$set"] = bson.M{"status": change.NewStatus, "updated_at": change.ChangedAt}
+			update["$push"] = bson.M{"status_history": change}
+		}
+
+		var updated orderModel
+		err = s.db.Collection("orders").FindOneAndUpdate(sc,
+			bson.M{"_id": orderID, "status": order.Status},
+			update,
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&updated)
+		if err == mongo.ErrNoDocuments {
+			return status.Error(codes.Aborted, "order status changed concurrently, retry the shipment")
+		}
+		if err != nil {
+			return err
+		}
+
+		if next != current {
+			return s.enqueueEvent(sc, eventOrderStatusChanged, &updated, order.Status, change.Reason)
+		}
+		return nil
+	})
+
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create shipment: %v", err)
+	}
+
+	return shipment.toProto(), nil
+}
+
+func (s *server) ListShipments(ctx context.Context, req *pb.ListShipmentsRequest) (*pb.ListShipmentsResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order id is required")
+	}
+
+	// Convert string ID to ObjectID
+	orderID, err := primitive.ObjectIDFromHex(req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order id")
+	}
+
+	cursor, err := s.db.Collection("shipments").Find(ctx, bson.M{"order_id": orderID},
+		options.Find().SetSort(bson.M{"shipped_at": 1}),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list shipments: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	shipments := make([]*pb.Shipment, 0)
+	for cursor.Next(ctx) {
+		var shipment shipmentModel
+		if err := cursor.Decode(&shipment); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to decode shipment: %v", err)
+		}
+		shipments = append(shipments, shipment.toProto())
+	}
+
+	return &pb.ListShipmentsResponse{Shipments: shipments}, nil
+}
+
+// remainingToShip returns, per product, the ordered quantity not yet covered by a shipment.
+func (s *server) remainingToShip(ctx context.Context, order *orderModel) (map[string]int32, error) {
+	remaining := map[string]int32{}
+	for _, item := range order.Items {
+		remaining[item.ProductID] += item.Quantity
+	}
+
+	cursor, err := s.db.Collection("shipments").Find(ctx, bson.M{"order_id": order.ID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var shipment shipmentModel
+		if err := cursor.Decode(&shipment); err != nil {
+			return nil, err
+		}
+		for _, item := range shipment.Items {
+			remaining[item.ProductID] -= item.Quantity
+		}
+	}
+	return remaining, cursor.Err()
+}
+
+// toProto converts a stored shipment into its protobuf representation.
+func (sh *shipmentModel) toProto() *pb.Shipment {
+	items := make([]*pb.ShipmentItem, 0, len(sh.Items))
+	for _, item := range sh.Items {
+		items = append(items, &pb.ShipmentItem{
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return &pb.Shipment{
+		Id:             sh.ID.Hex(),
+		OrderId:        sh.OrderID.Hex(),
+		Carrier:        sh.Carrier,
+		TrackingNumber: sh.TrackingNumber,
+		Items:          items,
+		ShippedAt:      sh.ShippedAt.Format(time.RFC3339),
+		CreatedAt:      sh.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// Shipping costs are priced by billable weight: for every unit, the larger of its
+// weight and its volumetric weight (length x width x height / 5000, in cm and kg),
+// summed over the order. An option costs its base price, which covers the first
+// started kilogram, plus its price per further started kilogram.
+
+// volumetricDivisor converts a volume in cubic millimetres to a volumetric weight in grams.
+const volumetricDivisor = 5000
+
+const (
+	shippingZoneDomestic      = "domestic"
+	shippingZoneInternational = "international"
+)
+
+// defaultShippingRates is the rate table used when SHIPPING_RATES_FILE is not set.
+//
+//go:embed shipping_rates.json
+var defaultShippingRates []byte
+
+// shippingRate is one option of the rate table, offered to destinations in its zone
+// for orders priced in its currency.
+type shippingRate struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Zone           string `json:"zone"`
+	Currency       string `json:"currency"`
+	BaseUnitsMinor int64  `json:"base_units_minor"`
+	PerKgMinor     int64  `json:"per_kg_units_minor"`
+	MaxWeightGrams int64  `json:"max_weight_grams,omitempty"`
+	MinDays        int32  `json:"min_days"`
+	MaxDays        int32  `json:"max_days"`
+}
+
+// shippingRateTable holds the shipping options. Destinations in OriginCountry are
+// domestic, all others international.
+type shippingRateTable struct {
+	OriginCountry string         `json:"origin_country"`
+	Rates         []shippingRate `json:"rates"`
+}
+
+type shippingOptionModel struct {
+	ID      string     `bson:"id"`
+	Name    string     `bson:"name"`
+	Cost    moneyModel `bson:"cost"`
+	MinDays int32      `bson:"min_days"`
+	MaxDays int32      `bson:"max_days"`
+}
+
+// newShippingRateTable parses a rate table of the form {"origin_country": "US", "rates": [...]}.
+func newShippingRateTable(data []byte) (*shippingRateTable, error) {
+	var table shippingRateTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("invalid shipping rate table: %v", err)
+	}
+
+	table.OriginCountry = strings.ToUpper(table.OriginCountry)
+	if len(table.OriginCountry) != 2 {
+		return nil, fmt.Errorf("shipping rate table needs a two-letter origin_country")
+	}
+	for i, rate := range table.Rates {
+		switch {
+		case rate.ID != "standard" && rate.ID != "express" && rate.ID != "pickup":
+			return nil, fmt.Errorf("shipping rate %d has unknown id %q, expected standard, express or pickup", i, rate.ID)
+		case rate.Zone != shippingZoneDomestic && rate.Zone != shippingZoneInternational:
+			return nil, fmt.Errorf("shipping rate %d has unknown zone %q", i, rate.Zone)
+		case len(rate.Currency) != 3:
+			return nil, fmt.Errorf("shipping rate %d needs a three-letter currency", i)
+		case rate.BaseUnitsMinor < 0 || rate.PerKgMinor < 0 || rate.MaxWeightGrams < 0:
+			return nil, fmt.Errorf("shipping rate %d has a negative price or weight", i)
+		case rate.MinDays < 0 || rate.MaxDays < rate.MinDays:
+			return nil, fmt.Errorf("shipping rate %d has invalid delivery days", i)
+		}
+	}
+	return &table, nil
+}
+
+// quote returns the options available to address for a shipment of billableGrams
+// priced in currency, in table order.
+func (t *shippingRateTable) quote(address addressModel, currency string, billableGrams int64) ([]shippingOptionModel, error) {
+	zone := shippingZoneInternational
+	if address.Country == t.OriginCountry {
+		zone = shippingZoneDomestic
+	}
+
+	// The base price covers the first started kilogram
+	extraKg := (billableGrams+999)/1000 - 1
+	if extraKg < 0 {
+		extraKg = 0
+	}
+
+	options := make([]shippingOptionModel, 0, len(t.Rates))
+	for _, rate := range t.Rates {
+		if rate.Zone != zone || rate.Currency != currency ||
+			(rate.MaxWeightGrams > 0 && billableGrams > rate.MaxWeightGrams) {
+			continue
+		}
+		if rate.PerKgMinor > 0 && extraKg > (math.MaxInt64-rate.BaseUnitsMinor)/rate.PerKgMinor {
+			return nil, errAmountOverflow
+		}
+		options = append(options, shippingOptionModel{
+			ID:      rate.ID,
+			Name:    rate.Name,
+			Cost:    moneyModel{UnitsMinor: rate.BaseUnitsMinor + rate.PerKgMinor*extraKg, Currency: currency},
+			MinDays: rate.MinDays,
+			MaxDays: rate.MaxDays,
+		})
+	}
+	return options, nil
+}
+
+func (s *server) QuoteShipping(ctx context.Context, req *pb.QuoteShippingRequest) (*pb.ShippingQuote, error) {
+	if len(req.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "quote must contain at least one item")
+	}
+	for _, item := range req.Items {
+		if item.ProductId == "" || item.Quantity < 1 {
+			return nil, status.Error(codes.InvalidArgument, "every item needs a product_id and a quantity of at least 1")
+		}
+	}
+	address, err := addressFromProto(req.ShippingAddress)
+	if err != nil {
+		return nil, err
+	}
+	if address == nil {
+		return nil, status.Error(codes.InvalidArgument, "shipping_address is required")
+	}
+
+	products := map[string]*pb.Product{}
+	items := make([]orderItemModel, 0, len(req.Items))
+	currency := ""
+	for _, item := range req.Items {
+		product, err := s.productClient.GetProduct(ctx, &pb.GetProductRequest{Id: item.ProductId})
+		if err != nil {
+			switch status.Code(err) {
+			case codes.NotFound, codes.InvalidArgument:
+				return nil, status.Errorf(codes.InvalidArgument, "product %s not found", item.ProductId)
+			default:
+				return nil, status.Errorf(codes.Unavailable, "failed to look up product %s: %v", item.ProductId, err)
+			}
+		}
+		if product.Price == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "product %s has no price", item.ProductId)
+		}
+		if currency != "" && product.Price.Currency != currency {
+			return nil, status.Error(codes.FailedPrecondition, "order items are priced in different currencies")
+		}
+		currency = product.Price.Currency
+
+		products[item.ProductId] = product
+		items = append(items, orderItemModel{ProductID: item.ProductId, Quantity: item.Quantity})
+	}
+
+	weight, err := billableWeight(items, products)
+	if err != nil {
+		return nil, err
+	}
+	options, err := s.shipping.quote(*address, currency, weight)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "shipping cost is out of range")
+	}
+
+	return &pb.ShippingQuote{
+		Options:             shippingOptionsToProto(options),
+		BillableWeightGrams: int32(weight),
+	}, nil
+}
+
+// chooseShipping prices the shipping option with the given id for an order. It fails
+// with FailedPrecondition if the option is not offered for the order.
+func (s *server) chooseShipping(id string, address addressModel, currency string,
+	items []orderItemModel, products map[string]*pb.Product) (*shippingOptionModel, error) {
+	weight, err := billableWeight(items, products)
+	if err != nil {
+		return nil, err
+	}
+	options, err := s.shipping.quote(address, currency, weight)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "shipping cost is out of range")
+	}
+	for i := range options {
+		if options[i].ID == id {
+			return &options[i], nil
+		}
+	}
+	return nil, status.Errorf(codes.FailedPrecondition, "shipping option %q is not available for this order", id)
+}
+
+// billableWeight returns the billable weight in grams of items. Products without a
+// parcel count as weightless.
+func billableWeight(items []orderItemModel, products map[string]*pb.Product) (int64, error) {
+	total := new(big.Int)
+	for _, item := range items {
+		parcel := products[item.ProductID].GetParcel()
+		if parcel == nil {
+			continue
+		}
+
+		// Volumetric weight is rounded up to the next gram
+		volume := new(big.Int).Mul(big.NewInt(int64(parcel.LengthMm)), big.NewInt(int64(parcel.WidthMm)))
+		volume.Mul(volume, big.NewInt(int64(parcel.HeightMm)))
+		volumetric := volume.Add(volume, big.NewInt(volumetricDivisor-1)).Quo(volume, big.NewInt(volumetricDivisor))
+
+		unit := big.NewInt(int64(parcel.WeightGrams))
+		if volumetric.Cmp(unit) > 0 {
+			unit = volumetric
+		}
+		total.Add(total, unit.Mul(unit, big.NewInt(int64(item.Quantity))))
+	}
+
+	if total.Cmp(big.NewInt(math.MaxInt32)) > 0 {
+		return 0, status.Error(codes.InvalidArgument, "order is too heavy to ship")
+	}
+	return total.Int64(), nil
+}
+
+// toProto converts a stored shipping option into its protobuf representation.
+func (o *shippingOptionModel) toProto() *pb.ShippingOption {
+	if o == nil {
+		return nil
+	}
+	return &pb.ShippingOption{
+		Id:      o.ID,
+		Name:    o.Name,
+		Cost:    o.Cost.toProto(),
+		MinDays: o.MinDays,
+		MaxDays: o.MaxDays,
+	}
+}
+
+func shippingOptionsToProto(options []shippingOptionModel) []*pb.ShippingOption {
+	converted := make([]*pb.ShippingOption, 0, len(options))
+	for i := range options {
+		converted = append(converted, options[i].toProto())
+	}
+	return converted
+}
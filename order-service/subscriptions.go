@@ -0,0 +1,623 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// A subscription places the same order every cycle. Every subscriptionPollInterval the
+// replica holding the subscription lease places the orders of the subscriptions that
+// are due through the same path as CreateOrder, as the system actor. Each cycle uses
+// the idempotency key subscription:<id>:<cycle>, so a cycle interrupted after its order
+// was placed does not place a second one.
+//
+// A failed attempt is recorded on the subscription and retried after
+// subscriptionRetryDelay, doubling each time. After subscriptionMaxAttempts failures
+// the cycle is given up and the subscription moves on to the next one. Cycles missed
+// while a subscription was paused or the service was down are not made up.
+
+// subscriptionPollInterval is how often due subscriptions are looked for.
+const subscriptionPollInterval = time.Minute
+
+// subscriptionBatchSize bounds the orders placed in one run, so a run finishes well
+// within the lease.
+const subscriptionBatchSize = 100
+
+// subscriptionLease is the lease that lets one replica at a time place subscription orders.
+const subscriptionLease = "run_subscriptions"
+
+const (
+	subscriptionMaxAttempts = 5
+	subscriptionRetryDelay  = 5 * time.Minute
+)
+
+// subscriptionRunsKept is how many of the most recent runs a subscription keeps.
+const subscriptionRunsKept = 20
+
+type subscriptionModel struct {
+	ID              primitive.ObjectID      `bson:"_id,omitempty"`
+	UserID          string                  `bson:"user_id"`
+	Items           []subscriptionItemModel `bson:"items"`
+	CouponCodes     []string                `bson:"coupon_codes,omitempty"`
+	ShippingAddress *addressModel           `bson:"shipping_address,omitempty"`
+	ShippingOption  string                  `bson:"shipping_option,omitempty"`
+	Interval        string                  `bson:"interval"`
+	IntervalCount   int32                   `bson:"interval_count"`
+	Status          string                  `bson:"status"`
+	CreatedAt       time.Time               `bson:"created_at"`
+	UpdatedAt       time.Time               `bson:"updated_at"`
+
+	// Runs are scheduled Periods intervals after AnchorAt. Cycle counts the cycles done,
+	// placed, skipped or given up, and only ever grows.
+	AnchorAt       time.Time              `bson:"anchor_at"`
+	Periods        int32                  `bson:"periods"`
+	Cycle          int32                  `bson:"cycle"`
+	NextRunAt      time.Time              `bson:"next_run_at"`
+	DueAt          time.Time              `bson:"due_at"` // NextRunAt, or RetryAt while retrying
+	FailedAttempts int32                  `bson:"failed_attempts"`
+	RetryAt        *time.Time             `bson:"retry_at,omitempty"`
+	LastError      string                 `bson:"last_error,omitempty"`
+	Runs           []subscriptionRunModel `bson:"runs"`
+}
+
+type subscriptionItemModel struct {
+	ProductID string `bson:"product_id"`
+	Quantity  int32  `bson:"quantity"`
+}
+
+type subscriptionRunModel struct {
+	ScheduledFor time.Time `bson:"scheduled_for"`
+	RanAt        time.Time `bson:"ran_at"`
+	OrderID      string    `bson:"order_id,omitempty"`
+	Error        string    `bson:"error,omitempty"`
+	Skipped      bool      `bson:"skipped,omitempty"`
+}
+
+// subscriptionServer implements the SubscriptionService RPCs and places the orders of
+// due subscriptions through the order server.
+type subscriptionServer struct {
+	pb.UnimplementedSubscriptionServiceServer
+	db     *mongo.Database
+	orders *server
+}
+
+func (s *subscriptionServer) CreateSubscription(ctx context.Context, req *pb.CreateSubscriptionRequest) (*pb.Subscription, error) {
+	subscription := &subscriptionModel{UserID: req.UserId}
+	if err := s.setContents(ctx, subscription, req.Items, req.CouponCodes, req.ShippingAddress,
+		req.ShippingOption, req.Interval, req.IntervalCount); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	firstRun := now
+	if req.FirstRunAt != "" {
+		t, err := time.Parse(time.RFC3339, req.FirstRunAt)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "first_run_at must be an RFC 3339 timestamp")
+		}
+		firstRun = t.UTC()
+	}
+
+	subscription.ID = primitive.NewObjectID()
+	subscription.Status = subscriptionStatusName(pb.SubscriptionStatus_SUBSCRIPTION_STATUS_ACTIVE)
+	subscription.reschedule(firstRun)
+	subscription.Runs = []subscriptionRunModel{}
+	subscription.CreatedAt = now
+	subscription.UpdatedAt = now
+
+	if _, err := s.db.Collection("subscriptions").InsertOne(ctx, subscription); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create subscription: %v", err)
+	}
+
+	return subscription.toProto(), nil
+}
+
+func (s *subscriptionServer) GetSubscription(ctx context.Context, req *pb.GetSubscriptionRequest) (*pb.Subscription, error) {
+	subscription, err := s.findSubscription(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return subscription.toProto(), nil
+}
+
+func (s *subscriptionServer) UpdateSubscription(ctx context.Context, req *pb.UpdateSubscriptionRequest) (*pb.Subscription, error) {
+	var nextRun *time.Time
+	if req.NextRunAt != "" {
+		t, err := time.Parse(time.RFC3339, req.NextRunAt)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "next_run_at must be an RFC 3339 timestamp")
+		}
+		t = t.UTC()
+		nextRun = &t
+	}
+
+	return s.changeSubscription(ctx, req.Id, func(subscription *subscriptionModel) error {
+		interval, count := subscription.Interval, subscription.IntervalCount
+		if err := s.setContents(ctx, subscription, req.Items, req.CouponCodes, req.ShippingAddress,
+			req.ShippingOption, req.Interval, req.IntervalCount); err != nil {
+			return err
+		}
+
+		// A new cadence starts from the next run
+		switch {
+		case nextRun != nil:
+			subscription.reschedule(*nextRun)
+		case subscription.Interval != interval || subscription.IntervalCount != count:
+			subscription.reschedule(subscription.NextRunAt)
+		}
+		return nil
+	})
+}
+
+func (s *subscriptionServer) ListSubscriptions(ctx context.Context, req *pb.ListSubscriptionsRequest) (*pb.ListSubscriptionsResponse, error) {
+	// Set default values for pagination
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 10
+	}
+
+	// Create filter
+	filter := bson.M{}
+	if req.UserId != "" {
+		filter["user_id"] = req.UserId
+	}
+	if req.Status != pb.SubscriptionStatus_SUBSCRIPTION_STATUS_UNSPECIFIED {
+		filter["status"] = subscriptionStatusName(req.Status)
+	}
+
+	// Get total count, only when asked for
+	var total int64
+	var err error
+	if req.IncludeTotal {
+		total, err = s.db.Collection("subscriptions").CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to count subscriptions: %v", err)
+		}
+	}
+
+	// Continue after the page token. One subscription more than the limit is read to
+	// tell whether there is a next page.
+	query := fmt.Sprintf("user_id=%s&status=%d", req.UserId, req.Status)
+	if req.PageToken != "" {
+		token, err := decodePageToken(req.PageToken, query)
+		if err != nil {
+			return nil, err
+		}
+		filter = bson.M{"$and": bson.A{filter,
+			afterFilter("created_at", -1, primitive.DateTime(token.Value), token.ID)}}
+	}
+
+	// Find subscriptions
+	cursor, err := s.db.Collection("subscriptions").Find(ctx, filter,
+		options.Find().
+			SetLimit(int64(req.Limit)+1).
+			SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list subscriptions: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	subscriptions := make([]*pb.Subscription, 0)
+	var last subscriptionModel
+	var nextPageToken string
+	for cursor.Next(ctx) {
+		if len(subscriptions) == int(req.Limit) {
+			nextPageToken = encodePageToken(query, int64(primitive.NewDateTimeFromTime(last.CreatedAt)), last.ID)
+			break
+		}
+
+		var subscription subscriptionModel
+		if err := cursor.Decode(&subscription); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to decode subscription: %v", err)
+		}
+		last = subscription
+		subscriptions = append(subscriptions, subscription.toProto())
+	}
+
+	return &pb.ListSubscriptionsResponse{
+		Subscriptions: subscriptions,
+		Total:         int32(total),
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (s *subscriptionServer) PauseSubscription(ctx context.Context, req *pb.GetSubscriptionRequest) (*pb.Subscription, error) {
+	return s.changeSubscription(ctx, req.Id, func(subscription *subscriptionModel) error {
+		if parseSubscriptionStatus(subscription.Status) != pb.SubscriptionStatus_SUBSCRIPTION_STATUS_ACTIVE {
+			return status.Errorf(codes.FailedPrecondition, "subscription is %s, only active subscriptions can be paused", subscription.Status)
+		}
+		subscription.Status = subscriptionStatusName(pb.SubscriptionStatus_SUBSCRIPTION_STATUS_PAUSED)
+		return nil
+	})
+}
+
+func (s *subscriptionServer) ResumeSubscription(ctx context.Context, req *pb.GetSubscriptionRequest) (*pb.Subscription, error) {
+	return s.changeSubscription(ctx, req.Id, func(subscription *subscriptionModel) error {
+		if parseSubscriptionStatus(subscription.Status) != pb.SubscriptionStatus_SUBSCRIPTION_STATUS_PAUSED {
+			return status.Errorf(codes.FailedPrecondition, "subscription is %s, only paused subscriptions can be resumed", subscription.Status)
+		}
+		subscription.Status = subscriptionStatusName(pb.SubscriptionStatus_SUBSCRIPTION_STATUS_ACTIVE)
+
+		// Cycles missed while paused are not made up
+		subscription.advance(time.Now().UTC(), false)
+		return nil
+	})
+}
+
+func (s *subscriptionServer) SkipSubscription(ctx context.Context, req *pb.GetSubscriptionRequest) (*pb.Subscription, error) {
+	return s.changeSubscription(ctx, req.Id, func(subscription *subscriptionModel) error {
+		if parseSubscriptionStatus(subscription.Status) == pb.SubscriptionStatus_SUBSCRIPTION_STATUS_CANCELLED {
+			return status.Error(codes.FailedPrecondition, "subscription is cancelled")
+		}
+		subscription.record(subscriptionRunModel{
+			ScheduledFor: subscription.NextRunAt,
+			RanAt:        time.Now().UTC(),
+			Skipped:      true,
+		})
+		subscription.advance(subscription.NextRunAt, true)
+		return nil
+	})
+}
+
+func (s *subscriptionServer) CancelSubscription(ctx context.Context, req *pb.GetSubscriptionRequest) (*pb.Subscription, error) {
+	return s.changeSubscription(ctx, req.Id, func(subscription *subscriptionModel) error {
+		if parseSubscriptionStatus(subscription.Status) == pb.SubscriptionStatus_SUBSCRIPTION_STATUS_CANCELLED {
+			return status.Error(codes.FailedPrecondition, "subscription is already cancelled")
+		}
+		subscription.Status = subscriptionStatusName(pb.SubscriptionStatus_SUBSCRIPTION_STATUS_CANCELLED)
+		return nil
+	})
+}
+
+// setContents validates what a subscription orders and how often, and sets it.
+func (s *subscriptionServer) setContents(ctx context.Context, subscription *subscriptionModel,
+	items []*pb.OrderItem, couponCodes []string, address *pb.Address, shippingOption string,
+	interval pb.SubscriptionInterval, count int32) error {
+	if interval == pb.SubscriptionInterval_SUBSCRIPTION_INTERVAL_UNSPECIFIED {
+		return status.Error(codes.InvalidArgument, "interval is required")
+	}
+	if _, ok := pb.SubscriptionInterval_name[int32(interval)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown interval %d", interval)
+	}
+	if count == 0 {
+		count = 1
+	}
+	if count < 1 || count > 365 {
+		return status.Error(codes.InvalidArgument, "interval_count must be between 1 and 365")
+	}
+
+	// Subscriptions must describe an order CreateOrder would accept
+	req := &pb.CreateOrderRequest{
+		UserId:          subscription.UserID,
+		Items:           items,
+		CouponCodes:     couponCodes,
+		ShippingAddress: address,
+		ShippingOption:  shippingOption,
+	}
+	if err := validateOrderRequest(req); err != nil {
+		return err
+	}
+	shippingAddress, err := addressFromProto(address)
+	if err != nil {
+		return err
+	}
+
+	subscription.Items = make([]subscriptionItemModel, 0, len(items))
+	for _, item := range items {
+		_, err := s.orders.productClient.GetProduct(ctx, &pb.GetProductRequest{Id: item.ProductId})
+		if err != nil {
+			switch status.Code(err) {
+			case codes.NotFound, codes.InvalidArgument:
+				return status.Errorf(codes.InvalidArgument, "product %s not found", item.ProductId)
+			default:
+				return status.Errorf(codes.Unavailable, "failed to look up product %s: %v", item.ProductId, err)
+			}
+		}
+		subscription.Items = append(subscription.Items, subscriptionItemModel{ProductID: item.ProductId, Quantity: item.Quantity})
+	}
+
+	subscription.CouponCodes = nil
+	for _, code := range couponCodes {
+		subscription.CouponCodes = append(subscription.CouponCodes, normalizeCouponCode(code))
+	}
+	subscription.ShippingAddress = shippingAddress
+	subscription.ShippingOption = shippingOption
+	subscription.Interval = subscriptionIntervalName(interval)
+	subscription.IntervalCount = count
+	return nil
+}
+
+// findSubscription loads the subscription with the given hex id.
+func (s *subscriptionServer) findSubscription(ctx context.Context, hexID string) (*subscriptionModel, error) {
+	if hexID == "" {
+		return nil, status.Error(codes.InvalidArgument, "subscription id is required")
+	}
+
+	// Convert string ID to ObjectID
+	id, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid subscription id")
+	}
+
+	var subscription subscriptionModel
+	err = s.db.Collection("subscriptions").FindOne(ctx, bson.M{"_id": id}).Decode(&subscription)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Error(codes.NotFound, "subscription not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get subscription: %v", err)
+	}
+	return &subscription, nil
+}
+
+// changeSubscription applies change to a subscription and stores the result, unless
+// the subscription was changed meanwhile, by a client or by a run.
+func (s *subscriptionServer) changeSubscription(ctx context.Context, hexID string,
+	change func(subscription *subscriptionModel) error) (*pb.Subscription, error) {
+	subscription, err := s.findSubscription(ctx, hexID)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt := subscription.UpdatedAt
+	if err := change(subscription); err != nil {
+		return nil, err
+	}
+
+	subscription.UpdatedAt = time.Now().UTC()
+	result, err := s.db.Collection("subscriptions").ReplaceOne(ctx,
+		bson.M{"_id": subscription.ID, "updated_at": updatedAt}, subscription)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update subscription: %v", err)
+	}
+	if result.MatchedCount == 0 {
+		return nil, status.Error(codes.Aborted, "subscription changed concurrently, retry the change")
+	}
+	return subscription.toProto(), nil
+}
+
+// runSubscriptions places the orders of due subscriptions every subscriptionPollInterval
+// until ctx is done. Only the replica holding the subscription lease does so.
+func (s *subscriptionServer) runSubscriptions(ctx context.Context) {
+	holder := newInstanceID()
+	ticker := time.NewTicker(subscriptionPollInterval)
+	defer ticker.Stop()
+
+	for {
+		held, err := acquireLease(ctx, s.db, subscriptionLease, holder, 2*subscriptionPollInterval)
+		if err != nil {
+			log.Printf("Failed to acquire the %s lease: %v", subscriptionLease, err)
+		} else if held {
+			s.runDueSubscriptions(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDueSubscriptions runs up to subscriptionBatchSize due subscriptions, longest
+// due first.
+func (s *subscriptionServer) runDueSubscriptions(ctx context.Context) {
+	cursor, err := s.db.Collection("subscriptions").Find(ctx,
+		bson.M{
+			"status": subscriptionStatusName(pb.SubscriptionStatus_SUBSCRIPTION_STATUS_ACTIVE),
+			"due_at": bson.M{"$lte": time.Now().UTC()},
+		},
+		options.Find().SetSort(bson.D{{Key: "due_at", Value: 1}}).SetLimit(subscriptionBatchSize),
+	)
+	if err != nil {
+		log.Printf("Failed to look up due subscriptions: %v", err)
+		return
+	}
+	var due []subscriptionModel
+	if err := cursor.All(ctx, &due); err != nil {
+		log.Printf("Failed to decode due subscriptions: %v", err)
+		return
+	}
+
+	for i := range due {
+		if err := s.runCycle(ctx, &due[i]); err != nil {
+			log.Printf("Failed to record run of subscription %s: %v", due[i].ID.Hex(), err)
+		}
+	}
+}
+
+// runCycle places the order of a subscription's current cycle and records the outcome.
+// The result is dropped if the subscription changed meanwhile; the cycle then runs
+// again and its idempotency key returns the order already placed.
+func (s *subscriptionServer) runCycle(ctx context.Context, subscription *subscriptionModel) error {
+	key := fmt.Sprintf("subscription:%s:%d", subscription.ID.Hex(), subscription.Cycle)
+	order, err := s.orders.createOrder(ctx, subscription.orderRequest(), systemActor, key, subscription.ID.Hex())
+	if status.Code(err) == codes.AlreadyExists {
+		// The subscription was edited after this cycle's order was placed
+		var placed orderModel
+		if findErr := s.db.Collection("orders").FindOne(ctx, bson.M{"idempotency_key": key}).Decode(&placed); findErr == nil {
+			order, err = placed.toProto(), nil
+		}
+	}
+
+	now := time.Now().UTC()
+	updatedAt := subscription.UpdatedAt
+	run := subscriptionRunModel{ScheduledFor: subscription.NextRunAt, RanAt: now}
+	if err == nil {
+		run.OrderID = order.Id
+		subscription.LastError = ""
+		subscription.advance(now, true)
+		log.Printf("Placed order %s for subscription %s", order.Id, subscription.ID.Hex())
+	} else {
+		run.Error = status.Convert(err).Message()
+		subscription.LastError = run.Error
+		subscription.FailedAttempts++
+		if subscription.FailedAttempts >= subscriptionMaxAttempts {
+			log.Printf("Giving up cycle %d of subscription %s after %d attempts: %v",
+				subscription.Cycle, subscription.ID.Hex(), subscription.FailedAttempts, err)
+			subscription.advance(now, true)
+		} else {
+			retryAt := now.Add(subscriptionRetryDelay << (subscription.FailedAttempts - 1))
+			subscription.RetryAt = &retryAt
+			subscription.DueAt = retryAt
+		}
+	}
+	subscription.record(run)
+	subscription.UpdatedAt = now
+
+	_, err = s.db.Collection("subscriptions").ReplaceOne(ctx,
+		bson.M{"_id": subscription.ID, "updated_at": updatedAt}, subscription)
+	return err
+}
+
+// orderRequest returns the CreateOrderRequest for one cycle of the subscription.
+func (sub *subscriptionModel) orderRequest() *pb.CreateOrderRequest {
+	items := make([]*pb.OrderItem, 0, len(sub.Items))
+	for _, item := range sub.Items {
+		items = append(items, &pb.OrderItem{ProductId: item.ProductID, Quantity: item.Quantity})
+	}
+	return &pb.CreateOrderRequest{
+		UserId:          sub.UserID,
+		Items:           items,
+		CouponCodes:     sub.CouponCodes,
+		ShippingAddress: sub.ShippingAddress.toProto(),
+		ShippingOption:  sub.ShippingOption,
+	}
+}
+
+// reschedule starts the subscription's cadence at first, which becomes the next run.
+func (sub *subscriptionModel) reschedule(first time.Time) {
+	sub.AnchorAt = first
+	sub.Periods = 0
+	sub.NextRunAt = first
+	sub.clearFailures()
+}
+
+// advance moves the next run to the first scheduled time after the current one that
+// is not before after. cycleDone counts the current cycle as done.
+func (sub *subscriptionModel) advance(after time.Time, cycleDone bool) {
+	if cycleDone {
+		sub.Cycle++
+		sub.Periods++
+		sub.NextRunAt = sub.runAt(sub.Periods)
+	}
+	for sub.NextRunAt.Before(after) {
+		sub.Periods++
+		sub.NextRunAt = sub.runAt(sub.Periods)
+	}
+	sub.clearFailures()
+}
+
+// clearFailures forgets the failed attempts at the current cycle, so the next run
+// happens when scheduled.
+func (sub *subscriptionModel) clearFailures() {
+	sub.FailedAttempts = 0
+	sub.RetryAt = nil
+	sub.DueAt = sub.NextRunAt
+}
+
+// runAt returns when the run periods intervals after the anchor is scheduled. Monthly
+// runs keep the anchor's day of the month, or take the last day of shorter months.
+func (sub *subscriptionModel) runAt(periods int32) time.Time {
+	n := int(sub.IntervalCount) * int(periods)
+	anchor := sub.AnchorAt
+	switch parseSubscriptionInterval(sub.Interval) {
+	case pb.SubscriptionInterval_SUBSCRIPTION_INTERVAL_DAY:
+		return anchor.AddDate(0, 0, n)
+	case pb.SubscriptionInterval_SUBSCRIPTION_INTERVAL_WEEK:
+		return anchor.AddDate(0, 0, 7*n)
+	}
+
+	first := time.Date(anchor.Year(), anchor.Month()+time.Month(n), 1,
+		anchor.Hour(), anchor.Minute(), anchor.Second(), anchor.Nanosecond(), time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(anchor.Day(), lastDay)-1)
+}
+
+// record adds a run to the subscription's history, keeping the most recent ones.
+func (sub *subscriptionModel) record(run subscriptionRunModel) {
+	sub.Runs = append([]subscriptionRunModel{run}, sub.Runs...)
+	if len(sub.Runs) > subscriptionRunsKept {
+		sub.Runs = sub.Runs[:subscriptionRunsKept]
+	}
+}
+
+// toProto converts a stored subscription into its protobuf representation.
+func (sub *subscriptionModel) toProto() *pb.Subscription {
+	items := make([]*pb.OrderItem, 0, len(sub.Items))
+	for _, item := range sub.Items {
+		items = append(items, &pb.OrderItem{ProductId: item.ProductID, Quantity: item.Quantity})
+	}
+	runs := make([]*pb.SubscriptionRun, 0, len(sub.Runs))
+	for _, run := range sub.Runs {
+		runs = append(runs, &pb.SubscriptionRun{
+			ScheduledFor: run.ScheduledFor.Format(time.RFC3339),
+			RanAt:        run.RanAt.Format(time.RFC3339),
+			OrderId:      run.OrderID,
+			Error:        run.Error,
+			Skipped:      run.Skipped,
+		})
+	}
+
+	subscription := &pb.Subscription{
+		Id:              sub.ID.Hex(),
+		UserId:          sub.UserID,
+		Items:           items,
+		CouponCodes:     sub.CouponCodes,
+		ShippingAddress: sub.ShippingAddress.toProto(),
+		ShippingOption:  sub.ShippingOption,
+		Interval:        parseSubscriptionInterval(sub.Interval),
+		IntervalCount:   sub.IntervalCount,
+		Status:          parseSubscriptionStatus(sub.Status),
+		NextRunAt:       sub.NextRunAt.Format(time.RFC3339),
+		FailedAttempts:  sub.FailedAttempts,
+		LastError:       sub.LastError,
+		Runs:            runs,
+		CreatedAt:       sub.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       sub.UpdatedAt.Format(time.RFC3339),
+	}
+	if sub.RetryAt != nil {
+		subscription.RetryAt = sub.RetryAt.Format(time.RFC3339)
+	}
+	return subscription
+}
+
+// subscriptionIntervalName returns the lowercase name stored in MongoDB for an interval.
+func subscriptionIntervalName(interval pb.SubscriptionInterval) string {
+	return strings.ToLower(strings.TrimPrefix(interval.String(), "SUBSCRIPTION_INTERVAL_"))
+}
+
+// parseSubscriptionInterval converts a stored interval name back to its enum value.
+func parseSubscriptionInterval(name string) pb.SubscriptionInterval {
+	return pb.SubscriptionInterval(pb.SubscriptionInterval_value["SUBSCRIPTION_INTERVAL_"+strings.ToUpper(name)])
+}
+
+// subscriptionStatusName returns the lowercase name stored in MongoDB for a status.
+func subscriptionStatusName(st pb.SubscriptionStatus) string {
+	return strings.ToLower(strings.TrimPrefix(st.String(), "SUBSCRIPTION_STATUS_"))
+}
+
+// parseSubscriptionStatus converts a stored status name back to its enum value.
+func parseSubscriptionStatus(name string) pb.SubscriptionStatus {
+	return pb.SubscriptionStatus(pb.SubscriptionStatus_value["SUBSCRIPTION_STATUS_"+strings.ToUpper(name)])
+}
+
+// ensureSubscriptionIndexes creates the indexes for finding due subscriptions and for
+// listing those of a user.
+func ensureSubscriptionIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("subscriptions").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "due_at", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+	})
+	return err
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscriptionRunAt(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		interval string
+		count    int32
+		anchor   time.Time
+		periods  int32
+		want     time.Time
+	}{
+		{"anchor", "month", 1, date(2023, time.January, 31), 0, date(2023, time.January, 31)},
+		{"month end clamps", "month", 1, date(2023, time.January, 31), 1, date(2023, time.February, 28)},
+		{"month end clamps in a leap year", "month", 1, date(2024, time.January, 31), 1, date(2024, time.February, 29)},
+		{"anchor day comes back", "month", 1, date(2023, time.January, 31), 2, date(2023, time.March, 31)},
+		{"30-day month", "month", 1, date(2023, time.January, 31), 3, date(2023, time.April, 30)},
+		{"day 29 in February", "month", 1, date(2023, time.January, 29), 1, date(2023, time.February, 28)},
+		{"day within every month", "month", 1, date(2023, time.January, 15), 13, date(2024, time.February, 15)},
+		{"across the year", "month", 3, date(2023, time.November, 30), 1, date(2024, time.February, 29)},
+		{"several months", "month", 2, date(2023, time.August, 31), 3, date(2024, time.February, 29)},
+		{"days", "day", 10, date(2023, time.December, 25), 1, date(2024, time.January, 4)},
+		{"leap day", "day", 1, date(2024, time.February, 28), 1, date(2024, time.February, 29)},
+		{"weeks", "week", 2, date(2023, time.December, 25), 3, date(2024, time.February, 5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &subscriptionModel{Interval: tt.interval, IntervalCount: tt.count, AnchorAt: tt.anchor}
+			if got := sub.runAt(tt.periods); !got.Equal(tt.want) {
+				t.Errorf("runAt(%d) = %s, want %s", tt.periods, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionAdvance(t *testing.T) {
+	anchor := time.Date(2023, time.January, 31, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		after       time.Time
+		cycleDone   bool
+		wantPeriods int32
+		wantCycle   int32
+		wantNext    time.Time
+	}{
+		{
+			name:        "cycle done",
+			after:       anchor,
+			cycleDone:   true,
+			wantPeriods: 1,
+			wantCycle:   1,
+			wantNext:    time.Date(2023, time.February, 28, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:        "missed runs are skipped",
+			after:       time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC),
+			cycleDone:   true,
+			wantPeriods: 4,
+			wantCycle:   1,
+			wantNext:    time.Date(2023, time.May, 31, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:        "resumed without a cycle",
+			after:       time.Date(2023, time.March, 31, 9, 30, 0, 0, time.UTC),
+			wantPeriods: 2,
+			wantNext:    time.Date(2023, time.March, 31, 9, 30, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retryAt := anchor.Add(time.Hour)
+			sub := &subscriptionModel{
+				Interval:       "month",
+				IntervalCount:  1,
+				AnchorAt:       anchor,
+				NextRunAt:      anchor,
+				FailedAttempts: 2,
+				RetryAt:        &retryAt,
+			}
+			sub.advance(tt.after, tt.cycleDone)
+			if sub.Periods != tt.wantPeriods || sub.Cycle != tt.wantCycle || !sub.NextRunAt.Equal(tt.wantNext) {
+				t.Errorf("advance() gave periods %d, cycle %d, next run %s; want %d, %d, %s",
+					sub.Periods, sub.Cycle, sub.NextRunAt, tt.wantPeriods, tt.wantCycle, tt.wantNext)
+			}
+			if sub.FailedAttempts != 0 || sub.RetryAt != nil || !sub.DueAt.Equal(sub.NextRunAt) {
+				t.Errorf("advance() left failures: %d attempts, retry at %v, due at %s",
+					sub.FailedAttempts, sub.RetryAt, sub.DueAt)
+			}
+		})
+	}
+}
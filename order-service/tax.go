@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// TaxCalculator works out the taxes on the items of an order shipped to address.
+// Items are charged at their amount after discounts.
+type TaxCalculator interface {
+	Calculate(ctx context.Context, address addressModel, items []taxableItem) ([]taxLineModel, error)
+}
+
+// taxableItem is what an order charges for one product, after discounts.
+type taxableItem struct {
+	ProductID string
+	Category  string
+	Amount    moneyModel
+}
+
+type addressModel struct {
+	Line1      string `bson:"line1,omitempty"`
+	Line2      string `bson:"line2,omitempty"`
+	City       string `bson:"city,omitempty"`
+	Region     string `bson:"region,omitempty"`
+	PostalCode string `bson:"postal_code,omitempty"`
+	Country    string `bson:"country"`
+}
+
+type taxLineModel struct {
+	Name          string     `bson:"name"`
+	Jurisdiction  string     `bson:"jurisdiction"`
+	Rate          string     `bson:"rate"`
+	TaxableAmount moneyModel `bson:"taxable_amount"`
+	Amount        moneyModel `bson:"amount"`
+	Inclusive     bool       `bson:"inclusive"`
+}
+
+// defaultTaxRules is the rule table used when TAX_RULES_FILE is not set.
+//
+//go:embed tax_rules.json
+var defaultTaxRules []byte
+
+// taxRule is one row of the rule table. Region and Category are optional; a rule
+// without them covers the whole country or every category.
+type taxRule struct {
+	Name      string `json:"name"`
+	Country   string `json:"country"`
+	Region    string `json:"region,omitempty"`
+	Category  string `json:"category,omitempty"`
+	Rate      string `json:"rate"`
+	Inclusive bool   `json:"inclusive,omitempty"`
+
+	rate *big.Rat
+}
+
+// ruleTableCalculator taxes each product by the most specific rule matching the
+// destination and the product's category: a rule naming the region beats one naming
+// the category, which beats one naming neither, and earlier rules win ties. Products
+// no rule matches are not taxed, and neither are those whose rule has a zero rate.
+//
+// Items taxed by the same rule are added up and taxed once, rounding half away from
+// zero. For inclusive rules the tax is the part of the price that is tax,
+// amount * rate / (1 + rate), and the taxable amount is what remains.
+type ruleTableCalculator struct {
+	rules []taxRule
+}
+
+// newRuleTableCalculator parses a rule table of the form {"rules": [...]}.
+func newRuleTableCalculator(data []byte) (*ruleTableCalculator, error) {
+	var table struct {
+		Rules []taxRule `json:"rules"`
+	}
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("invalid tax rule table: %v", err)
+	}
+
+	for i := range table.Rules {
+		rule := &table.Rules[i]
+		rule.Country = strings.ToUpper(rule.Country)
+		rule.Region = strings.ToUpper(rule.Region)
+		if rule.Name == "" || len(rule.Country) != 2 {
+			return nil, fmt.Errorf("tax rule %d needs a name and a two-letter country", i)
+		}
+		rate, ok := new(big.Rat).SetString(rule.Rate)
+		if !ok || rate.Sign() < 0 || rate.Cmp(big.NewRat(1, 1)) >= 0 {
+			return nil, fmt.Errorf("tax rule %d has invalid rate %q, expected a decimal fraction below 1", i, rule.Rate)
+		}
+		rule.rate = rate
+	}
+	return &ruleTableCalculator{rules: table.Rules}, nil
+}
+
+func (c *ruleTableCalculator) Calculate(ctx context.Context, address addressModel, items []taxableItem) ([]taxLineModel, error) {
+	// Taxable amounts per rule, in the order the rules are first used
+	var used []int
+	totals := map[int]moneyModel{}
+	for _, item := range items {
+		i := c.match(address, item.Category)
+		if i < 0 || c.rules[i].rate.Sign() == 0 {
+			continue
+		}
+		if _, ok := totals[i]; !ok {
+			used = append(used, i)
+		}
+		total, err := totals[i].add(item.Amount)
+		if err != nil {
+			return nil, err
+		}
+		totals[i] = total
+	}
+
+	lines := make([]taxLineModel, 0, len(used))
+	for _, i := range used {
+		rule := c.rules[i]
+		taxable := totals[i]
+
+		tax := new(big.Rat).Mul(new(big.Rat).SetInt64(taxable.UnitsMinor), rule.rate)
+		if rule.Inclusive {
+			tax.Quo(tax, new(big.Rat).Add(big.NewRat(1, 1), rule.rate))
+		}
+		amount, err := roundRat(tax)
+		if err != nil {
+			return nil, err
+		}
+		if rule.Inclusive {
+			taxable.UnitsMinor -= amount
+		}
+
+		jurisdiction := rule.Country
+		if rule.Region != "" {
+			jurisdiction += "-" + rule.Region
+		}
+		lines = append(lines, taxLineModel{
+			Name:          rule.Name,
+			Jurisdiction:  jurisdiction,
+			Rate:          rule.Rate,
+			TaxableAmount: taxable,
+			Amount:        moneyModel{UnitsMinor: amount, Currency: taxable.Currency},
+			Inclusive:     rule.Inclusive,
+		})
+	}
+	return lines, nil
+}
+
+// match returns the index of the rule taxing category at address, or -1 if none does.
+func (c *ruleTableCalculator) match(address addressModel, category string) int {
+	best, bestScore := -1, -1
+	for i, rule := range c.rules {
+		if rule.Country != address.Country ||
+			(rule.Region != "" && rule.Region != address.Region) ||
+			(rule.Category != "" && rule.Category != category) {
+			continue
+		}
+		score := 0
+		if rule.Region != "" {
+			score += 2
+		}
+		if rule.Category != "" {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+// taxableItems returns what an order charges for each of its products after
+// discounts, in the order the products first appear.
+func taxableItems(items []orderItemModel, discounts []discountModel, products map[string]*pb.Product) []taxableItem {
+	var taxable []taxableItem
+	index := map[string]int{}
+	for _, item := range items {
+		line := moneyModel{UnitsMinor: item.Price.UnitsMinor * int64(item.Quantity), Currency: item.Price.Currency}
+		if i, ok := index[item.ProductID]; ok {
+			taxable[i].Amount.UnitsMinor += line.UnitsMinor
+			continue
+		}
+		category := ""
+		if product := products[item.ProductID]; product != nil {
+			category = product.Category
+		}
+		index[item.ProductID] = len(taxable)
+		taxable = append(taxable, taxableItem{ProductID: item.ProductID, Category: category, Amount: line})
+	}
+	for _, discount := range discounts {
+		taxable[index[discount.ProductID]].Amount.UnitsMinor -= discount.Amount.UnitsMinor
+	}
+	return taxable
+}
+
+// addressFromProto validates a shipping address and normalizes its country and region
+// codes to upper case.
+func addressFromProto(address *pb.Address) (*addressModel, error) {
+	if address == nil {
+		return nil, nil
+	}
+	model := &addressModel{
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		Region:     strings.ToUpper(strings.TrimSpace(address.Region)),
+		PostalCode: address.PostalCode,
+		Country:    strings.ToUpper(strings.TrimSpace(address.Country)),
+	}
+	if len(model.Country) != 2 {
+		return nil, status.Error(codes.InvalidArgument, "shipping_address.country must be a two-letter ISO 3166-1 code")
+	}
+	return model, nil
+}
+
+// toProto converts a stored address into its protobuf representation.
+func (a *addressModel) toProto() *pb.Address {
+	if a == nil {
+		return nil
+	}
+	return &pb.Address{
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+func taxLinesToProto(lines []taxLineModel) []*pb.TaxLine {
+	taxLines := make([]*pb.TaxLine, 0, len(lines))
+	for _, line := range lines {
+		taxLines = append(taxLines, &pb.TaxLine{
+			Name:          line.Name,
+			Jurisdiction:  line.Jurisdiction,
+			Rate:          line.Rate,
+			TaxableAmount: line.TaxableAmount.toProto(),
+			Amount:        line.Amount.toProto(),
+			Inclusive:     line.Inclusive,
+		})
+	}
+	return taxLines
+}
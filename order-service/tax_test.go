@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRuleTableMatch(t *testing.T) {
+	calculator, err := newRuleTableCalculator([]byte(`{"rules": [
+		{"name": "US books", "country": "US", "category": "books", "rate": "0.01"},
+		{"name": "California", "country": "US", "region": "ca", "rate": "0.0725"},
+		{"name": "US", "country": "US", "rate": "0.05"},
+		{"name": "California books", "country": "US", "region": "CA", "category": "books", "rate": "0.02"},
+		{"name": "California again", "country": "US", "region": "CA", "rate": "0.09"},
+		{"name": "Oregon books", "country": "US", "region": "OR", "category": "books", "rate": "0"},
+		{"name": "VAT", "country": "gb", "rate": "0.20", "inclusive": true}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		address  addressModel
+		category string
+		want     string
+	}{
+		{"region beats category", addressModel{Country: "US", Region: "CA"}, "toys", "California"},
+		{"region and category beat region", addressModel{Country: "US", Region: "CA"}, "books", "California books"},
+		{"category beats country", addressModel{Country: "US", Region: "TX"}, "books", "US books"},
+		{"country only", addressModel{Country: "US", Region: "TX"}, "toys", "US"},
+		{"region of another rule", addressModel{Country: "US"}, "toys", "US"},
+		{"zero rate still matches", addressModel{Country: "US", Region: "OR"}, "books", "Oregon books"},
+		{"codes are upper case", addressModel{Country: "GB"}, "", "VAT"},
+		{"no rule", addressModel{Country: "DE"}, "books", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if i := calculator.match(tt.address, tt.category); i >= 0 {
+				got = calculator.rules[i].Name
+			}
+			if got != tt.want {
+				t.Errorf("match(%+v, %q) = %q, want %q", tt.address, tt.category, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleTableCalculate(t *testing.T) {
+	calculator, err := newRuleTableCalculator([]byte(`{"rules": [
+		{"name": "California", "country": "US", "region": "CA", "rate": "0.0725"},
+		{"name": "Tenth", "country": "US", "region": "TX", "rate": "0.1"},
+		{"name": "Texas clothing", "country": "US", "region": "TX", "category": "clothing", "rate": "0"},
+		{"name": "VAT", "country": "GB", "rate": "0.20", "inclusive": true},
+		{"name": "VAT (reduced)", "country": "GB", "category": "books", "rate": "0.05", "inclusive": true}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	usd := func(units int64) moneyModel { return moneyModel{UnitsMinor: units, Currency: "USD"} }
+	gbp := func(units int64) moneyModel { return moneyModel{UnitsMinor: units, Currency: "GBP"} }
+
+	tests := []struct {
+		name    string
+		address addressModel
+		items   []taxableItem
+		want    []taxLineModel
+	}{
+		{
+			name:    "exclusive rounds half up",
+			address: addressModel{Country: "US", Region: "CA"},
+			items:   []taxableItem{{ProductID: "p1", Amount: usd(1000)}},
+			want: []taxLineModel{{Name: "California", Jurisdiction: "US-CA", Rate: "0.0725",
+				TaxableAmount: usd(1000), Amount: usd(73)}},
+		},
+		{
+			name:    "items of a rule are taxed together",
+			address: addressModel{Country: "US", Region: "TX"},
+			items:   []taxableItem{{ProductID: "p1", Amount: usd(5)}, {ProductID: "p2", Amount: usd(5)}},
+			want: []taxLineModel{{Name: "Tenth", Jurisdiction: "US-TX", Rate: "0.1",
+				TaxableAmount: usd(10), Amount: usd(1)}},
+		},
+		{
+			name:    "zero rate is left out",
+			address: addressModel{Country: "US", Region: "TX"},
+			items:   []taxableItem{{ProductID: "p1", Category: "clothing", Amount: usd(5000)}},
+			want:    []taxLineModel{},
+		},
+		{
+			name:    "inclusive is backed out of the price",
+			address: addressModel{Country: "GB"},
+			items:   []taxableItem{{ProductID: "p1", Amount: gbp(1200)}},
+			want: []taxLineModel{{Name: "VAT", Jurisdiction: "GB", Rate: "0.20",
+				TaxableAmount: gbp(1000), Amount: gbp(200), Inclusive: true}},
+		},
+		{
+			name:    "inclusive rounds the tax, taxable amount takes the rest",
+			address: addressModel{Country: "GB"},
+			items:   []taxableItem{{ProductID: "p1", Amount: gbp(999)}},
+			want: []taxLineModel{{Name: "VAT", Jurisdiction: "GB", Rate: "0.20",
+				TaxableAmount: gbp(832), Amount: gbp(167), Inclusive: true}},
+		},
+		{
+			name:    "lines in the order rules are first used",
+			address: addressModel{Country: "GB"},
+			items: []taxableItem{
+				{ProductID: "p1", Category: "books", Amount: gbp(2100)},
+				{ProductID: "p2", Amount: gbp(600)},
+				{ProductID: "p3", Category: "books", Amount: gbp(1050)},
+			},
+			want: []taxLineModel{
+				{Name: "VAT (reduced)", Jurisdiction: "GB", Rate: "0.05",
+					TaxableAmount: gbp(3000), Amount: gbp(150), Inclusive: true},
+				{Name: "VAT", Jurisdiction: "GB", Rate: "0.20",
+					TaxableAmount: gbp(500), Amount: gbp(100), Inclusive: true},
+			},
+		},
+		{
+			name:    "negative amounts round away from zero",
+			address: addressModel{Country: "US", Region: "CA"},
+			items:   []taxableItem{{ProductID: "p1", Amount: usd(-1000)}},
+			want: []taxLineModel{{Name: "California", Jurisdiction: "US-CA", Rate: "0.0725",
+				TaxableAmount: usd(-1000), Amount: usd(-73)}},
+		},
+		{
+			name:    "no rule for the destination",
+			address: addressModel{Country: "FR"},
+			items:   []taxableItem{{ProductID: "p1", Amount: usd(1000)}},
+			want:    []taxLineModel{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := calculator.Calculate(context.Background(), tt.address, tt.items)
+			if err != nil {
+				t.Fatalf("Calculate() returned error %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Calculate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleTableCalculateCurrencyMismatch(t *testing.T) {
+	calculator, err := newRuleTableCalculator([]byte(`{"rules": [{"name": "VAT", "country": "GB", "rate": "0.2"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = calculator.Calculate(context.Background(), addressModel{Country: "GB"}, []taxableItem{
+		{ProductID: "p1", Amount: moneyModel{UnitsMinor: 100, Currency: "GBP"}},
+		{ProductID: "p2", Amount: moneyModel{UnitsMinor: 100, Currency: "EUR"}},
+	})
+	if err != errCurrencyMismatch {
+		t.Errorf("Calculate() error = %v, want %v", err, errCurrencyMismatch)
+	}
+}
+
+func TestNewRuleTableCalculatorRejectsInvalidRules(t *testing.T) {
+	tests := []string{
+		`{"rules": [{"name": "VAT", "country": "GB", "rate": "1"}]}`,
+		`{"rules": [{"name": "VAT", "country": "GB", "rate": "-0.1"}]}`,
+		`{"rules": [{"name": "VAT", "country": "GB", "rate": "twenty"}]}`,
+		`{"rules": [{"name": "VAT", "country": "GBR", "rate": "0.2"}]}`,
+		`{"rules": [{"country": "GB", "rate": "0.2"}]}`,
+		`{"rules": {}}`,
+	}
+	for _, table := range tests {
+		if _, err := newRuleTableCalculator([]byte(table)); err == nil {
+			t.Errorf("newRuleTableCalculator(%s) accepted an invalid table", table)
+		}
+	}
+}
+
+func TestDefaultTaxRulesParse(t *testing.T) {
+	if _, err := newRuleTableCalculator(defaultTaxRules); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,539 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// Stored payment statuses. Refunds do not change the stored status of a captured
+// payment; partially_refunded and refunded are derived from the refunded amount.
+const (
+	paymentPending    = "pending"
+	paymentAuthorized = "authorized"
+	paymentCaptured   = "captured"
+	paymentDeclined   = "declined"
+	paymentFailed     = "failed"
+	paymentVoided     = "voided"
+)
+
+const (
+	refundPending   = "pending"
+	refundSucceeded = "succeeded"
+	refundFailed    = "failed"
+)
+
+type server struct {
+	pb.UnimplementedPaymentServiceServer
+	db          *mongo.Database
+	orderClient pb.OrderServiceClient
+	provider    PaymentProvider
+}
+
+type refundModel struct {
+	ID                primitive.ObjectID `bson:"_id"`
+	Amount            moneyModel         `bson:"amount"`
+	Reason            string             `bson:"reason,omitempty"`
+	Status            string             `bson:"status"`
+	ProviderReference string             `bson:"provider_reference,omitempty"`
+	FailureReason     string             `bson:"failure_reason,omitempty"`
+	CreatedAt         time.Time          `bson:"created_at"`
+}
+
+type paymentModel struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	OrderID   string             `bson:"order_id"`
+	Status    string             `bson:"status"`
+	Active    bool               `bson:"active"` // At most one active payment per order
+	Amount    moneyModel         `bson:"amount"`
+	Provider  string             `bson:"provider"`
+	CreatedAt time.Time          `bson:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+
+	// Kept while the payment is pending, so an interrupted authorization can be retried
+	PaymentMethod string `bson:"payment_method,omitempty"`
+
+	ProviderReference string        `bson:"provider_reference,omitempty"`
+	CaptureReference  string        `bson:"capture_reference,omitempty"`
+	CapturedAmount    moneyModel    `bson:"captured_amount"`
+	RefundedAmount    moneyModel    `bson:"refunded_amount"`
+	Refunds           []refundModel `bson:"refunds"`
+	FailureReason     string        `bson:"failure_reason,omitempty"`
+	OrderPaidAt       *time.Time    `bson:"order_paid_at,omitempty"`
+	OrderUpdateError  string        `bson:"order_update_error,omitempty"`
+}
+
+func main() {
+	// MongoDB connection
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	mongoURI := os.Getenv("MONGO_URI")
+	if mongoURI == "" {
+		mongoURI = "mongodb://localhost:27017"
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer client.Disconnect(ctx)
+
+	// Convert amounts stored as floats by earlier versions to Money
+	migrated, err := migrateLegacyAmounts(context.Background(), client.Database("order_management"))
+	if err != nil {
+		log.Fatalf("Failed to migrate payment amounts: %v", err)
+	}
+	if migrated > 0 {
+		log.Printf("Migrated amounts of %d payments to Money", migrated)
+	}
+
+	// Create unique index so an order has at most one active payment
+	_, err = client.Database("order_management").Collection("payments").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.M{"order_id": 1},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"active": true}),
+	})
+	if err != nil {
+		log.Fatalf("Failed to create index: %v", err)
+	}
+
+	// Set up the payment provider
+	var provider PaymentProvider
+	switch os.Getenv("PAYMENT_PROVIDER") {
+	case "", "fake":
+		provider = newFakeProvider()
+	default:
+		log.Fatalf("Unknown PAYMENT_PROVIDER %q, expected fake", os.Getenv("PAYMENT_PROVIDER"))
+	}
+
+	// Connect to Order Service
+	orderServiceURL := os.Getenv("ORDER_SERVICE_URL")
+	if orderServiceURL == "" {
+		orderServiceURL = "localhost:50051"
+	}
+
+	orderConn, err := grpc.Dial(orderServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to Order service: %v", err)
+	}
+	defer orderConn.Close()
+
+	// Initialize gRPC server
+	lis, err := net.Listen("tcp", ":50054")
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	srv := &server{
+		db:          client.Database("order_management"),
+		orderClient: pb.NewOrderServiceClient(orderConn),
+		provider:    provider,
+	}
+
+	// Resolve authorizations that were interrupted, and retry moving orders to paid
+	// when that failed right after a capture
+	go srv.watchPayments(context.Background())
+
+	s := grpc.NewServer()
+	pb.RegisterPaymentServiceServer(s, srv)
+
+	log.Printf("Payment service listening on :50054")
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+func (s *server) AuthorizePayment(ctx context.Context, req *pb.AuthorizePaymentRequest) (*pb.Payment, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	if req.PaymentMethod == "" {
+		return nil, status.Error(codes.InvalidArgument, "payment_method is required")
+	}
+
+	order, err := s.getOrder(ctx, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != pb.OrderStatus_ORDER_STATUS_CONFIRMED {
+		return nil, status.Errorf(codes.FailedPrecondition, "order in status %s cannot be paid", orderStatusName(order.Status))
+	}
+	if order.TotalAmount == nil {
+		return nil, status.Error(codes.FailedPrecondition, "order has no total amount")
+	}
+
+	// Captured and refunded amounts start at zero in the currency of the order
+	amount := moneyFromProto(order.TotalAmount)
+	none := moneyModel{Currency: amount.Currency}
+
+	now := time.Now().UTC()
+	payment := paymentModel{
+		ID:             primitive.NewObjectID(),
+		OrderID:        order.Id,
+		Status:         paymentPending,
+		Active:         true,
+		Amount:         amount,
+		CapturedAmount: none,
+		RefundedAmount: none,
+		Provider:       s.provider.Name(),
+		PaymentMethod:  req.PaymentMethod,
+		Refunds:        []refundModel{},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if _, err := s.db.Collection("payments").InsertOne(ctx, payment); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, status.Error(codes.AlreadyExists, "order already has an active payment")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create payment: %v", err)
+	}
+
+	// The payment id is the idempotency key, so the provider never authorizes it twice
+	reference, authErr := s.provider.Authorize(ctx, payment.ID.Hex(), payment.Amount, req.PaymentMethod)
+	if err := s.recordAuthorization(ctx, &payment, reference, authErr); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update payment: %v", err)
+	}
+
+	// A decline is a valid answer; only a provider failure is reported as an error
+	if payment.Status == paymentFailed {
+		return nil, status.Errorf(codes.Unavailable, "payment provider failed: %v", authErr)
+	}
+	return payment.toProto(), nil
+}
+
+// recordAuthorization stores the outcome of authorizing a pending payment: authorized
+// with reference if authErr is nil, and declined or failed otherwise. Only a pending
+// payment is updated.
+func (s *server) recordAuthorization(ctx context.Context, payment *paymentModel, reference string, authErr error) error {
+	update := bson.M{"updated_at": time.Now().UTC()}
+	switch {
+	case authErr == nil:
+		payment.Status, payment.ProviderReference = paymentAuthorized, reference
+		update["provider_reference"] = reference
+	case isDeclined(authErr):
+		payment.Status, payment.Active, payment.FailureReason = paymentDeclined, false, authErr.Error()
+	default:
+		payment.Status, payment.Active, payment.FailureReason = paymentFailed, false, authErr.Error()
+	}
+	update["status"], update["active"] = payment.Status, payment.Active
+	if payment.FailureReason != "" {
+		update["failure_reason"] = payment.FailureReason
+	}
+	payment.PaymentMethod = ""
+
+	_, err := s.db.Collection("payments").UpdateOne(ctx,
+		bson.M{"_id": payment.ID, "status": paymentPending},
+		bson.M{"$set": update, "$unset": bson.M{"payment_method": ""}},
+	)
+	return err
+}
+
+func (s *server) CapturePayment(ctx context.Context, req *pb.CapturePaymentRequest) (*pb.Payment, error) {
+	payment, err := s.findPayment(ctx, req.PaymentId)
+	if err != nil {
+		return nil, err
+	}
+
+	switch payment.Status {
+	case paymentAuthorized:
+	case paymentCaptured:
+		// Already captured; make sure the order caught up
+		if payment.OrderPaidAt == nil && payment.OrderUpdateError == "" {
+			if err := s.markOrderPaid(ctx, payment); err != nil {
+				log.Printf("Failed to mark order %s paid for payment %s: %v", payment.OrderID, payment.ID.Hex(), err)
+			}
+		}
+		return payment.toProto(), nil
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "payment is %s, only authorized payments can be captured", payment.Status)
+	}
+
+	amount := payment.Amount
+	if req.Amount != nil {
+		amount = moneyFromProto(req.Amount)
+		if amount.Currency != payment.Amount.Currency {
+			return nil, status.Errorf(codes.InvalidArgument, "capture currency must be %s", payment.Amount.Currency)
+		}
+		if amount.UnitsMinor <= 0 || amount.UnitsMinor > payment.Amount.UnitsMinor {
+			return nil, status.Error(codes.InvalidArgument, "capture amount must be positive and no more than the authorized amount")
+		}
+	}
+
+	// Don't take money for an order that can no longer be paid
+	order, err := s.getOrder(ctx, payment.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != pb.OrderStatus_ORDER_STATUS_CONFIRMED {
+		return nil, status.Errorf(codes.FailedPrecondition, "order in status %s cannot be paid", orderStatusName(order.Status))
+	}
+	if total := moneyFromProto(order.TotalAmount); total != payment.Amount {
+		// The items of the order changed after the payment was authorized
+		return nil, s.voidPayment(ctx, payment, total)
+	}
+
+	reference, err := s.provider.Capture(ctx, payment.ID.Hex(), payment.ProviderReference, amount)
+	if err != nil {
+		if isDeclined(err) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Unavailable, "payment provider failed: %v", err)
+	}
+
+	var captured paymentModel
+	err = s.db.Collection("payments").FindOneAndUpdate(ctx,
+		bson.M{"_id": payment.ID, "status": paymentAuthorized},
+		bson.M{"$set": bson.M{
+			"status":            paymentCaptured,
+			"captured_amount":   amount,
+			"capture_reference": reference,
+			"updated_at":        time.Now().UTC(),
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&captured)
+	if err == mongo.ErrNoDocuments {
+		// A concurrent capture got there first; the provider call was idempotent
+		current, err := s.findPayment(ctx, req.PaymentId)
+		if err != nil {
+			return nil, err
+		}
+		return current.toProto(), nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update payment: %v", err)
+	}
+
+	// The capture stands even if the order can't be updated right now;
+	// watchPayments retries
+	if err := s.markOrderPaid(ctx, &captured); err != nil {
+		log.Printf("Failed to mark order %s paid for payment %s: %v", captured.OrderID, captured.ID.Hex(), err)
+	}
+
+	return captured.toProto(), nil
+}
+
+// voidPayment releases the authorization of a payment whose order total has changed
+// to total since, so the order can be authorized again for its new total. It returns
+// the error to report for the capture.
+func (s *server) voidPayment(ctx context.Context, payment *paymentModel, total moneyModel) error {
+	if err := s.provider.Void(ctx, payment.ID.Hex(), payment.ProviderReference); err != nil {
+		// The payment stays authorized, so capturing again retries the void
+		return status.Errorf(codes.Unavailable, "payment provider failed: %v", err)
+	}
+
+	reason := fmt.Sprintf("order total changed from %d to %d minor units of %s after authorization",
+		payment.Amount.UnitsMinor, total.UnitsMinor, total.Currency)
+	_, err := s.db.Collection("payments").UpdateOne(ctx,
+		bson.M{"_id": payment.ID, "status": paymentAuthorized},
+		bson.M{"$set": bson.M{
+			"status":         paymentVoided,
+			"active":         false,
+			"failure_reason": reason,
+			"updated_at":     time.Now().UTC(),
+		}},
+	)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to update payment: %v", err)
+	}
+	return status.Errorf(codes.FailedPrecondition, "%s; the authorization was voided, authorize the order again", reason)
+}
+
+func (s *server) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.Payment, error) {
+	payment, err := s.findPayment(ctx, req.PaymentId)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status != paymentCaptured {
+		return nil, status.Errorf(codes.FailedPrecondition, "payment is %s, only captured payments can be refunded", payment.Status)
+	}
+
+	remaining := payment.CapturedAmount
+	remaining.UnitsMinor -= payment.RefundedAmount.UnitsMinor
+
+	amount := remaining
+	if req.Amount != nil {
+		amount = moneyFromProto(req.Amount)
+		if amount.Currency != remaining.Currency {
+			return nil, status.Errorf(codes.InvalidArgument, "refund currency must be %s", remaining.Currency)
+		}
+		if amount.UnitsMinor <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "refund amount must be positive")
+		}
+	}
+	if amount.UnitsMinor == 0 || amount.UnitsMinor > remaining.UnitsMinor {
+		return nil, status.Errorf(codes.FailedPrecondition, "only %d minor units of %s can still be refunded",
+			remaining.UnitsMinor, remaining.Currency)
+	}
+
+	refund := refundModel{
+		ID:        primitive.NewObjectID(),
+		Amount:    amount,
+		Reason:    req.Reason,
+		Status:    refundPending,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	// Reserve the amount before calling the provider, so concurrent refunds can't
+	// together exceed what was captured
+	result, err := s.db.Collection("payments").UpdateOne(ctx,
+		bson.M{"_id": payment.ID, "refunded_amount.units_minor": payment.RefundedAmount.UnitsMinor},
+		bson.M{
+			"$inc":  bson.M{"refunded_amount.units_minor": amount.UnitsMinor},
+			"$set":  bson.M{"updated_at": refund.CreatedAt},
+			"$push": bson.M{"refunds": refund},
+		},
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to record refund: %v", err)
+	}
+	if result.MatchedCount == 0 {
+		return nil, status.Error(codes.Aborted, "payment was refunded concurrently, retry the refund")
+	}
+
+	// The refund id is the idempotency key for the provider
+	reference, refundErr := s.provider.Refund(ctx, refund.ID.Hex(), payment.CaptureReference, amount)
+
+	filter := bson.M{"_id": payment.ID, "refunds._id": refund.ID}
+	var update bson.M
+	if refundErr == nil {
+		update = bson.M{"$set": bson.M{
+			"refunds.$.status":             refundSucceeded,
+			"refunds.$.provider_reference": reference,
+			"updated_at":                   time.Now().UTC(),
+		}}
+	} else {
+		// Give the reserved amount back
+		update = bson.M{
+			"$set": bson.M{
+				"refunds.$.status":         refundFailed,
+				"refunds.$.failure_reason": refundErr.Error(),
+				"updated_at":               time.Now().UTC(),
+			},
+			"$inc": bson.M{"refunded_amount.units_minor": -amount.UnitsMinor},
+		}
+	}
+
+	var updated paymentModel
+	err = s.db.Collection("payments").FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update refund %s: %v", refund.ID.Hex(), err)
+	}
+
+	if refundErr != nil {
+		if isDeclined(refundErr) {
+			return nil, status.Error(codes.FailedPrecondition, refundErr.Error())
+		}
+		return nil, status.Errorf(codes.Unavailable, "payment provider failed: %v", refundErr)
+	}
+	return updated.toProto(), nil
+}
+
+func (s *server) GetPayment(ctx context.Context, req *pb.GetPaymentRequest) (*pb.Payment, error) {
+	payment, err := s.findPayment(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return payment.toProto(), nil
+}
+
+func (s *server) findPayment(ctx context.Context, id string) (*paymentModel, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "payment id is required")
+	}
+
+	// Convert string ID to ObjectID
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid payment id")
+	}
+
+	var payment paymentModel
+	err = s.db.Collection("payments").FindOne(ctx, bson.M{"_id": objectID}).Decode(&payment)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Error(codes.NotFound, "payment not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get payment: %v", err)
+	}
+	return &payment, nil
+}
+
+// paymentStatus returns the status reported to clients, deriving the refund states
+// of a captured payment from the amount refunded so far.
+func (p *paymentModel) paymentStatus() pb.PaymentStatus {
+	switch p.Status {
+	case paymentPending:
+		return pb.PaymentStatus_PAYMENT_STATUS_PENDING
+	case paymentAuthorized:
+		return pb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED
+	case paymentCaptured:
+		refunded := p.RefundedAmount.UnitsMinor
+		switch {
+		case refunded > 0 && refunded >= p.CapturedAmount.UnitsMinor:
+			return pb.PaymentStatus_PAYMENT_STATUS_REFUNDED
+		case refunded > 0:
+			return pb.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED
+		}
+		return pb.PaymentStatus_PAYMENT_STATUS_CAPTURED
+	case paymentDeclined:
+		return pb.PaymentStatus_PAYMENT_STATUS_DECLINED
+	case paymentFailed:
+		return pb.PaymentStatus_PAYMENT_STATUS_FAILED
+	case paymentVoided:
+		return pb.PaymentStatus_PAYMENT_STATUS_VOIDED
+	}
+	return pb.PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+// toProto converts a stored payment into its protobuf representation.
+func (p *paymentModel) toProto() *pb.Payment {
+	refunds := make([]*pb.Refund, 0, len(p.Refunds))
+	for _, refund := range p.Refunds {
+		refunds = append(refunds, &pb.Refund{
+			Id:                refund.ID.Hex(),
+			Amount:            refund.Amount.toProto(),
+			Reason:            refund.Reason,
+			ProviderReference: refund.ProviderReference,
+			CreatedAt:         refund.CreatedAt.Format(time.RFC3339),
+			Status:            refund.Status,
+			FailureReason:     refund.FailureReason,
+		})
+	}
+
+	payment := &pb.Payment{
+		Id:                p.ID.Hex(),
+		OrderId:           p.OrderID,
+		Status:            p.paymentStatus(),
+		Amount:            p.Amount.toProto(),
+		CapturedAmount:    p.CapturedAmount.toProto(),
+		RefundedAmount:    p.RefundedAmount.toProto(),
+		Provider:          p.Provider,
+		ProviderReference: p.ProviderReference,
+		FailureReason:     p.FailureReason,
+		Refunds:           refunds,
+		CreatedAt:         p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         p.UpdatedAt.Format(time.RFC3339),
+	}
+	if p.OrderPaidAt != nil {
+		payment.OrderPaidAt = p.OrderPaidAt.Format(time.RFC3339)
+	}
+	return payment
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	pb "github.com/order-management/proto"
+)
+
+// Amounts are Money: an int64 count of the minor unit of a currency, e.g. cents for
+// USD, and all arithmetic is done on these integers.
+
+type moneyModel struct {
+	UnitsMinor int64  `bson:"units_minor"`
+	Currency   string `bson:"currency"`
+}
+
+func moneyFromProto(m *pb.Money) moneyModel {
+	if m == nil {
+		return moneyModel{}
+	}
+	return moneyModel{UnitsMinor: m.UnitsMinor, Currency: m.Currency}
+}
+
+func (m moneyModel) toProto() *pb.Money {
+	return &pb.Money{UnitsMinor: m.UnitsMinor, Currency: m.Currency}
+}
+
+// legacyPayment holds the amounts of a payment stored before they became Money, when
+// they were floats in major units of the payment's currency.
+type legacyPayment struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	Currency       string             `bson:"currency"`
+	Amount         float64            `bson:"amount"`
+	CapturedAmount float64            `bson:"captured_amount"`
+	RefundedAmount float64            `bson:"refunded_amount"`
+	Refunds        []struct {
+		Amount float64 `bson:"amount"`
+		Status string  `bson:"status"`
+	} `bson:"refunds"`
+}
+
+// migrateLegacyAmounts converts the float amounts of payments to Money. The refunded
+// amount is recomputed from the refunds that did not fail. Each update is conditional
+// on the old amount, so the migration can be re-run, and run by several replicas at
+// once. It returns the number of payments converted.
+func migrateLegacyAmounts(ctx context.Context, db *mongo.Database) (int, error) {
+	coll := db.Collection("payments")
+	cursor, err := coll.Find(ctx, bson.M{"amount": bson.M{"$type": "number"}})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	migrated := 0
+	for cursor.Next(ctx) {
+		var legacy legacyPayment
+		if err := cursor.Decode(&legacy); err != nil {
+			return migrated, err
+		}
+
+		convert := func(amount float64) (moneyModel, error) {
+			units, err := legacyMinorUnits(amount)
+			return moneyModel{UnitsMinor: units, Currency: legacy.Currency}, err
+		}
+
+		set := bson.M{}
+		var err error
+		if set["amount"], err = convert(legacy.Amount); err != nil {
+			return migrated, err
+		}
+		if set["captured_amount"], err = convert(legacy.CapturedAmount); err != nil {
+			return migrated, err
+		}
+
+		refunded := moneyModel{Currency: legacy.Currency}
+		for i, refund := range legacy.Refunds {
+			amount, err := convert(refund.Amount)
+			if err != nil {
+				return migrated, err
+			}
+			set[fmt.Sprintf("refunds.%d.amount", i)] = amount
+			if refund.Status != refundFailed {
+				refunded.UnitsMinor += amount.UnitsMinor
+			}
+		}
+		set["refunded_amount"] = refunded
+
+		_, err = coll.UpdateOne(ctx,
+			bson.M{"_id": legacy.ID, "amount": legacy.Amount},
+			bson.M{"$set": set, "$unset": bson.M{"currency": ""}},
+		)
+		if err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+	return migrated, cursor.Err()
+}
+
+// legacyMinorUnits converts a float amount in major units to minor units, assuming two
+// decimal places, and rounds half away from zero. The float is read as the shortest
+// decimal that represents it, so 0.285 is 29 cents rather than the 28 its binary value
+// 0.28499999... would round to.
+func legacyMinorUnits(amount float64) (int64, error) {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	if !ok {
+		return 0, fmt.Errorf("invalid amount %v", amount)
+	}
+	r.Mul(r, big.NewRat(100, 1))
+
+	half := big.NewRat(1, 2)
+	if r.Sign() < 0 {
+		half.Neg(half)
+	}
+	r.Add(r, half)
+
+	// Quo truncates toward zero
+	q := new(big.Int).Quo(r.Num(), r.Denom())
+	if !q.IsInt64() {
+		return 0, fmt.Errorf("amount %v is out of range", amount)
+	}
+	return q.Int64(), nil
+}
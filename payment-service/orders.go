@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+const orderSyncInterval = 30 * time.Second
+
+// pendingPaymentTimeout is how long a payment may stay pending before its authorization
+// is taken to have been interrupted.
+const pendingPaymentTimeout = 5 * time.Minute
+
+// getOrder fetches an order from the order service, forwarding the caller's token.
+func (s *server) getOrder(ctx context.Context, id string) (*pb.Order, error) {
+	order, err := s.orderClient.GetOrder(forwardAuth(ctx), &pb.GetOrderRequest{Id: id})
+	if err != nil {
+		if code := status.Code(err); code == codes.NotFound || code == codes.InvalidArgument {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Unavailable, "failed to look up order %s: %v", id, err)
+	}
+	return order, nil
+}
+
+// markOrderPaid moves the order of a captured payment to paid and records when that
+// happened. An order that has already moved past paid counts as paid. An order that
+// can no longer be paid, e.g. because it was cancelled, is recorded as an error and
+// not retried.
+func (s *server) markOrderPaid(ctx context.Context, payment *paymentModel) error {
+	order, err := s.getOrder(ctx, payment.OrderID)
+	if err != nil {
+		return err
+	}
+
+	switch order.Status {
+	case pb.OrderStatus_ORDER_STATUS_CONFIRMED:
+		_, err := s.orderClient.UpdateOrder(forwardAuth(ctx), &pb.UpdateOrderRequest{
+			Id:     payment.OrderID,
+			Status: pb.OrderStatus_ORDER_STATUS_PAID,
+			Reason: fmt.Sprintf("payment %s captured", payment.ID.Hex()),
+		})
+		if err != nil {
+			return err
+		}
+	case pb.OrderStatus_ORDER_STATUS_PAID,
+		pb.OrderStatus_ORDER_STATUS_PARTIALLY_SHIPPED,
+		pb.OrderStatus_ORDER_STATUS_SHIPPED,
+		pb.OrderStatus_ORDER_STATUS_DELIVERED,
+		pb.OrderStatus_ORDER_STATUS_REFUNDED:
+	default:
+		msg := fmt.Sprintf("order in status %s cannot be paid", orderStatusName(order.Status))
+		_, err := s.db.Collection("payments").UpdateOne(ctx,
+			bson.M{"_id": payment.ID},
+			bson.M{"$set": bson.M{"order_update_error": msg, "updated_at": time.Now().UTC()}},
+		)
+		if err != nil {
+			return err
+		}
+		payment.OrderUpdateError = msg
+		return fmt.Errorf("%s", msg)
+	}
+
+	now := time.Now().UTC()
+	_, err = s.db.Collection("payments").UpdateOne(ctx,
+		bson.M{"_id": payment.ID},
+		bson.M{"$set": bson.M{"order_paid_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+	payment.OrderPaidAt = &now
+	return nil
+}
+
+// watchPayments periodically resolves stale pending payments and retries moving orders
+// to paid for captured payments whose order update failed, until ctx is done.
+func (s *server) watchPayments(ctx context.Context) {
+	ticker := time.NewTicker(orderSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s.resolvePendingPayments(ctx)
+		s.syncCapturedPayments(ctx)
+	}
+}
+
+// resolvePendingPayments finishes the authorization of payments left pending for
+// longer than pendingPaymentTimeout, because the service stopped or failed to store
+// the outcome while authorizing them. Authorizing again with the payment id as the
+// idempotency key returns the outcome of the first attempt, or makes the attempt if
+// the provider never got it. While the provider can't be reached the payment stays
+// pending and is tried again. Payments pending since before their payment method was
+// kept cannot be retried and are marked failed; an authorization the provider did
+// make for one of them lapses there.
+func (s *server) resolvePendingPayments(ctx context.Context) {
+	cursor, err := s.db.Collection("payments").Find(ctx,
+		bson.M{
+			"status":     paymentPending,
+			"updated_at": bson.M{"$lt": time.Now().UTC().Add(-pendingPaymentTimeout)},
+		},
+		options.Find().SetLimit(100),
+	)
+	if err != nil {
+		log.Printf("Failed to look up pending payments: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var payment paymentModel
+		if err := cursor.Decode(&payment); err != nil {
+			log.Printf("Failed to decode payment: %v", err)
+			return
+		}
+
+		var reference string
+		authErr := fmt.Errorf("authorization was interrupted and cannot be retried")
+		if payment.PaymentMethod != "" {
+			reference, authErr = s.provider.Authorize(ctx, payment.ID.Hex(), payment.Amount, payment.PaymentMethod)
+			if authErr != nil && !isDeclined(authErr) {
+				log.Printf("Failed to resolve pending payment %s, retrying later: %v", payment.ID.Hex(), authErr)
+				continue
+			}
+		}
+		if err := s.recordAuthorization(ctx, &payment, reference, authErr); err != nil {
+			log.Printf("Failed to update pending payment %s: %v", payment.ID.Hex(), err)
+			continue
+		}
+		log.Printf("Resolved pending payment %s of order %s as %s", payment.ID.Hex(), payment.OrderID, payment.Status)
+	}
+}
+
+// syncCapturedPayments retries moving orders to paid for captured payments whose order
+// update failed.
+func (s *server) syncCapturedPayments(ctx context.Context) {
+	// Leave recent captures to the request that made them
+	cursor, err := s.db.Collection("payments").Find(ctx,
+		bson.M{
+			"status":             paymentCaptured,
+			"order_paid_at":      bson.M{"$exists": false},
+			"order_update_error": bson.M{"$exists": false},
+			"updated_at":         bson.M{"$lt": time.Now().UTC().Add(-orderSyncInterval)},
+		},
+		options.Find().SetLimit(100),
+	)
+	if err != nil {
+		log.Printf("Failed to look up captured payments: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var payment paymentModel
+		if err := cursor.Decode(&payment); err != nil {
+			log.Printf("Failed to decode payment: %v", err)
+			return
+		}
+		if err := s.markOrderPaid(ctx, &payment); err != nil {
+			log.Printf("Failed to mark order %s paid for payment %s: %v", payment.OrderID, payment.ID.Hex(), err)
+		}
+	}
+}
+
+// forwardAuth passes the caller's token on to the order service, which records the
+// user it identifies as the actor of the status change.
+func forwardAuth(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) > 0 && values[0] != "" {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", values[0])
+	}
+	return ctx
+}
+
+// orderStatusName returns the lowercase name of an order status, e.g. "confirmed".
+func orderStatusName(st pb.OrderStatus) string {
+	return strings.ToLower(strings.TrimPrefix(st.String(), "ORDER_STATUS_"))
+}
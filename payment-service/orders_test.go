@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// fakeOrderClient serves a single order and records the status updates sent for it.
+type fakeOrderClient struct {
+	pb.OrderServiceClient
+	order   *pb.Order
+	updates []pb.OrderStatus
+}
+
+func (c *fakeOrderClient) GetOrder(ctx context.Context, req *pb.GetOrderRequest, opts ...grpc.CallOption) (*pb.Order, error) {
+	if c.order == nil || req.Id != c.order.Id {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return c.order, nil
+}
+
+func (c *fakeOrderClient) UpdateOrder(ctx context.Context, req *pb.UpdateOrderRequest, opts ...grpc.CallOption) (*pb.Order, error) {
+	c.updates = append(c.updates, req.Status)
+	c.order.Status = req.Status
+	return c.order, nil
+}
+
+// recordingProvider is the fake provider, recording its calls. before, if set, runs at
+// the start of every call.
+type recordingProvider struct {
+	fakeProvider
+	calls     []string
+	voidErr   error
+	refundErr error
+	before    func(call string)
+}
+
+func (p *recordingProvider) record(call string) {
+	p.calls = append(p.calls, call)
+	if p.before != nil {
+		p.before(call)
+	}
+}
+
+func (p *recordingProvider) Authorize(ctx context.Context, key string, amount moneyModel, paymentMethod string) (string, error) {
+	p.record("authorize " + key)
+	return p.fakeProvider.Authorize(ctx, key, amount, paymentMethod)
+}
+
+func (p *recordingProvider) Capture(ctx context.Context, key, authorization string, amount moneyModel) (string, error) {
+	p.record("capture " + key)
+	return p.fakeProvider.Capture(ctx, key, authorization, amount)
+}
+
+func (p *recordingProvider) Void(ctx context.Context, key, authorization string) error {
+	p.record("void " + key)
+	if p.voidErr != nil {
+		return p.voidErr
+	}
+	return p.fakeProvider.Void(ctx, key, authorization)
+}
+
+func (p *recordingProvider) Refund(ctx context.Context, key, capture string, amount moneyModel) (string, error) {
+	p.record("refund " + key)
+	if p.refundErr != nil {
+		return "", p.refundErr
+	}
+	return p.fakeProvider.Refund(ctx, key, capture, amount)
+}
+
+// paymentDoc returns a payment as MongoDB returns it.
+func paymentDoc(t *testing.T, payment paymentModel) bson.D {
+	t.Helper()
+	data, err := bson.Marshal(payment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc bson.D
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// paymentWrite is a write sent to the payments collection.
+type paymentWrite struct {
+	Filter bson.Raw
+	Update bson.Raw
+}
+
+// paymentWrites returns the updates and findAndModify commands sent to the payments
+// collection, in order.
+func paymentWrites(mt *mtest.T) []paymentWrite {
+	var writes []paymentWrite
+	for _, started := range mt.GetAllStartedEvents() {
+		switch started.CommandName {
+		case "update":
+			update := started.Command.Lookup("updates").Array().Index(0).Value().Document()
+			writes = append(writes, paymentWrite{
+				Filter: update.Lookup("q").Document(),
+				Update: update.Lookup("u").Document(),
+			})
+		case "findAndModify":
+			writes = append(writes, paymentWrite{
+				Filter: started.Command.Lookup("query").Document(),
+				Update: started.Command.Lookup("update").Document(),
+			})
+		}
+	}
+	return writes
+}
+
+var updateSucceeded = mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1})
+
+func usd(units int64) moneyModel {
+	return moneyModel{UnitsMinor: units, Currency: "USD"}
+}
+
+func TestResolvePendingPayments(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		wantCalls     bool
+		wantUpdate    bool
+		wantStatus    string
+		wantActive    bool
+		wantReference bool
+		wantFailure   string
+	}{
+		{
+			name:          "authorized",
+			method:        "card_visa",
+			wantCalls:     true,
+			wantUpdate:    true,
+			wantStatus:    paymentAuthorized,
+			wantActive:    true,
+			wantReference: true,
+		},
+		{
+			name:        "declined",
+			method:      fakeMethodInsufficientFunds,
+			wantCalls:   true,
+			wantUpdate:  true,
+			wantStatus:  paymentDeclined,
+			wantFailure: "payment declined: insufficient funds",
+		},
+		{
+			name:      "provider still unavailable",
+			method:    fakeMethodUnavailable,
+			wantCalls: true,
+		},
+		{
+			name:        "pending since before the payment method was kept",
+			wantUpdate:  true,
+			wantStatus:  paymentFailed,
+			wantFailure: "authorization was interrupted and cannot be retried",
+		},
+	}
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			stale := time.Now().UTC().Add(-time.Hour)
+			payment := paymentModel{
+				ID:            primitive.NewObjectID(),
+				OrderID:       primitive.NewObjectID().Hex(),
+				Status:        paymentPending,
+				Active:        true,
+				Amount:        usd(2500),
+				PaymentMethod: tt.method,
+				CreatedAt:     stale,
+				UpdatedAt:     stale,
+			}
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "order_management.payments", mtest.FirstBatch, paymentDoc(mt.T, payment)),
+				updateSucceeded,
+			)
+			provider := &recordingProvider{}
+			srv := &server{db: mt.Client.Database("order_management"), provider: provider}
+
+			srv.resolvePendingPayments(context.Background())
+
+			find := mt.GetStartedEvent()
+			if got := find.Command.Lookup("filter", "status").StringValue(); got != paymentPending {
+				t.Errorf("looked up payments in status %q, want %q", got, paymentPending)
+			}
+			before := find.Command.Lookup("filter", "updated_at", "$lt").Time()
+			if since := time.Since(before); since < pendingPaymentTimeout || since > pendingPaymentTimeout+time.Minute {
+				t.Errorf("looked up payments pending since %s ago, want %s", since, pendingPaymentTimeout)
+			}
+
+			var wantCalls []string
+			if tt.wantCalls {
+				// The payment id is the idempotency key of the first attempt
+				wantCalls = []string{"authorize " + payment.ID.Hex()}
+			}
+			if !reflect.DeepEqual(provider.calls, wantCalls) {
+				t.Errorf("provider calls = %q, want %q", provider.calls, wantCalls)
+			}
+
+			writes := paymentWrites(mt)
+			if !tt.wantUpdate {
+				if len(writes) != 0 {
+					t.Errorf("payment updated to %s, want it left pending", writes[0].Update)
+				}
+				return
+			}
+			if len(writes) != 1 {
+				t.Fatalf("payment updated %d times, want once", len(writes))
+			}
+			filter, update := writes[0].Filter, writes[0].Update
+			if got := filter.Lookup("status").StringValue(); got != paymentPending {
+				t.Errorf("update applies to status %q, want %q", got, paymentPending)
+			}
+			set := update.Lookup("$set").Document()
+			if got := set.Lookup("status").StringValue(); got != tt.wantStatus {
+				t.Errorf("status = %q, want %q", got, tt.wantStatus)
+			}
+			if got := set.Lookup("active").Boolean(); got != tt.wantActive {
+				t.Errorf("active = %v, want %v", got, tt.wantActive)
+			}
+			reference, _ := set.Lookup("provider_reference").StringValueOK()
+			if tt.wantReference && reference != "fake_auth_"+payment.ID.Hex() {
+				t.Errorf("provider_reference = %q, want the reference of the first attempt", reference)
+			}
+			failure, _ := set.Lookup("failure_reason").StringValueOK()
+			if failure != tt.wantFailure {
+				t.Errorf("failure_reason = %q, want %q", failure, tt.wantFailure)
+			}
+			if _, err := update.LookupErr("$unset", "payment_method"); err != nil {
+				t.Errorf("payment_method is kept after the authorization was resolved")
+			}
+		})
+	}
+}
+
+func TestSyncCapturedPayments(t *testing.T) {
+	tests := []struct {
+		name        string
+		orderStatus pb.OrderStatus
+		wantUpdates []pb.OrderStatus
+		wantSet     string
+	}{
+		{
+			name:        "confirmed order is moved to paid",
+			orderStatus: pb.OrderStatus_ORDER_STATUS_CONFIRMED,
+			wantUpdates: []pb.OrderStatus{pb.OrderStatus_ORDER_STATUS_PAID},
+			wantSet:     "order_paid_at",
+		},
+		{
+			name:        "order past paid counts as paid",
+			orderStatus: pb.OrderStatus_ORDER_STATUS_SHIPPED,
+			wantSet:     "order_paid_at",
+		},
+		{
+			name:        "cancelled order is recorded as an error",
+			orderStatus: pb.OrderStatus_ORDER_STATUS_CANCELLED,
+			wantSet:     "order_update_error",
+		},
+	}
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			stale := time.Now().UTC().Add(-time.Hour)
+			payment := paymentModel{
+				ID:             primitive.NewObjectID(),
+				OrderID:        primitive.NewObjectID().Hex(),
+				Status:         paymentCaptured,
+				Amount:         usd(2500),
+				CapturedAmount: usd(2500),
+				CreatedAt:      stale,
+				UpdatedAt:      stale,
+			}
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "order_management.payments", mtest.FirstBatch, paymentDoc(mt.T, payment)),
+				updateSucceeded,
+			)
+			orders := &fakeOrderClient{order: &pb.Order{Id: payment.OrderID, Status: tt.orderStatus}}
+			srv := &server{db: mt.Client.Database("order_management"), orderClient: orders, provider: &recordingProvider{}}
+
+			srv.syncCapturedPayments(context.Background())
+
+			if !reflect.DeepEqual(orders.updates, tt.wantUpdates) {
+				t.Errorf("order updates = %v, want %v", orders.updates, tt.wantUpdates)
+			}
+			writes := paymentWrites(mt)
+			if len(writes) != 1 {
+				t.Fatalf("payment updated %d times, want once", len(writes))
+			}
+			if _, err := writes[0].Update.LookupErr("$set", tt.wantSet); err != nil {
+				t.Errorf("update %s does not set %s", writes[0].Update, tt.wantSet)
+			}
+		})
+	}
+}
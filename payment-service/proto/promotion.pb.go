@@ -0,0 +1,639 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: promotion.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PromotionType int32
+
+const (
+	PromotionType_PROMOTION_TYPE_UNSPECIFIED  PromotionType = 0
+	PromotionType_PROMOTION_TYPE_PERCENTAGE   PromotionType = 1 // percent_off of each eligible item
+	PromotionType_PROMOTION_TYPE_FIXED_AMOUNT PromotionType = 2 // amount_off across the eligible items
+	PromotionType_PROMOTION_TYPE_BUY_X_GET_Y  PromotionType = 3 // Of every buy_quantity + get_quantity units, get_quantity are free
+)
+
+// Enum value maps for PromotionType.
+var (
+	PromotionType_name = map[int32]string{
+		0: "PROMOTION_TYPE_UNSPECIFIED",
+		1: "PROMOTION_TYPE_PERCENTAGE",
+		2: "PROMOTION_TYPE_FIXED_AMOUNT",
+		3: "PROMOTION_TYPE_BUY_X_GET_Y",
+	}
+	PromotionType_value = map[string]int32{
+		"PROMOTION_TYPE_UNSPECIFIED":  0,
+		"PROMOTION_TYPE_PERCENTAGE":   1,
+		"PROMOTION_TYPE_FIXED_AMOUNT": 2,
+		"PROMOTION_TYPE_BUY_X_GET_Y":  3,
+	}
+)
+
+func (x PromotionType) Enum() *PromotionType {
+	p := new(PromotionType)
+	*p = x
+	return p
+}
+
+func (x PromotionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PromotionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_promotion_proto_enumTypes[0].Descriptor()
+}
+
+func (PromotionType) Type() protoreflect.EnumType {
+	return &file_promotion_proto_enumTypes[0]
+}
+
+func (x PromotionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PromotionType.Descriptor instead.
+func (PromotionType) EnumDescriptor() ([]byte, []int) {
+	return file_promotion_proto_rawDescGZIP(), []int{0}
+}
+
+type Promotion struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code           string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // Coupon code; promotions without one apply to every order
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Type           PromotionType          `protobuf:"varint,4,opt,name=type,proto3,enum=proto.PromotionType" json:"type,omitempty"`
+	PercentOff     int32                  `protobuf:"varint,5,opt,name=percent_off,json=percentOff,proto3" json:"percent_off,omitempty"`                  // 1-100, for PERCENTAGE
+	AmountOff      *Money                 `protobuf:"bytes,6,opt,name=amount_off,json=amountOff,proto3" json:"amount_off,omitempty"`                      // For FIXED_AMOUNT
+	BuyQuantity    int32                  `protobuf:"varint,7,opt,name=buy_quantity,json=buyQuantity,proto3" json:"buy_quantity,omitempty"`               // For BUY_X_GET_Y
+	GetQuantity    int32                  `protobuf:"varint,8,opt,name=get_quantity,json=getQuantity,proto3" json:"get_quantity,omitempty"`               // For BUY_X_GET_Y
+	ProductIds     []string               `protobuf:"bytes,9,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`                   // Limits the promotion to these products...
+	Categories     []string               `protobuf:"bytes,10,rep,name=categories,proto3" json:"categories,omitempty"`                                    // ...or products in these categories; both empty means all
+	StartsAt       string                 `protobuf:"bytes,11,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`                        // RFC 3339, optional
+	EndsAt         string                 `protobuf:"bytes,12,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`                              // RFC 3339, optional
+	MaxUses        int32                  `protobuf:"varint,13,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`                          // 0 for unlimited
+	MaxUsesPerUser int32                  `protobuf:"varint,14,opt,name=max_uses_per_user,json=maxUsesPerUser,proto3" json:"max_uses_per_user,omitempty"` // 0 for unlimited
+	UsedCount      int32                  `protobuf:"varint,15,opt,name=used_count,json=usedCount,proto3" json:"used_count,omitempty"`                    // Output only
+	Active         bool                   `protobuf:"varint,16,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
+	mi := &file_promotion_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Promotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Promotion) ProtoMessage() {}
+
+func (x *Promotion) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return file_promotion_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Promotion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Promotion) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Promotion) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Promotion) GetType() PromotionType {
+	if x != nil {
+		return x.Type
+	}
+	return PromotionType_PROMOTION_TYPE_UNSPECIFIED
+}
+
+func (x *Promotion) GetPercentOff() int32 {
+	if x != nil {
+		return x.PercentOff
+	}
+	return 0
+}
+
+func (x *Promotion) GetAmountOff() *Money {
+	if x != nil {
+		return x.AmountOff
+	}
+	return nil
+}
+
+func (x *Promotion) GetBuyQuantity() int32 {
+	if x != nil {
+		return x.BuyQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetGetQuantity() int32 {
+	if x != nil {
+		return x.GetQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *Promotion) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Promotion) GetStartsAt() string {
+	if x != nil {
+		return x.StartsAt
+	}
+	return ""
+}
+
+func (x *Promotion) GetEndsAt() string {
+	if x != nil {
+		return x.EndsAt
+	}
+	return ""
+}
+
+func (x *Promotion) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *Promotion) GetMaxUsesPerUser() int32 {
+	if x != nil {
+		return x.MaxUsesPerUser
+	}
+	return 0
+}
+
+func (x *Promotion) GetUsedCount() int32 {
+	if x != nil {
+		return x.UsedCount
+	}
+	return 0
+}
+
+func (x *Promotion) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Promotion) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Promotion) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type GetPromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromotionRequest) Reset() {
+	*x = GetPromotionRequest{}
+	mi := &file_promotion_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromotionRequest) ProtoMessage() {}
+
+func (x *GetPromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromotionRequest.ProtoReflect.Descriptor instead.
+func (*GetPromotionRequest) Descriptor() ([]byte, []int) {
+	return file_promotion_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPromotionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListPromotionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActiveOnly    bool                   `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsRequest) Reset() {
+	*x = ListPromotionsRequest{}
+	mi := &file_promotion_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsRequest) ProtoMessage() {}
+
+func (x *ListPromotionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPromotionsRequest) Descriptor() ([]byte, []int) {
+	return file_promotion_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPromotionsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+func (x *ListPromotionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPromotionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListPromotionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotions    []*Promotion           `protobuf:"bytes,1,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsResponse) Reset() {
+	*x = ListPromotionsResponse{}
+	mi := &file_promotion_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsResponse) ProtoMessage() {}
+
+func (x *ListPromotionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPromotionsResponse) Descriptor() ([]byte, []int) {
+	return file_promotion_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPromotionsResponse) GetPromotions() []*Promotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+func (x *ListPromotionsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type DeletePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePromotionRequest) Reset() {
+	*x = DeletePromotionRequest{}
+	mi := &file_promotion_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePromotionRequest) ProtoMessage() {}
+
+func (x *DeletePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePromotionRequest.ProtoReflect.Descriptor instead.
+func (*DeletePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_promotion_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeletePromotionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeletePromotionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePromotionResponse) Reset() {
+	*x = DeletePromotionResponse{}
+	mi := &file_promotion_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePromotionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePromotionResponse) ProtoMessage() {}
+
+func (x *DeletePromotionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_promotion_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePromotionResponse.ProtoReflect.Descriptor instead.
+func (*DeletePromotionResponse) Descriptor() ([]byte, []int) {
+	return file_promotion_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeletePromotionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeletePromotionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_promotion_proto protoreflect.FileDescriptor
+
+const file_promotion_proto_rawDesc = "" +
+	"\n" +
+	"\x0fpromotion.proto\x12\x05proto\x1a\vmoney.proto\"\xc1\x04\n" +
+	"\tPromotion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12(\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x14.proto.PromotionTypeR\x04type\x12\x1f\n" +
+	"\vpercent_off\x18\x05 \x01(\x05R\n" +
+	"percentOff\x12+\n" +
+	"\n" +
+	"amount_off\x18\x06 \x01(\v2\f.proto.MoneyR\tamountOff\x12!\n" +
+	"\fbuy_quantity\x18\a \x01(\x05R\vbuyQuantity\x12!\n" +
+	"\fget_quantity\x18\b \x01(\x05R\vgetQuantity\x12\x1f\n" +
+	"\vproduct_ids\x18\t \x03(\tR\n" +
+	"productIds\x12\x1e\n" +
+	"\n" +
+	"categories\x18\n" +
+	" \x03(\tR\n" +
+	"categories\x12\x1b\n" +
+	"\tstarts_at\x18\v \x01(\tR\bstartsAt\x12\x17\n" +
+	"\aends_at\x18\f \x01(\tR\x06endsAt\x12\x19\n" +
+	"\bmax_uses\x18\r \x01(\x05R\amaxUses\x12)\n" +
+	"\x11max_uses_per_user\x18\x0e \x01(\x05R\x0emaxUsesPerUser\x12\x1d\n" +
+	"\n" +
+	"used_count\x18\x0f \x01(\x05R\tusedCount\x12\x16\n" +
+	"\x06active\x18\x10 \x01(\bR\x06active\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x12 \x01(\tR\tupdatedAt\"%\n" +
+	"\x13GetPromotionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"b\n" +
+	"\x15ListPromotionsRequest\x12\x1f\n" +
+	"\vactive_only\x18\x01 \x01(\bR\n" +
+	"activeOnly\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"`\n" +
+	"\x16ListPromotionsResponse\x120\n" +
+	"\n" +
+	"promotions\x18\x01 \x03(\v2\x10.proto.PromotionR\n" +
+	"promotions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"(\n" +
+	"\x16DeletePromotionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"M\n" +
+	"\x17DeletePromotionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage*\x8f\x01\n" +
+	"\rPromotionType\x12\x1e\n" +
+	"\x1aPROMOTION_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19PROMOTION_TYPE_PERCENTAGE\x10\x01\x12\x1f\n" +
+	"\x1bPROMOTION_TYPE_FIXED_AMOUNT\x10\x02\x12\x1e\n" +
+	"\x1aPROMOTION_TYPE_BUY_X_GET_Y\x10\x032\xe9\x02\n" +
+	"\x10PromotionService\x127\n" +
+	"\x0fCreatePromotion\x12\x10.proto.Promotion\x1a\x10.proto.Promotion\"\x00\x12>\n" +
+	"\fGetPromotion\x12\x1a.proto.GetPromotionRequest\x1a\x10.proto.Promotion\"\x00\x127\n" +
+	"\x0fUpdatePromotion\x12\x10.proto.Promotion\x1a\x10.proto.Promotion\"\x00\x12O\n" +
+	"\x0eListPromotions\x12\x1c.proto.ListPromotionsRequest\x1a\x1d.proto.ListPromotionsResponse\"\x00\x12R\n" +
+	"\x0fDeletePromotion\x12\x1d.proto.DeletePromotionRequest\x1a\x1e.proto.DeletePromotionResponse\"\x00B#Z!github.com/order-management/protob\x06proto3"
+
+var (
+	file_promotion_proto_rawDescOnce sync.Once
+	file_promotion_proto_rawDescData []byte
+)
+
+func file_promotion_proto_rawDescGZIP() []byte {
+	file_promotion_proto_rawDescOnce.Do(func() {
+		file_promotion_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_promotion_proto_rawDesc), len(file_promotion_proto_rawDesc)))
+	})
+	return file_promotion_proto_rawDescData
+}
+
+var file_promotion_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_promotion_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_promotion_proto_goTypes = []any{
+	(PromotionType)(0),              // 0: proto.PromotionType
+	(*Promotion)(nil),               // 1: proto.Promotion
+	(*GetPromotionRequest)(nil),     // 2: proto.GetPromotionRequest
+	(*ListPromotionsRequest)(nil),   // 3: proto.ListPromotionsRequest
+	(*ListPromotionsResponse)(nil),  // 4: proto.ListPromotionsResponse
+	(*DeletePromotionRequest)(nil),  // 5: proto.DeletePromotionRequest
+	(*DeletePromotionResponse)(nil), // 6: proto.DeletePromotionResponse
+	(*Money)(nil),                   // 7: proto.Money
+}
+var file_promotion_proto_depIdxs = []int32{
+	0, // 0: proto.Promotion.type:type_name -> proto.PromotionType
+	7, // 1: proto.Promotion.amount_off:type_name -> proto.Money
+	1, // 2: proto.ListPromotionsResponse.promotions:type_name -> proto.Promotion
+	1, // 3: proto.PromotionService.CreatePromotion:input_type -> proto.Promotion
+	2, // 4: proto.PromotionService.GetPromotion:input_type -> proto.GetPromotionRequest
+	1, // 5: proto.PromotionService.UpdatePromotion:input_type -> proto.Promotion
+	3, // 6: proto.PromotionService.ListPromotions:input_type -> proto.ListPromotionsRequest
+	5, // 7: proto.PromotionService.DeletePromotion:input_type -> proto.DeletePromotionRequest
+	1, // 8: proto.PromotionService.CreatePromotion:output_type -> proto.Promotion
+	1, // 9: proto.PromotionService.GetPromotion:output_type -> proto.Promotion
+	1, // 10: proto.PromotionService.UpdatePromotion:output_type -> proto.Promotion
+	4, // 11: proto.PromotionService.ListPromotions:output_type -> proto.ListPromotionsResponse
+	6, // 12: proto.PromotionService.DeletePromotion:output_type -> proto.DeletePromotionResponse
+	8, // [8:13] is the sub-list for method output_type
+	3, // [3:8] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_promotion_proto_init() }
+func file_promotion_proto_init() {
+	if File_promotion_proto != nil {
+		return
+	}
+	file_money_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_promotion_proto_rawDesc), len(file_promotion_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_promotion_proto_goTypes,
+		DependencyIndexes: file_promotion_proto_depIdxs,
+		EnumInfos:         file_promotion_proto_enumTypes,
+		MessageInfos:      file_promotion_proto_msgTypes,
+	}.Build()
+	File_promotion_proto = out.File
+	file_promotion_proto_goTypes = nil
+	file_promotion_proto_depIdxs = nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// PaymentProvider moves money through an external payment gateway. Every call carries
+// an idempotency key, and repeating a call with the same key must not authorize,
+// capture, void or refund twice. A provider refusing a payment returns a *declinedError;
+// any other error means the outcome is unknown and the call may be retried.
+type PaymentProvider interface {
+	Name() string
+	Authorize(ctx context.Context, key string, amount moneyModel, paymentMethod string) (string, error)
+	Capture(ctx context.Context, key, authorization string, amount moneyModel) (string, error)
+	Void(ctx context.Context, key, authorization string) error
+	Refund(ctx context.Context, key, capture string, amount moneyModel) (string, error)
+}
+
+// declinedError reports a payment the provider refused.
+type declinedError struct {
+	reason string
+}
+
+func (e *declinedError) Error() string {
+	return "payment declined: " + e.reason
+}
+
+// isDeclined reports whether err is a decline rather than a provider failure.
+func isDeclined(err error) bool {
+	var declined *declinedError
+	return errors.As(err, &declined)
+}
+
+// Payment methods with a fixed outcome at the fake provider. Any other method is approved.
+const (
+	fakeMethodDecline           = "fake_decline"
+	fakeMethodInsufficientFunds = "fake_insufficient_funds"
+	fakeMethodUnavailable       = "fake_unavailable"
+)
+
+// fakeProvider is a deterministic stand-in for a real gateway, used for local runs and
+// tests. The outcome depends only on the payment method, and references are derived
+// from the idempotency key, so repeated calls always give the same answer.
+type fakeProvider struct{}
+
+func newFakeProvider() *fakeProvider {
+	return &fakeProvider{}
+}
+
+func (p *fakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *fakeProvider) Authorize(ctx context.Context, key string, amount moneyModel, paymentMethod string) (string, error) {
+	switch paymentMethod {
+	case fakeMethodDecline:
+		return "", &declinedError{reason: "card declined"}
+	case fakeMethodInsufficientFunds:
+		return "", &declinedError{reason: "insufficient funds"}
+	case fakeMethodUnavailable:
+		return "", fmt.Errorf("fake provider unavailable")
+	}
+	if amount.UnitsMinor <= 0 {
+		return "", &declinedError{reason: "invalid amount"}
+	}
+	return "fake_auth_" + key, nil
+}
+
+func (p *fakeProvider) Capture(ctx context.Context, key, authorization string, amount moneyModel) (string, error) {
+	if amount.UnitsMinor <= 0 {
+		return "", &declinedError{reason: "invalid amount"}
+	}
+	return "fake_cap_" + key, nil
+}
+
+func (p *fakeProvider) Void(ctx context.Context, key, authorization string) error {
+	return nil
+}
+
+func (p *fakeProvider) Refund(ctx context.Context, key, capture string, amount moneyModel) (string, error) {
+	if amount.UnitsMinor <= 0 {
+		return "", &declinedError{reason: "invalid amount"}
+	}
+	return "fake_ref_" + key, nil
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+func TestFakeProviderAuthorize(t *testing.T) {
+	tests := []struct {
+		method       string
+		amount       int64
+		want         string
+		wantDeclined bool
+		wantErr      string
+	}{
+		{method: "card_visa", amount: 1000, want: "fake_auth_p1"},
+		{method: fakeMethodDecline, amount: 1000, wantDeclined: true, wantErr: "payment declined: card declined"},
+		{method: fakeMethodInsufficientFunds, amount: 1000, wantDeclined: true, wantErr: "payment declined: insufficient funds"},
+		{method: fakeMethodUnavailable, amount: 1000, wantErr: "fake provider unavailable"},
+		{method: "card_visa", amount: 0, wantDeclined: true, wantErr: "payment declined: invalid amount"},
+	}
+	provider := newFakeProvider()
+	for _, tt := range tests {
+		for attempt := 0; attempt < 2; attempt++ {
+			reference, err := provider.Authorize(context.Background(), "p1", usd(tt.amount), tt.method)
+			if tt.wantErr == "" {
+				if err != nil || reference != tt.want {
+					t.Errorf("Authorize(%s, %d) = %q, %v; want %q", tt.method, tt.amount, reference, err, tt.want)
+				}
+				continue
+			}
+			if err == nil || err.Error() != tt.wantErr || isDeclined(err) != tt.wantDeclined {
+				t.Errorf("Authorize(%s, %d) error = %v (declined %v), want %q (declined %v)",
+					tt.method, tt.amount, err, isDeclined(err), tt.wantErr, tt.wantDeclined)
+			}
+		}
+	}
+}
+
+func TestCapturePayment(t *testing.T) {
+	tests := []struct {
+		name        string
+		orderStatus pb.OrderStatus
+		orderTotal  int64
+		voidErr     error
+		wantCode    codes.Code
+		wantCalls   []string
+		wantStatus  string // Set on the payment, if updated
+		wantUpdates []pb.OrderStatus
+	}{
+		{
+			name:        "captured and order paid",
+			orderStatus: pb.OrderStatus_ORDER_STATUS_CONFIRMED,
+			orderTotal:  2500,
+			wantCalls:   []string{"capture"},
+			wantStatus:  paymentCaptured,
+			wantUpdates: []pb.OrderStatus{pb.OrderStatus_ORDER_STATUS_PAID},
+		},
+		{
+			name:        "order total changed after authorization",
+			orderStatus: pb.OrderStatus_ORDER_STATUS_CONFIRMED,
+			orderTotal:  3100,
+			wantCode:    codes.FailedPrecondition,
+			wantCalls:   []string{"void"},
+			wantStatus:  paymentVoided,
+		},
+		{
+			name:        "void fails at the provider",
+			orderStatus: pb.OrderStatus_ORDER_STATUS_CONFIRMED,
+			orderTotal:  2000,
+			voidErr:     errors.New("timeout"),
+			wantCode:    codes.Unavailable,
+			wantCalls:   []string{"void"},
+		},
+		{
+			name:        "order cancelled",
+			orderStatus: pb.OrderStatus_ORDER_STATUS_CANCELLED,
+			orderTotal:  2500,
+			wantCode:    codes.FailedPrecondition,
+		},
+	}
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			now := time.Now().UTC()
+			payment := paymentModel{
+				ID:                primitive.NewObjectID(),
+				OrderID:           primitive.NewObjectID().Hex(),
+				Status:            paymentAuthorized,
+				Active:            true,
+				Amount:            usd(2500),
+				CapturedAmount:    usd(0),
+				RefundedAmount:    usd(0),
+				ProviderReference: "fake_auth_1",
+				Refunds:           []refundModel{},
+				CreatedAt:         now,
+				UpdatedAt:         now,
+			}
+			captured := payment
+			captured.Status, captured.CapturedAmount = paymentCaptured, usd(2500)
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "order_management.payments", mtest.FirstBatch, paymentDoc(mt.T, payment)),
+				mtest.CreateSuccessResponse(bson.E{Key: "value", Value: paymentDoc(mt.T, captured)}),
+				updateSucceeded,
+			)
+			orders := &fakeOrderClient{order: &pb.Order{
+				Id:          payment.OrderID,
+				Status:      tt.orderStatus,
+				TotalAmount: &pb.Money{UnitsMinor: tt.orderTotal, Currency: "USD"},
+			}}
+			provider := &recordingProvider{voidErr: tt.voidErr}
+			srv := &server{db: mt.Client.Database("order_management"), orderClient: orders, provider: provider}
+
+			got, err := srv.CapturePayment(context.Background(), &pb.CapturePaymentRequest{PaymentId: payment.ID.Hex()})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("CapturePayment() error = %v, want code %s", err, tt.wantCode)
+			}
+			if err == nil && got.Status != pb.PaymentStatus_PAYMENT_STATUS_CAPTURED {
+				t.Errorf("CapturePayment() status = %s, want captured", got.Status)
+			}
+
+			var wantCalls []string
+			for _, call := range tt.wantCalls {
+				wantCalls = append(wantCalls, call+" "+payment.ID.Hex())
+			}
+			if !reflect.DeepEqual(provider.calls, wantCalls) {
+				t.Errorf("provider calls = %q, want %q", provider.calls, wantCalls)
+			}
+			if !reflect.DeepEqual(orders.updates, tt.wantUpdates) {
+				t.Errorf("order updates = %v, want %v", orders.updates, tt.wantUpdates)
+			}
+
+			writes := paymentWrites(mt)
+			if tt.wantStatus == "" {
+				if len(writes) != 0 {
+					t.Errorf("payment updated to %s, want it left authorized", writes[0].Update)
+				}
+				return
+			}
+			if len(writes) == 0 {
+				t.Fatal("payment not updated")
+			}
+			set := writes[0].Update.Lookup("$set").Document()
+			if got := set.Lookup("status").StringValue(); got != tt.wantStatus {
+				t.Errorf("payment status = %q, want %q", got, tt.wantStatus)
+			}
+			if got := writes[0].Filter.Lookup("status").StringValue(); got != paymentAuthorized {
+				t.Errorf("update applies to status %q, want %q", got, paymentAuthorized)
+			}
+			if tt.wantStatus == paymentVoided && set.Lookup("active").Boolean() {
+				t.Errorf("voided payment is still active, so the order cannot be authorized again")
+			}
+		})
+	}
+}
+
+func TestRefundPayment(t *testing.T) {
+	tests := []struct {
+		name         string
+		refunded     int64
+		amount       int64
+		reserveMatch bool
+		refundErr    error
+		wantCode     codes.Code
+		wantRefund   bool   // The provider is called
+		wantOutcome  string // Status recorded for the refund
+	}{
+		{
+			name:         "refunded",
+			amount:       1000,
+			reserveMatch: true,
+			wantRefund:   true,
+			wantOutcome:  refundSucceeded,
+		},
+		{
+			name:         "provider fails and the amount is given back",
+			refunded:     500,
+			amount:       1000,
+			reserveMatch: true,
+			refundErr:    errors.New("timeout"),
+			wantCode:     codes.Unavailable,
+			wantRefund:   true,
+			wantOutcome:  refundFailed,
+		},
+		{
+			name:         "provider declines and the amount is given back",
+			amount:       2500,
+			reserveMatch: true,
+			refundErr:    &declinedError{reason: "capture expired"},
+			wantCode:     codes.FailedPrecondition,
+			wantRefund:   true,
+			wantOutcome:  refundFailed,
+		},
+		{
+			name:     "refunded concurrently",
+			amount:   1000,
+			wantCode: codes.Aborted,
+		},
+		{
+			name:     "more than what is left",
+			refunded: 2000,
+			amount:   1000,
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			now := time.Now().UTC()
+			payment := paymentModel{
+				ID:               primitive.NewObjectID(),
+				OrderID:          primitive.NewObjectID().Hex(),
+				Status:           paymentCaptured,
+				Amount:           usd(2500),
+				CapturedAmount:   usd(2500),
+				RefundedAmount:   usd(tt.refunded),
+				CaptureReference: "fake_cap_1",
+				Refunds:          []refundModel{},
+				CreatedAt:        now,
+				UpdatedAt:        now,
+			}
+			matched := int32(0)
+			if tt.reserveMatch {
+				matched = 1
+			}
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "order_management.payments", mtest.FirstBatch, paymentDoc(mt.T, payment)),
+				mtest.CreateSuccessResponse(bson.E{Key: "n", Value: matched}, bson.E{Key: "nModified", Value: matched}),
+				mtest.CreateSuccessResponse(bson.E{Key: "value", Value: paymentDoc(mt.T, payment)}),
+			)
+			provider := &recordingProvider{refundErr: tt.refundErr}
+			provider.before = func(call string) {
+				// The amount must be reserved before the provider is asked for it
+				writes := paymentWrites(mt)
+				if len(writes) != 1 || writes[0].Update.Lookup("$inc", "refunded_amount.units_minor").AsInt64() != tt.amount {
+					t.Errorf("provider called for a refund of %d before reserving it", tt.amount)
+				}
+			}
+			srv := &server{db: mt.Client.Database("order_management"), provider: provider}
+
+			_, err := srv.RefundPayment(context.Background(), &pb.RefundPaymentRequest{
+				PaymentId: payment.ID.Hex(),
+				Amount:    &pb.Money{UnitsMinor: tt.amount, Currency: "USD"},
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("RefundPayment() error = %v, want code %s", err, tt.wantCode)
+			}
+			if refunded := len(provider.calls) > 0; refunded != tt.wantRefund {
+				t.Errorf("provider called %q, want a refund %v", provider.calls, tt.wantRefund)
+			}
+
+			writes := paymentWrites(mt)
+			if tt.wantCode == codes.FailedPrecondition && !tt.wantRefund {
+				if len(writes) != 0 {
+					t.Errorf("payment updated for a refund that was refused")
+				}
+				return
+			}
+			reserve := writes[0]
+			if got := reserve.Filter.Lookup("refunded_amount.units_minor").AsInt64(); got != tt.refunded {
+				t.Errorf("reservation applies to a refunded amount of %d, want %d", got, tt.refunded)
+			}
+			pushed := reserve.Update.Lookup("$push", "refunds").Document()
+			if got := pushed.Lookup("status").StringValue(); got != refundPending {
+				t.Errorf("refund recorded as %q before the provider call, want %q", got, refundPending)
+			}
+			if !tt.wantRefund {
+				if len(writes) != 1 {
+					t.Errorf("refund updated after the reservation failed")
+				}
+				return
+			}
+
+			if len(writes) != 2 {
+				t.Fatalf("payment written %d times, want a reservation and an outcome", len(writes))
+			}
+			outcome := writes[1].Update
+			if got := outcome.Lookup("$set", "refunds.$.status").StringValue(); got != tt.wantOutcome {
+				t.Errorf("refund status = %q, want %q", got, tt.wantOutcome)
+			}
+			given, err := outcome.LookupErr("$inc", "refunded_amount.units_minor")
+			switch {
+			case tt.wantOutcome == refundFailed && (err != nil || given.AsInt64() != -tt.amount):
+				t.Errorf("failed refund gives back %v, want %d", given, -tt.amount)
+			case tt.wantOutcome == refundSucceeded && err == nil:
+				t.Errorf("succeeded refund changes the refunded amount again")
+			}
+		})
+	}
+}
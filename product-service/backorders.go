@@ -0,0 +1,468 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// Orders take stock through ReserveStock. Units beyond the stock of a product whose
+// policy allows it are recorded in the backorders collection and counted in the
+// product's backordered_quantity, with its stock left at zero. Stock added later, by
+// UpdateStock or by a release, goes to the oldest backorders first; only what is left
+// over becomes available stock. Stock changes and allocations run in one transaction.
+//
+// A reservation made with a key is recorded in the stock_reservations collection in
+// the same transaction, so a caller that lost the answer can repeat the request, or
+// release the reservation, without knowing whether it was made. A stock update made
+// with a key is likewise recorded in stock_updates.
+
+type backorderModel struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	ProductID string             `bson:"product_id"`
+	OrderID   string             `bson:"order_id"`
+	Quantity  int32              `bson:"quantity"`
+	Allocated int32              `bson:"allocated"`
+	CreatedAt time.Time          `bson:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+}
+
+// stockReservationModel records the outcome of a keyed reservation, and of its release.
+// A release of a key nothing was reserved with leaves a released record with no units.
+type stockReservationModel struct {
+	Key                string    `bson:"_id"`
+	ProductID          string    `bson:"product_id"`
+	OrderID            string    `bson:"order_id"`
+	Quantity           int32     `bson:"quantity"`
+	Reserved           int32     `bson:"reserved"`
+	Backordered        int32     `bson:"backordered"`
+	Released           bool      `bson:"released"`
+	Restocked          int32     `bson:"restocked"`
+	BackorderCancelled int32     `bson:"backorder_cancelled"`
+	CreatedAt          time.Time `bson:"created_at"`
+	UpdatedAt          time.Time `bson:"updated_at"`
+}
+
+// stockUpdateModel records a keyed stock update, so repeating it changes nothing.
+type stockUpdateModel struct {
+	Key            string    `bson:"_id"`
+	ProductID      string    `bson:"product_id"`
+	QuantityChange int32     `bson:"quantity_change"`
+	CreatedAt      time.Time `bson:"created_at"`
+}
+
+// stockModel holds the stock fields of a product document.
+type stockModel struct {
+	StockQuantity   int32 `bson:"stock_quantity"`
+	BackorderPolicy int32 `bson:"backorder_policy"`
+	BackorderLimit  int32 `bson:"backorder_limit"`
+	Backordered     int32 `bson:"backordered_quantity"`
+}
+
+// validateBackorderPolicy checks the backorder settings sent by a client.
+func validateBackorderPolicy(policy pb.BackorderPolicy, limit int32) error {
+	if _, ok := pb.BackorderPolicy_name[int32(policy)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown backorder policy %d", policy)
+	}
+	if limit < 0 {
+		return status.Error(codes.InvalidArgument, "backorder limit cannot be negative")
+	}
+	return nil
+}
+
+// int32FromDoc returns an int32 field of a product document, or 0 for products
+// stored before the field existed.
+func int32FromDoc(v interface{}) int32 {
+	n, _ := v.(int32)
+	return n
+}
+
+func (s *server) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.StockReservation, error) {
+	id, err := stockRequestID(req.ProductId, req.OrderId, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation := &pb.StockReservation{}
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		if req.ReservationKey != "" {
+			previous, err := s.findStockReservation(sc, req.ReservationKey, id, req.OrderId, req.Quantity)
+			if err != nil {
+				return err
+			}
+			if previous != nil {
+				if previous.Released {
+					return status.Errorf(codes.FailedPrecondition, "reservation %s was already released", req.ReservationKey)
+				}
+				reservation.Reserved, reservation.Backordered = previous.Reserved, previous.Backordered
+				return nil
+			}
+		}
+
+		var product stockModel
+		if err := s.db.Collection("products").FindOne(sc, bson.M{"_id": id}).Decode(&product); err != nil {
+			return err
+		}
+
+		reserved := req.Quantity
+		if product.StockQuantity < reserved {
+			reserved = product.StockQuantity
+		}
+		backordered := req.Quantity - reserved
+		if backordered > 0 {
+			if pb.BackorderPolicy(product.BackorderPolicy) == pb.BackorderPolicy_BACKORDER_POLICY_NONE {
+				return status.Error(codes.FailedPrecondition, "insufficient stock")
+			}
+			if product.BackorderLimit > 0 && product.Backordered+backordered > product.BackorderLimit {
+				return status.Errorf(codes.FailedPrecondition,
+					"insufficient stock: %d units available and %d of %d backorderable units already owed",
+					product.StockQuantity, product.Backordered, product.BackorderLimit)
+			}
+			_, err := s.db.Collection("backorders").InsertOne(sc, backorderModel{
+				ProductID: id.Hex(),
+				OrderID:   req.OrderId,
+				Quantity:  backordered,
+				CreatedAt: time.Now().UTC(),
+				UpdatedAt: time.Now().UTC(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := s.db.Collection("products").UpdateOne(sc,
+			bson.M{"_id": id},
+			bson.M{
+				"$inc": bson.M{"stock_quantity": -reserved, "backordered_quantity": backordered},
+				"$set": bson.M{"updated_at": time.Now().UTC()},
+			},
+		)
+		if err != nil {
+			return err
+		}
+		reservation.Reserved, reservation.Backordered = reserved, backordered
+
+		if req.ReservationKey == "" {
+			return nil
+		}
+		_, err = s.db.Collection("stock_reservations").InsertOne(sc, stockReservationModel{
+			Key:         req.ReservationKey,
+			ProductID:   id.Hex(),
+			OrderID:     req.OrderId,
+			Quantity:    req.Quantity,
+			Reserved:    reserved,
+			Backordered: backordered,
+			CreatedAt:   time.Now().UTC(),
+			UpdatedAt:   time.Now().UTC(),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, stockError(err)
+	}
+
+	return reservation, nil
+}
+
+func (s *server) ReleaseStock(ctx context.Context, req *pb.ReleaseStockRequest) (*pb.StockRelease, error) {
+	id, err := stockRequestID(req.ProductId, req.OrderId, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	release := &pb.StockRelease{}
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		if req.ReservationKey != "" {
+			previous, err := s.findStockReservation(sc, req.ReservationKey, id, req.OrderId, req.Quantity)
+			if err != nil {
+				return err
+			}
+			if previous == nil {
+				// Nothing was reserved; make sure nothing will be
+				_, err := s.db.Collection("stock_reservations").InsertOne(sc, stockReservationModel{
+					Key:       req.ReservationKey,
+					ProductID: id.Hex(),
+					OrderID:   req.OrderId,
+					Quantity:  req.Quantity,
+					Released:  true,
+					CreatedAt: time.Now().UTC(),
+					UpdatedAt: time.Now().UTC(),
+				})
+				return err
+			}
+			if previous.Released {
+				release.Restocked, release.BackorderCancelled = previous.Restocked, previous.BackorderCancelled
+				return nil
+			}
+		}
+
+		var product stockModel
+		if err := s.db.Collection("products").FindOne(sc, bson.M{"_id": id}).Decode(&product); err != nil {
+			return err
+		}
+
+		// Cancel the units still owed to the order, newest backorder first
+		cursor, err := s.db.Collection("backorders").Find(sc,
+			bson.M{"product_id": id.Hex(), "order_id": req.OrderId, "quantity": bson.M{"$gt": 0}},
+			options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}),
+		)
+		if err != nil {
+			return err
+		}
+		var backorders []backorderModel
+		if err := cursor.All(sc, &backorders); err != nil {
+			return err
+		}
+
+		var cancelled int32
+		for _, backorder := range backorders {
+			n := backorder.Quantity
+			if n > req.Quantity-cancelled {
+				n = req.Quantity - cancelled
+			}
+			if n == 0 {
+				break
+			}
+			_, err := s.db.Collection("backorders").UpdateOne(sc,
+				bson.M{"_id": backorder.ID},
+				bson.M{"$inc": bson.M{"quantity": -n}, "$set": bson.M{"updated_at": time.Now().UTC()}},
+			)
+			if err != nil {
+				return err
+			}
+			cancelled += n
+		}
+
+		restocked := req.Quantity - cancelled
+		_, err = s.db.Collection("products").UpdateOne(sc,
+			bson.M{"_id": id},
+			bson.M{
+				"$inc": bson.M{"stock_quantity": restocked, "backordered_quantity": -cancelled},
+				"$set": bson.M{"updated_at": time.Now().UTC()},
+			},
+		)
+		if err != nil {
+			return err
+		}
+		release.Restocked, release.BackorderCancelled = restocked, cancelled
+
+		if req.ReservationKey != "" {
+			_, err := s.db.Collection("stock_reservations").UpdateOne(sc,
+				bson.M{"_id": req.ReservationKey},
+				bson.M{"$set": bson.M{
+					"released":            true,
+					"restocked":           restocked,
+					"backorder_cancelled": cancelled,
+					"updated_at":          time.Now().UTC(),
+				}},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		if restocked > 0 {
+			return s.allocateBackorders(sc, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, stockError(err)
+	}
+
+	return release, nil
+}
+
+// findStockReservation returns the reservation recorded with key, or nil if there is
+// none. A key recorded for a different product, order or quantity is rejected.
+func (s *server) findStockReservation(sc mongo.SessionContext, key string, productID primitive.ObjectID,
+	orderID string, quantity int32) (*stockReservationModel, error) {
+	var reservation stockReservationModel
+	err := s.db.Collection("stock_reservations").FindOne(sc, bson.M{"_id": key}).Decode(&reservation)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if reservation.ProductID != productID.Hex() || reservation.OrderID != orderID || reservation.Quantity != quantity {
+		return nil, status.Errorf(codes.InvalidArgument, "reservation key %s was used for another request", key)
+	}
+	return &reservation, nil
+}
+
+// stockUpdateApplied reports whether a stock update with key was already made.
+func (s *server) stockUpdateApplied(sc mongo.SessionContext, key string, productID primitive.ObjectID,
+	quantityChange int32) (bool, error) {
+	var update stockUpdateModel
+	err := s.db.Collection("stock_updates").FindOne(sc, bson.M{"_id": key}).Decode(&update)
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if update.ProductID != productID.Hex() || update.QuantityChange != quantityChange {
+		return false, status.Errorf(codes.InvalidArgument, "update key %s was used for another request", key)
+	}
+	return true, nil
+}
+
+// allocateBackorders hands the stock of a product to its backorders, oldest first,
+// until either runs out.
+func (s *server) allocateBackorders(sc mongo.SessionContext, id primitive.ObjectID) error {
+	var product stockModel
+	if err := s.db.Collection("products").FindOne(sc, bson.M{"_id": id}).Decode(&product); err != nil {
+		return err
+	}
+	if product.StockQuantity <= 0 || product.Backordered <= 0 {
+		return nil
+	}
+
+	cursor, err := s.db.Collection("backorders").Find(sc,
+		bson.M{"product_id": id.Hex(), "quantity": bson.M{"$gt": 0}},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(sc)
+
+	var allocated int32
+	for product.StockQuantity > allocated && cursor.Next(sc) {
+		var backorder backorderModel
+		if err := cursor.Decode(&backorder); err != nil {
+			return err
+		}
+		n := backorder.Quantity
+		if n > product.StockQuantity-allocated {
+			n = product.StockQuantity - allocated
+		}
+		_, err := s.db.Collection("backorders").UpdateOne(sc,
+			bson.M{"_id": backorder.ID},
+			bson.M{
+				"$inc": bson.M{"quantity": -n, "allocated": n},
+				"$set": bson.M{"updated_at": time.Now().UTC()},
+			},
+		)
+		if err != nil {
+			return err
+		}
+		allocated += n
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	if allocated == 0 {
+		return nil
+	}
+
+	_, err = s.db.Collection("products").UpdateOne(sc,
+		bson.M{"_id": id},
+		bson.M{"$inc": bson.M{"stock_quantity": -allocated, "backordered_quantity": -allocated}},
+	)
+	return err
+}
+
+func (s *server) ListBackorders(ctx context.Context, req *pb.ListBackordersRequest) (*pb.ListBackordersResponse, error) {
+	filter := bson.M{}
+	if req.OrderId != "" {
+		filter["order_id"] = req.OrderId
+	}
+	if req.ProductId != "" {
+		filter["product_id"] = req.ProductId
+	}
+	if len(filter) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "order_id or product_id is required")
+	}
+	if req.OutstandingOnly {
+		filter["quantity"] = bson.M{"$gt": 0}
+	}
+
+	cursor, err := s.db.Collection("backorders").Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list backorders: %v", err)
+	}
+	var backorders []backorderModel
+	if err := cursor.All(ctx, &backorders); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode backorders: %v", err)
+	}
+
+	response := &pb.ListBackordersResponse{Backorders: make([]*pb.Backorder, 0, len(backorders))}
+	for _, backorder := range backorders {
+		response.Backorders = append(response.Backorders, &pb.Backorder{
+			Id:        backorder.ID.Hex(),
+			ProductId: backorder.ProductID,
+			OrderId:   backorder.OrderID,
+			Quantity:  backorder.Quantity,
+			Allocated: backorder.Allocated,
+			CreatedAt: backorder.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: backorder.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	return response, nil
+}
+
+// stockRequestID validates a ReserveStock or ReleaseStock request and returns the
+// product's ObjectID.
+func stockRequestID(productID, orderID string, quantity int32) (primitive.ObjectID, error) {
+	if productID == "" {
+		return primitive.NilObjectID, status.Error(codes.InvalidArgument, "product id is required")
+	}
+	if orderID == "" {
+		return primitive.NilObjectID, status.Error(codes.InvalidArgument, "order id is required")
+	}
+	if quantity < 1 {
+		return primitive.NilObjectID, status.Error(codes.InvalidArgument, "quantity must be at least 1")
+	}
+
+	// Convert string ID to ObjectID
+	id, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return primitive.NilObjectID, status.Error(codes.InvalidArgument, "invalid product id")
+	}
+	return id, nil
+}
+
+// stockError converts an error from a stock transaction into a gRPC status.
+func stockError(err error) error {
+	if err == mongo.ErrNoDocuments {
+		return status.Error(codes.NotFound, "product not found")
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Errorf(codes.Internal, "failed to update product stock: %v", err)
+}
+
+// withTransaction runs fn in a MongoDB transaction, retrying it on transient errors.
+func (s *server) withTransaction(ctx context.Context, fn func(sc mongo.SessionContext) error) error {
+	session, err := s.db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
+// ensureBackorderIndexes creates the indexes for allocating a product's backorders in
+// order and for looking up those of an order.
+func ensureBackorderIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("backorders").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "order_id", Value: 1}, {Key: "product_id", Value: 1}}},
+	})
+	return err
+}
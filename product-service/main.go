@@ -38,6 +38,30 @@ func main() {
 	}
 	defer client.Disconnect(ctx)
 
+	// Create indexes for listing products page by page
+	_, err = client.Database("order_management").Collection("products").Indexes().CreateMany(
+		context.Background(),
+		[]mongo.IndexModel{
+			{Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+			{Keys: bson.D{{Key: "category", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+		},
+	)
+	if err != nil {
+		log.Fatalf("Failed to create index: %v", err)
+	}
+	if err := ensureBackorderIndexes(context.Background(), client.Database("order_management")); err != nil {
+		log.Fatalf("Failed to create index: %v", err)
+	}
+
+	// Convert prices stored as floats by earlier versions to Money
+	migrated, err := migrateLegacyPrices(context.Background(), client.Database("order_management"))
+	if err != nil {
+		log.Fatalf("Failed to migrate product prices: %v", err)
+	}
+	if migrated > 0 {
+		log.Printf("Migrated prices of %d products to Money", migrated)
+	}
+
 	// Initialize gRPC server
 	lis, err := net.Listen("tcp", ":50052") // Different port from order service
 	if err != nil {
@@ -59,22 +83,38 @@ func (s *server) CreateProduct(ctx context.Context, req *pb.CreateProductRequest
 	if req.Name == "" {
 		return nil, status.Error(codes.InvalidArgument, "product name is required")
 	}
-	if req.Price < 0 {
-		return nil, status.Error(codes.InvalidArgument, "price cannot be negative")
+	if req.Price == nil {
+		return nil, status.Error(codes.InvalidArgument, "price is required")
+	}
+	if err := validatePrice(req.Price); err != nil {
+		return nil, err
 	}
 	if req.StockQuantity < 0 {
 		return nil, status.Error(codes.InvalidArgument, "stock quantity cannot be negative")
 	}
+	if req.Parcel != nil {
+		if err := validateParcel(req.Parcel); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateBackorderPolicy(req.BackorderPolicy, req.BackorderLimit); err != nil {
+		return nil, err
+	}
 
 	// Create product document
 	product := bson.M{
-		"name":           req.Name,
-		"description":    req.Description,
-		"price":         req.Price,
-		"stock_quantity": req.StockQuantity,
-		"category":      req.Category,
-		"created_at":    time.Now().UTC(),
-		"updated_at":    time.Now().UTC(),
+		"name":             req.Name,
+		"description":      req.Description,
+		"price":            priceDoc(req.Price),
+		"stock_quantity":   req.StockQuantity,
+		"category":         req.Category,
+		"backorder_policy": int32(req.BackorderPolicy),
+		"backorder_limit":  req.BackorderLimit,
+		"created_at":       time.Now().UTC(),
+		"updated_at":       time.Now().UTC(),
+	}
+	if req.Parcel != nil {
+		product["parcel"] = parcelDoc(req.Parcel)
 	}
 
 	// Insert into MongoDB
@@ -88,14 +128,17 @@ func (s *server) CreateProduct(ctx context.Context, req *pb.CreateProductRequest
 
 	// Return the created product
 	return &pb.Product{
-		Id:            id.Hex(),
-		Name:          req.Name,
-		Description:   req.Description,
-		Price:         req.Price,
-		StockQuantity: req.StockQuantity,
-		Category:      req.Category,
-		CreatedAt:     product["created_at"].(time.Time).Format(time.RFC3339),
-		UpdatedAt:     product["updated_at"].(time.Time).Format(time.RFC3339),
+		Id:              id.Hex(),
+		Name:            req.Name,
+		Description:     req.Description,
+		Price:           req.Price,
+		StockQuantity:   req.StockQuantity,
+		Category:        req.Category,
+		Parcel:          req.Parcel,
+		BackorderPolicy: req.BackorderPolicy,
+		BackorderLimit:  req.BackorderLimit,
+		CreatedAt:       product["created_at"].(time.Time).Format(time.RFC3339),
+		UpdatedAt:       product["updated_at"].(time.Time).Format(time.RFC3339),
 	}, nil
 }
 
@@ -122,14 +165,18 @@ func (s *server) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb
 
 	// Return the product
 	return &pb.Product{
-		Id:            req.Id,
-		Name:          product["name"].(string),
-		Description:   product["description"].(string),
-		Price:         product["price"].(float64),
-		StockQuantity: product["stock_quantity"].(int32),
-		Category:      product["category"].(string),
-		CreatedAt:     product["created_at"].(primitive.DateTime).Time().Format(time.RFC3339),
-		UpdatedAt:     product["updated_at"].(primitive.DateTime).Time().Format(time.RFC3339),
+		Id:                  req.Id,
+		Name:                product["name"].(string),
+		Description:         product["description"].(string),
+		Price:               priceFromDoc(product["price"]),
+		StockQuantity:       product["stock_quantity"].(int32),
+		Category:            product["category"].(string),
+		Parcel:              parcelFromDoc(product["parcel"]),
+		BackorderPolicy:     pb.BackorderPolicy(int32FromDoc(product["backorder_policy"])),
+		BackorderLimit:      int32FromDoc(product["backorder_limit"]),
+		BackorderedQuantity: int32FromDoc(product["backordered_quantity"]),
+		CreatedAt:           product["created_at"].(primitive.DateTime).Time().Format(time.RFC3339),
+		UpdatedAt:           product["updated_at"].(primitive.DateTime).Time().Format(time.RFC3339),
 	}, nil
 }
 
@@ -144,17 +191,38 @@ func (s *server) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest
 		return nil, status.Error(codes.InvalidArgument, "invalid product id")
 	}
 
-	// Create update document
-	update := bson.M{
-		"$set": bson.M{
-			"name":        req.Name,
-			"description": req.Description,
-			"price":       req.Price,
-			"category":    req.Category,
-			"updated_at":  time.Now().UTC(),
-		},
+	if err := validateBackorderPolicy(req.GetBackorderPolicy(), req.GetBackorderLimit()); err != nil {
+		return nil, err
 	}
 
+	// Create update document, leaving the price, parcel and backorder settings alone
+	// when none is sent
+	set := bson.M{
+		"name":        req.Name,
+		"description": req.Description,
+		"category":    req.Category,
+		"updated_at":  time.Now().UTC(),
+	}
+	if req.BackorderPolicy != nil {
+		set["backorder_policy"] = int32(*req.BackorderPolicy)
+	}
+	if req.BackorderLimit != nil {
+		set["backorder_limit"] = *req.BackorderLimit
+	}
+	if req.Price != nil {
+		if err := validatePrice(req.Price); err != nil {
+			return nil, err
+		}
+		set["price"] = priceDoc(req.Price)
+	}
+	if req.Parcel != nil {
+		if err := validateParcel(req.Parcel); err != nil {
+			return nil, err
+		}
+		set["parcel"] = parcelDoc(req.Parcel)
+	}
+	update := bson.M{"$set": set}
+
 	// Find and update the product
 	var updatedProduct bson.M
 	err = s.db.Collection("products").FindOneAndUpdate(
@@ -173,14 +241,18 @@ func (s *server) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest
 
 	// Return the updated product
 	return &pb.Product{
-		Id:            req.Id,
-		Name:          updatedProduct["name"].(string),
-		Description:   updatedProduct["description"].(string),
-		Price:         updatedProduct["price"].(float64),
-		StockQuantity: updatedProduct["stock_quantity"].(int32),
-		Category:      updatedProduct["category"].(string),
-		CreatedAt:     updatedProduct["created_at"].(primitive.DateTime).Time().Format(time.RFC3339),
-		UpdatedAt:     updatedProduct["updated_at"].(primitive.DateTime).Time().Format(time.RFC3339),
+		Id:                  req.Id,
+		Name:                updatedProduct["name"].(string),
+		Description:         updatedProduct["description"].(string),
+		Price:               priceFromDoc(updatedProduct["price"]),
+		StockQuantity:       updatedProduct["stock_quantity"].(int32),
+		Category:            updatedProduct["category"].(string),
+		Parcel:              parcelFromDoc(updatedProduct["parcel"]),
+		BackorderPolicy:     pb.BackorderPolicy(int32FromDoc(updatedProduct["backorder_policy"])),
+		BackorderLimit:      int32FromDoc(updatedProduct["backorder_limit"]),
+		BackorderedQuantity: int32FromDoc(updatedProduct["backordered_quantity"]),
+		CreatedAt:           updatedProduct["created_at"].(primitive.DateTime).Time().Format(time.RFC3339),
+		UpdatedAt:           updatedProduct["updated_at"].(primitive.DateTime).Time().Format(time.RFC3339),
 	}, nil
 }
 
@@ -205,55 +277,78 @@ func (s *server) UpdateStock(ctx context.Context, req *pb.UpdateStockRequest) (*
 		},
 	}
 
-	// Find and update the product
+	// Only decrement when enough stock is left, so the check and the update are atomic
+	filter := bson.M{"_id": id}
+	if req.QuantityChange < 0 {
+		filter["stock_quantity"] = bson.M{"$gte": -req.QuantityChange}
+	}
+
+	// Find and update the product. Added stock goes to the oldest backorders first.
 	var updatedProduct bson.M
-	err = s.db.Collection("products").FindOneAndUpdate(
-		ctx,
-		bson.M{"_id": id},
-		update,
-		options.FindOneAndUpdate().SetReturnDocument(options.After),
-	).Decode(&updatedProduct)
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		if req.UpdateKey != "" {
+			applied, err := s.stockUpdateApplied(sc, req.UpdateKey, id, req.QuantityChange)
+			if err != nil {
+				return err
+			}
+			if applied {
+				return s.db.Collection("products").FindOne(sc, bson.M{"_id": id}).Decode(&updatedProduct)
+			}
+			_, err = s.db.Collection("stock_updates").InsertOne(sc, stockUpdateModel{
+				Key:            req.UpdateKey,
+				ProductID:      id.Hex(),
+				QuantityChange: req.QuantityChange,
+				CreatedAt:      time.Now().UTC(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if err := s.db.Collection("products").FindOneAndUpdate(sc, filter, update).Err(); err != nil {
+			return err
+		}
+		if req.QuantityChange > 0 {
+			if err := s.allocateBackorders(sc, id); err != nil {
+				return err
+			}
+		}
+		return s.db.Collection("products").FindOne(sc, bson.M{"_id": id}).Decode(&updatedProduct)
+	})
 
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
+			// Distinguish a missing product from one without enough stock
+			count, countErr := s.db.Collection("products").CountDocuments(ctx, bson.M{"_id": id})
+			if countErr == nil && count > 0 {
+				return nil, status.Error(codes.FailedPrecondition, "insufficient stock")
+			}
 			return nil, status.Error(codes.NotFound, "product not found")
 		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update product stock: %v", err)
 	}
 
-	// Check if stock went negative
-	if updatedProduct["stock_quantity"].(int32) < 0 {
-		// Revert the change
-		_, err = s.db.Collection("products").UpdateOne(
-			ctx,
-			bson.M{"_id": id},
-			bson.M{
-				"$inc": bson.M{
-					"stock_quantity": -req.QuantityChange,
-				},
-			},
-		)
-		return nil, status.Error(codes.FailedPrecondition, "insufficient stock")
-	}
-
 	// Return the updated product
 	return &pb.Product{
-		Id:            req.Id,
-		Name:          updatedProduct["name"].(string),
-		Description:   updatedProduct["description"].(string),
-		Price:         updatedProduct["price"].(float64),
-		StockQuantity: updatedProduct["stock_quantity"].(int32),
-		Category:      updatedProduct["category"].(string),
-		CreatedAt:     updatedProduct["created_at"].(primitive.DateTime).Time().Format(time.RFC3339),
-		UpdatedAt:     updatedProduct["updated_at"].(primitive.DateTime).Time().Format(time.RFC3339),
+		Id:                  req.Id,
+		Name:                updatedProduct["name"].(string),
+		Description:         updatedProduct["description"].(string),
+		Price:               priceFromDoc(updatedProduct["price"]),
+		StockQuantity:       updatedProduct["stock_quantity"].(int32),
+		Category:            updatedProduct["category"].(string),
+		Parcel:              parcelFromDoc(updatedProduct["parcel"]),
+		BackorderPolicy:     pb.BackorderPolicy(int32FromDoc(updatedProduct["backorder_policy"])),
+		BackorderLimit:      int32FromDoc(updatedProduct["backorder_limit"]),
+		BackorderedQuantity: int32FromDoc(updatedProduct["backordered_quantity"]),
+		CreatedAt:           updatedProduct["created_at"].(primitive.DateTime).Time().Format(time.RFC3339),
+		UpdatedAt:           updatedProduct["updated_at"].(primitive.DateTime).Time().Format(time.RFC3339),
 	}, nil
 }
 
 func (s *server) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
 	// Set default values for pagination
-	if req.Page < 1 {
-		req.Page = 1
-	}
 	if req.Limit < 1 || req.Limit > 100 {
 		req.Limit = 10
 	}
@@ -264,22 +359,35 @@ func (s *server) ListProducts(ctx context.Context, req *pb.ListProductsRequest)
 		filter["category"] = req.Category
 	}
 
-	// Calculate skip value for pagination
-	skip := (req.Page - 1) * req.Limit
+	// Get total count, only when asked for or for clients paging by page number
+	var total int64
+	var err error
+	if req.IncludeTotal || (req.PageToken == "" && req.Page > 0) {
+		total, err = s.db.Collection("products").CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to count products: %v", err)
+		}
+	}
 
-	// Get total count
-	total, err := s.db.Collection("products").CountDocuments(ctx, filter)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to count products: %v", err)
+	// Continue after the page token, or skip to the requested page. One product more
+	// than the limit is read to tell whether there is a next page.
+	query := "category=" + req.Category
+	findOptions := options.Find().
+		SetLimit(int64(req.Limit) + 1).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if req.PageToken != "" {
+		token, err := decodePageToken(req.PageToken, query)
+		if err != nil {
+			return nil, err
+		}
+		filter = bson.M{"$and": bson.A{filter,
+			afterFilter("created_at", -1, primitive.DateTime(token.Value), token.ID)}}
+	} else if req.Page > 1 {
+		findOptions.SetSkip(int64((req.Page - 1) * req.Limit))
 	}
 
 	// Find products
-	cursor, err := s.db.Collection("products").Find(ctx, filter,
-		options.Find().
-			SetSkip(int64(skip)).
-			SetLimit(int64(req.Limit)).
-			SetSort(bson.M{"created_at": -1}),
-	)
+	cursor, err := s.db.Collection("products").Find(ctx, filter, findOptions)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list products: %v", err)
 	}
@@ -287,26 +395,40 @@ func (s *server) ListProducts(ctx context.Context, req *pb.ListProductsRequest)
 
 	// Process results
 	products := make([]*pb.Product, 0)
+	var last bson.M
+	var nextPageToken string
 	for cursor.Next(ctx) {
+		if len(products) == int(req.Limit) {
+			nextPageToken = encodePageToken(query,
+				int64(last["created_at"].(primitive.DateTime)), last["_id"].(primitive.ObjectID))
+			break
+		}
+
 		var product bson.M
 		if err := cursor.Decode(&product); err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to decode product: %v", err)
 		}
+		last = product
 
 		products = append(products, &pb.Product{
-			Id:            product["_id"].(primitive.ObjectID).Hex(),
-			Name:          product["name"].(string),
-			Description:   product["description"].(string),
-			Price:         product["price"].(float64),
-			StockQuantity: product["stock_quantity"].(int32),
-			Category:      product["category"].(string),
-			CreatedAt:     product["created_at"].(primitive.DateTime).Time().Format(time.RFC3339),
-			UpdatedAt:     product["updated_at"].(primitive.DateTime).Time().Format(time.RFC3339),
+			Id:                  product["_id"].(primitive.ObjectID).Hex(),
+			Name:                product["name"].(string),
+			Description:         product["description"].(string),
+			Price:               priceFromDoc(product["price"]),
+			StockQuantity:       product["stock_quantity"].(int32),
+			Category:            product["category"].(string),
+			Parcel:              parcelFromDoc(product["parcel"]),
+			BackorderPolicy:     pb.BackorderPolicy(int32FromDoc(product["backorder_policy"])),
+			BackorderLimit:      int32FromDoc(product["backorder_limit"]),
+			BackorderedQuantity: int32FromDoc(product["backordered_quantity"]),
+			CreatedAt:           product["created_at"].(primitive.DateTime).Time().Format(time.RFC3339),
+			UpdatedAt:           product["updated_at"].(primitive.DateTime).Time().Format(time.RFC3339),
 		})
 	}
 
 	return &pb.ListProductsResponse{
-		Products: products,
-		Total:    int32(total),
+		Products:      products,
+		Total:         int32(total),
+		NextPageToken: nextPageToken,
 	}, nil
 }
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// Prices are stored as {units_minor, currency}: an int64 count of the minor unit of the
+// currency, e.g. cents for USD.
+
+// legacyCurrency is the currency of prices stored as floats before they became Money.
+const legacyCurrency = "USD"
+
+// validatePrice checks a price sent by a client.
+func validatePrice(price *pb.Money) error {
+	if price.UnitsMinor < 0 {
+		return status.Error(codes.InvalidArgument, "price cannot be negative")
+	}
+	if len(price.Currency) != 3 {
+		return status.Error(codes.InvalidArgument, "price currency must be a three-letter ISO 4217 code")
+	}
+	for _, c := range price.Currency {
+		if c < 'A' || c > 'Z' {
+			return status.Error(codes.InvalidArgument, "price currency must be a three-letter ISO 4217 code")
+		}
+	}
+	return nil
+}
+
+// priceDoc returns the document a price is stored as.
+func priceDoc(price *pb.Money) bson.M {
+	return bson.M{"units_minor": price.UnitsMinor, "currency": price.Currency}
+}
+
+// priceFromDoc converts the stored price of a product.
+func priceFromDoc(v interface{}) *pb.Money {
+	doc, ok := v.(bson.M)
+	if !ok {
+		return nil
+	}
+	units, _ := doc["units_minor"].(int64)
+	currency, _ := doc["currency"].(string)
+	return &pb.Money{UnitsMinor: units, Currency: currency}
+}
+
+// migrateLegacyPrices converts prices stored as floats in major units by earlier
+// versions to Money. Each update is conditional on the old price, so the migration
+// can be re-run, and run by several replicas at once. It returns the number of
+// products converted.
+func migrateLegacyPrices(ctx context.Context, db *mongo.Database) (int, error) {
+	coll := db.Collection("products")
+	cursor, err := coll.Find(ctx, bson.M{"price": bson.M{"$type": "number"}})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	migrated := 0
+	for cursor.Next(ctx) {
+		var product struct {
+			ID    interface{} `bson:"_id"`
+			Price float64     `bson:"price"`
+		}
+		if err := cursor.Decode(&product); err != nil {
+			return migrated, err
+		}
+
+		units, err := legacyMinorUnits(product.Price)
+		if err != nil {
+			return migrated, err
+		}
+
+		_, err = coll.UpdateOne(ctx,
+			bson.M{"_id": product.ID, "price": product.Price},
+			bson.M{"$set": bson.M{"price": priceDoc(&pb.Money{UnitsMinor: units, Currency: legacyCurrency})}},
+		)
+		if err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+	return migrated, cursor.Err()
+}
+
+// legacyMinorUnits converts a float amount in major units of legacyCurrency to minor
+// units, rounding half away from zero. The float is read as the shortest decimal that
+// represents it, so 0.285 is 29 cents rather than the 28 its binary value
+// 0.28499999... would round to.
+func legacyMinorUnits(amount float64) (int64, error) {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	if !ok {
+		return 0, fmt.Errorf("invalid price %v", amount)
+	}
+	r.Mul(r, big.NewRat(100, 1))
+
+	half := big.NewRat(1, 2)
+	if r.Sign() < 0 {
+		half.Neg(half)
+	}
+	r.Add(r, half)
+
+	// Quo truncates toward zero
+	q := new(big.Int).Quo(r.Num(), r.Denom())
+	if !q.IsInt64() {
+		return 0, fmt.Errorf("price %v is out of range", amount)
+	}
+	return q.Int64(), nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "github.com/order-management/proto"
+)
+
+// validateParcel checks the shipping weight and size of a product. Zero means unknown.
+func validateParcel(parcel *pb.Parcel) error {
+	if parcel.WeightGrams < 0 || parcel.LengthMm < 0 || parcel.WidthMm < 0 || parcel.HeightMm < 0 {
+		return status.Error(codes.InvalidArgument, "parcel weight and dimensions cannot be negative")
+	}
+	return nil
+}
+
+// parcelDoc returns the document a parcel is stored as.
+func parcelDoc(parcel *pb.Parcel) bson.M {
+	return bson.M{
+		"weight_grams": parcel.WeightGrams,
+		"length_mm":    parcel.LengthMm,
+		"width_mm":     parcel.WidthMm,
+		"height_mm":    parcel.HeightMm,
+	}
+}
+
+// parcelFromDoc converts the stored parcel of a product. Products created before
+// parcels were recorded have none.
+func parcelFromDoc(v interface{}) *pb.Parcel {
+	doc, ok := v.(bson.M)
+	if !ok {
+		return nil
+	}
+	weight, _ := doc["weight_grams"].(int32)
+	length, _ := doc["length_mm"].(int32)
+	width, _ := doc["width_mm"].(int32)
+	height, _ := doc["height_mm"].(int32)
+	return &pb.Parcel{WeightGrams: weight, LengthMm: length, WidthMm: width, HeightMm: height}
+}
@@ -21,34 +21,2390 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// OrderStatus is the lifecycle state of an order. Allowed transitions are
+// enforced by the order service.
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_UNSPECIFIED       OrderStatus = 0
+	OrderStatus_ORDER_STATUS_PENDING           OrderStatus = 1
+	OrderStatus_ORDER_STATUS_CONFIRMED         OrderStatus = 2
+	OrderStatus_ORDER_STATUS_PAID              OrderStatus = 3
+	OrderStatus_ORDER_STATUS_SHIPPED           OrderStatus = 4
+	OrderStatus_ORDER_STATUS_DELIVERED         OrderStatus = 5
+	OrderStatus_ORDER_STATUS_CANCELLED         OrderStatus = 6
+	OrderStatus_ORDER_STATUS_REFUNDED          OrderStatus = 7
+	OrderStatus_ORDER_STATUS_FAILED            OrderStatus = 8 // Order placement failed and was rolled back
+	OrderStatus_ORDER_STATUS_PARTIALLY_SHIPPED OrderStatus = 9 // Some, but not all, items have shipped
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNSPECIFIED",
+		1: "ORDER_STATUS_PENDING",
+		2: "ORDER_STATUS_CONFIRMED",
+		3: "ORDER_STATUS_PAID",
+		4: "ORDER_STATUS_SHIPPED",
+		5: "ORDER_STATUS_DELIVERED",
+		6: "ORDER_STATUS_CANCELLED",
+		7: "ORDER_STATUS_REFUNDED",
+		8: "ORDER_STATUS_FAILED",
+		9: "ORDER_STATUS_PARTIALLY_SHIPPED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED":       0,
+		"ORDER_STATUS_PENDING":           1,
+		"ORDER_STATUS_CONFIRMED":         2,
+		"ORDER_STATUS_PAID":              3,
+		"ORDER_STATUS_SHIPPED":           4,
+		"ORDER_STATUS_DELIVERED":         5,
+		"ORDER_STATUS_CANCELLED":         6,
+		"ORDER_STATUS_REFUNDED":          7,
+		"ORDER_STATUS_FAILED":            8,
+		"ORDER_STATUS_PARTIALLY_SHIPPED": 9,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_order_proto_enumTypes[0]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{0}
+}
+
+type ReturnStatus int32
+
+const (
+	ReturnStatus_RETURN_STATUS_UNSPECIFIED ReturnStatus = 0
+	ReturnStatus_RETURN_STATUS_REQUESTED   ReturnStatus = 1
+	ReturnStatus_RETURN_STATUS_APPROVED    ReturnStatus = 2
+	ReturnStatus_RETURN_STATUS_REJECTED    ReturnStatus = 3
+	ReturnStatus_RETURN_STATUS_RECEIVED    ReturnStatus = 4
+)
+
+// Enum value maps for ReturnStatus.
+var (
+	ReturnStatus_name = map[int32]string{
+		0: "RETURN_STATUS_UNSPECIFIED",
+		1: "RETURN_STATUS_REQUESTED",
+		2: "RETURN_STATUS_APPROVED",
+		3: "RETURN_STATUS_REJECTED",
+		4: "RETURN_STATUS_RECEIVED",
+	}
+	ReturnStatus_value = map[string]int32{
+		"RETURN_STATUS_UNSPECIFIED": 0,
+		"RETURN_STATUS_REQUESTED":   1,
+		"RETURN_STATUS_APPROVED":    2,
+		"RETURN_STATUS_REJECTED":    3,
+		"RETURN_STATUS_RECEIVED":    4,
+	}
+)
+
+func (x ReturnStatus) Enum() *ReturnStatus {
+	p := new(ReturnStatus)
+	*p = x
+	return p
+}
+
+func (x ReturnStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReturnStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_proto_enumTypes[1].Descriptor()
+}
+
+func (ReturnStatus) Type() protoreflect.EnumType {
+	return &file_order_proto_enumTypes[1]
+}
+
+func (x ReturnStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReturnStatus.Descriptor instead.
+func (ReturnStatus) EnumDescriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{1}
+}
+
+type InvoiceFormat int32
+
+const (
+	InvoiceFormat_INVOICE_FORMAT_UNSPECIFIED InvoiceFormat = 0
+	InvoiceFormat_INVOICE_FORMAT_HTML        InvoiceFormat = 1
+	InvoiceFormat_INVOICE_FORMAT_PDF         InvoiceFormat = 2
+)
+
+// Enum value maps for InvoiceFormat.
+var (
+	InvoiceFormat_name = map[int32]string{
+		0: "INVOICE_FORMAT_UNSPECIFIED",
+		1: "INVOICE_FORMAT_HTML",
+		2: "INVOICE_FORMAT_PDF",
+	}
+	InvoiceFormat_value = map[string]int32{
+		"INVOICE_FORMAT_UNSPECIFIED": 0,
+		"INVOICE_FORMAT_HTML":        1,
+		"INVOICE_FORMAT_PDF":         2,
+	}
+)
+
+func (x InvoiceFormat) Enum() *InvoiceFormat {
+	p := new(InvoiceFormat)
+	*p = x
+	return p
+}
+
+func (x InvoiceFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InvoiceFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_proto_enumTypes[2].Descriptor()
+}
+
+func (InvoiceFormat) Type() protoreflect.EnumType {
+	return &file_order_proto_enumTypes[2]
+}
+
+func (x InvoiceFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InvoiceFormat.Descriptor instead.
+func (InvoiceFormat) EnumDescriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{2}
+}
+
+type ImportFormat int32
+
+const (
+	ImportFormat_IMPORT_FORMAT_UNSPECIFIED ImportFormat = 0
+	ImportFormat_IMPORT_FORMAT_CSV         ImportFormat = 1
+	ImportFormat_IMPORT_FORMAT_JSON        ImportFormat = 2
+)
+
+// Enum value maps for ImportFormat.
+var (
+	ImportFormat_name = map[int32]string{
+		0: "IMPORT_FORMAT_UNSPECIFIED",
+		1: "IMPORT_FORMAT_CSV",
+		2: "IMPORT_FORMAT_JSON",
+	}
+	ImportFormat_value = map[string]int32{
+		"IMPORT_FORMAT_UNSPECIFIED": 0,
+		"IMPORT_FORMAT_CSV":         1,
+		"IMPORT_FORMAT_JSON":        2,
+	}
+)
+
+func (x ImportFormat) Enum() *ImportFormat {
+	p := new(ImportFormat)
+	*p = x
+	return p
+}
+
+func (x ImportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_proto_enumTypes[3].Descriptor()
+}
+
+func (ImportFormat) Type() protoreflect.EnumType {
+	return &file_order_proto_enumTypes[3]
+}
+
+func (x ImportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportFormat.Descriptor instead.
+func (ImportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{3}
+}
+
 type Order struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId             string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items              []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Status             OrderStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=proto.OrderStatus" json:"status,omitempty"`
+	TotalAmount        *Money                 `protobuf:"bytes,10,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	CreatedAt          string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CancellationReason string                 `protobuf:"bytes,8,opt,name=cancellation_reason,json=cancellationReason,proto3" json:"cancellation_reason,omitempty"`
+	StatusHistory      []*StatusChange        `protobuf:"bytes,9,rep,name=status_history,json=statusHistory,proto3" json:"status_history,omitempty"`
+	Discounts          []*AppliedDiscount     `protobuf:"bytes,11,rep,name=discounts,proto3" json:"discounts,omitempty"` // Already deducted from total_amount
+	Subtotal           *Money                 `protobuf:"bytes,12,opt,name=subtotal,proto3" json:"subtotal,omitempty"`   // Items less discounts, as priced in the catalog
+	TaxLines           []*TaxLine             `protobuf:"bytes,13,rep,name=tax_lines,json=taxLines,proto3" json:"tax_lines,omitempty"`
+	GrandTotal         *Money                 `protobuf:"bytes,14,opt,name=grand_total,json=grandTotal,proto3" json:"grand_total,omitempty"` // Subtotal, exclusive taxes and shipping; equals total_amount
+	ShippingAddress    *Address               `protobuf:"bytes,15,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	Shipping           *ShippingOption        `protobuf:"bytes,16,opt,name=shipping,proto3" json:"shipping,omitempty"`                                   // The chosen option, at the cost quoted when ordering
+	SubscriptionId     string                 `protobuf:"bytes,17,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"` // Set for orders placed by a subscription
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Order) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Order) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *Order) GetTotalAmount() *Money {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return nil
+}
+
+func (x *Order) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetCancellationReason() string {
+	if x != nil {
+		return x.CancellationReason
+	}
+	return ""
+}
+
+func (x *Order) GetStatusHistory() []*StatusChange {
+	if x != nil {
+		return x.StatusHistory
+	}
+	return nil
+}
+
+func (x *Order) GetDiscounts() []*AppliedDiscount {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
+}
+
+func (x *Order) GetSubtotal() *Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *Order) GetTaxLines() []*TaxLine {
+	if x != nil {
+		return x.TaxLines
+	}
+	return nil
+}
+
+func (x *Order) GetGrandTotal() *Money {
+	if x != nil {
+		return x.GrandTotal
+	}
+	return nil
+}
+
+func (x *Order) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *Order) GetShipping() *ShippingOption {
+	if x != nil {
+		return x.Shipping
+	}
+	return nil
+}
+
+func (x *Order) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+type Address struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	TotalAmount   float64                `protobuf:"fixed64,5,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Line1         string                 `protobuf:"bytes,1,opt,name=line1,proto3" json:"line1,omitempty"`
+	Line2         string                 `protobuf:"bytes,2,opt,name=line2,proto3" json:"line2,omitempty"`
+	City          string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	Region        string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"` // State or province code, e.g. "CA"
+	PostalCode    string                 `protobuf:"bytes,5,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Country       string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"` // ISO 3166-1 alpha-2 code, e.g. "US"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_order_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Address) GetLine1() string {
+	if x != nil {
+		return x.Line1
+	}
+	return ""
+}
+
+func (x *Address) GetLine2() string {
+	if x != nil {
+		return x.Line2
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+// TaxLine is one tax charged on an order, summed over the items it applies to.
+type TaxLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Jurisdiction  string                 `protobuf:"bytes,2,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"` // Country, or country and region, e.g. "US-CA"
+	Rate          string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`                 // Decimal fraction, e.g. "0.0725"
+	TaxableAmount *Money                 `protobuf:"bytes,4,opt,name=taxable_amount,json=taxableAmount,proto3" json:"taxable_amount,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Inclusive     bool                   `protobuf:"varint,6,opt,name=inclusive,proto3" json:"inclusive,omitempty"` // Included in the item prices rather than added to them
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaxLine) Reset() {
+	*x = TaxLine{}
+	mi := &file_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaxLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxLine) ProtoMessage() {}
+
+func (x *TaxLine) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxLine.ProtoReflect.Descriptor instead.
+func (*TaxLine) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TaxLine) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TaxLine) GetJurisdiction() string {
+	if x != nil {
+		return x.Jurisdiction
+	}
+	return ""
+}
+
+func (x *TaxLine) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *TaxLine) GetTaxableAmount() *Money {
+	if x != nil {
+		return x.TaxableAmount
+	}
+	return nil
+}
+
+func (x *TaxLine) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *TaxLine) GetInclusive() bool {
+	if x != nil {
+		return x.Inclusive
+	}
+	return false
+}
+
+// AppliedDiscount is the part of a promotion's discount that went to one order item.
+type AppliedDiscount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromotionId   string                 `protobuf:"bytes,1,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // Empty for promotions applied without a coupon
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ProductId     string                 `protobuf:"bytes,4,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppliedDiscount) Reset() {
+	*x = AppliedDiscount{}
+	mi := &file_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppliedDiscount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppliedDiscount) ProtoMessage() {}
+
+func (x *AppliedDiscount) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppliedDiscount.ProtoReflect.Descriptor instead.
+func (*AppliedDiscount) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AppliedDiscount) GetPromotionId() string {
+	if x != nil {
+		return x.PromotionId
+	}
+	return ""
+}
+
+func (x *AppliedDiscount) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AppliedDiscount) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AppliedDiscount) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AppliedDiscount) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// StatusChange records a single status transition of an order.
+type StatusChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldStatus     OrderStatus            `protobuf:"varint,1,opt,name=old_status,json=oldStatus,proto3,enum=proto.OrderStatus" json:"old_status,omitempty"` // Unspecified for the entry created with the order
+	NewStatus     OrderStatus            `protobuf:"varint,2,opt,name=new_status,json=newStatus,proto3,enum=proto.OrderStatus" json:"new_status,omitempty"`
+	ActorId       string                 `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // User id from the caller's JWT, or "system"
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	ChangedAt     string                 `protobuf:"bytes,5,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusChange) Reset() {
+	*x = StatusChange{}
+	mi := &file_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusChange) ProtoMessage() {}
+
+func (x *StatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusChange.ProtoReflect.Descriptor instead.
+func (*StatusChange) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StatusChange) GetOldStatus() OrderStatus {
+	if x != nil {
+		return x.OldStatus
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *StatusChange) GetNewStatus() OrderStatus {
+	if x != nil {
+		return x.NewStatus
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *StatusChange) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *StatusChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StatusChange) GetChangedAt() string {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return ""
+}
+
+type OrderHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Entries       []*StatusChange        `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderHistory) Reset() {
+	*x = OrderHistory{}
+	mi := &file_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderHistory) ProtoMessage() {}
+
+func (x *OrderHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderHistory.ProtoReflect.Descriptor instead.
+func (*OrderHistory) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OrderHistory) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderHistory) GetEntries() []*StatusChange {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type OrderItem struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ProductId           string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity            int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price               *Money                 `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`                                                         // Unit price snapshotted from the catalog at creation; ignored on input
+	BackorderedQuantity int32                  `protobuf:"varint,5,opt,name=backordered_quantity,json=backorderedQuantity,proto3" json:"backordered_quantity,omitempty"` // Units still waiting for stock; ignored on input
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItem) ProtoMessage() {}
+
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OrderItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderItem) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *OrderItem) GetBackorderedQuantity() int32 {
+	if x != nil {
+		return x.BackorderedQuantity
+	}
+	return 0
+}
+
+type CreateOrderRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items           []*OrderItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	CouponCodes     []string               `protobuf:"bytes,3,rep,name=coupon_codes,json=couponCodes,proto3" json:"coupon_codes,omitempty"`
+	ShippingAddress *Address               `protobuf:"bytes,4,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	ShippingOption  string                 `protobuf:"bytes,5,opt,name=shipping_option,json=shippingOption,proto3" json:"shipping_option,omitempty"` // An option id from QuoteShipping, e.g. "standard"
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetCouponCodes() []string {
+	if x != nil {
+		return x.CouponCodes
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetShippingOption() string {
+	if x != nil {
+		return x.ShippingOption
+	}
+	return ""
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=proto.OrderStatus" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderRequest) Reset() {
+	*x = UpdateOrderRequest{}
+	mi := &file_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderRequest) ProtoMessage() {}
+
+func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateOrderRequest) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancelOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Statuses      []OrderStatus          `protobuf:"varint,4,rep,packed,name=statuses,proto3,enum=proto.OrderStatus" json:"statuses,omitempty"` // Orders in any of these statuses
+	CreatedAfter  string                 `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`    // RFC 3339; bounds are inclusive
+	CreatedBefore string                 `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	UpdatedAfter  string                 `protobuf:"bytes,7,opt,name=updated_after,json=updatedAfter,proto3" json:"updated_after,omitempty"`
+	UpdatedBefore string                 `protobuf:"bytes,8,opt,name=updated_before,json=updatedBefore,proto3" json:"updated_before,omitempty"`
+	MinTotal      *Money                 `protobuf:"bytes,9,opt,name=min_total,json=minTotal,proto3" json:"min_total,omitempty"` // Total amount bounds, inclusive, in the same currency
+	MaxTotal      *Money                 `protobuf:"bytes,10,opt,name=max_total,json=maxTotal,proto3" json:"max_total,omitempty"`
+	ProductId     string                 `protobuf:"bytes,11,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`           // Orders containing this product
+	SortBy        string                 `protobuf:"bytes,12,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`                    // "created_at" (default), "updated_at" or "total_amount"
+	SortOrder     string                 `protobuf:"bytes,13,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`           // "desc" (default) or "asc"
+	PageToken     string                 `protobuf:"bytes,14,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`           // next_page_token of the previous page; page is ignored
+	IncludeTotal  bool                   `protobuf:"varint,15,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"` // Count the matching orders even when paging by token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetStatuses() []OrderStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetCreatedBefore() string {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetUpdatedAfter() string {
+	if x != nil {
+		return x.UpdatedAfter
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetUpdatedBefore() string {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetMinTotal() *Money {
+	if x != nil {
+		return x.MinTotal
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetMaxTotal() *Money {
+	if x != nil {
+		return x.MaxTotal
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`                                       // Only set when counted, see ListOrdersRequest
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Shipment is one box sent for an order. An order may ship in several shipments.
+type Shipment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId        string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Carrier        string                 `protobuf:"bytes,3,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,4,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	Items          []*ShipmentItem        `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	ShippedAt      string                 `protobuf:"bytes,6,opt,name=shipped_at,json=shippedAt,proto3" json:"shipped_at,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Shipment) Reset() {
+	*x = Shipment{}
+	mi := &file_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shipment) ProtoMessage() {}
+
+func (x *Shipment) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shipment.ProtoReflect.Descriptor instead.
+func (*Shipment) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Shipment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Shipment) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Shipment) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *Shipment) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *Shipment) GetItems() []*ShipmentItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Shipment) GetShippedAt() string {
+	if x != nil {
+		return x.ShippedAt
+	}
+	return ""
+}
+
+func (x *Shipment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ShipmentItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentItem) Reset() {
+	*x = ShipmentItem{}
+	mi := &file_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentItem) ProtoMessage() {}
+
+func (x *ShipmentItem) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentItem.ProtoReflect.Descriptor instead.
+func (*ShipmentItem) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ShipmentItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ShipmentItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type CreateShipmentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderId        string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Carrier        string                 `protobuf:"bytes,2,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,3,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	Items          []*ShipmentItem        `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	ShippedAt      string                 `protobuf:"bytes,5,opt,name=shipped_at,json=shippedAt,proto3" json:"shipped_at,omitempty"` // RFC 3339; defaults to now
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateShipmentRequest) Reset() {
+	*x = CreateShipmentRequest{}
+	mi := &file_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShipmentRequest) ProtoMessage() {}
+
+func (x *CreateShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShipmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateShipmentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetItems() []*ShipmentItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateShipmentRequest) GetShippedAt() string {
+	if x != nil {
+		return x.ShippedAt
+	}
+	return ""
+}
+
+type ListShipmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShipmentsRequest) Reset() {
+	*x = ListShipmentsRequest{}
+	mi := &file_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShipmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShipmentsRequest) ProtoMessage() {}
+
+func (x *ListShipmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShipmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListShipmentsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListShipmentsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ListShipmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipments     []*Shipment            `protobuf:"bytes,1,rep,name=shipments,proto3" json:"shipments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShipmentsResponse) Reset() {
+	*x = ListShipmentsResponse{}
+	mi := &file_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShipmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShipmentsResponse) ProtoMessage() {}
+
+func (x *ListShipmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShipmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListShipmentsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListShipmentsResponse) GetShipments() []*Shipment {
+	if x != nil {
+		return x.Shipments
+	}
+	return nil
+}
+
+// ReturnAuthorization (RMA) tracks the return of order items from request to receipt.
+type ReturnAuthorization struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        ReturnStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=proto.ReturnStatus" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Items         []*ReturnItem          `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	ReviewNote    string                 `protobuf:"bytes,6,opt,name=review_note,json=reviewNote,proto3" json:"review_note,omitempty"`
+	RequestedBy   string                 `protobuf:"bytes,7,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	ReviewedBy    string                 `protobuf:"bytes,8,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ReceivedAt    string                 `protobuf:"bytes,11,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnAuthorization) Reset() {
+	*x = ReturnAuthorization{}
+	mi := &file_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnAuthorization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnAuthorization) ProtoMessage() {}
+
+func (x *ReturnAuthorization) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnAuthorization.ProtoReflect.Descriptor instead.
+func (*ReturnAuthorization) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReturnAuthorization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReturnAuthorization) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReturnAuthorization) GetStatus() ReturnStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReturnStatus_RETURN_STATUS_UNSPECIFIED
+}
+
+func (x *ReturnAuthorization) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReturnAuthorization) GetItems() []*ReturnItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReturnAuthorization) GetReviewNote() string {
+	if x != nil {
+		return x.ReviewNote
+	}
+	return ""
+}
+
+func (x *ReturnAuthorization) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *ReturnAuthorization) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *ReturnAuthorization) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ReturnAuthorization) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *ReturnAuthorization) GetReceivedAt() string {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return ""
+}
+
+type ReturnItem struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ProductId        string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity         int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // Quantity authorized for return
+	ReceivedQuantity int32                  `protobuf:"varint,3,opt,name=received_quantity,json=receivedQuantity,proto3" json:"received_quantity,omitempty"`
+	Resellable       bool                   `protobuf:"varint,4,opt,name=resellable,proto3" json:"resellable,omitempty"` // Received goods can go back into stock
+	Restocked        bool                   `protobuf:"varint,5,opt,name=restocked,proto3" json:"restocked,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReturnItem) Reset() {
+	*x = ReturnItem{}
+	mi := &file_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnItem) ProtoMessage() {}
+
+func (x *ReturnItem) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnItem.ProtoReflect.Descriptor instead.
+func (*ReturnItem) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReturnItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReturnItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReturnItem) GetReceivedQuantity() int32 {
+	if x != nil {
+		return x.ReceivedQuantity
+	}
+	return 0
+}
+
+func (x *ReturnItem) GetResellable() bool {
+	if x != nil {
+		return x.Resellable
+	}
+	return false
+}
+
+func (x *ReturnItem) GetRestocked() bool {
+	if x != nil {
+		return x.Restocked
+	}
+	return false
+}
+
+type RequestReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         []*ReturnItem          `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"` // Only product_id and quantity are read
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestReturnRequest) Reset() {
+	*x = RequestReturnRequest{}
+	mi := &file_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestReturnRequest) ProtoMessage() {}
+
+func (x *RequestReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestReturnRequest.ProtoReflect.Descriptor instead.
+func (*RequestReturnRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RequestReturnRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RequestReturnRequest) GetItems() []*ReturnItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RequestReturnRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListReturnsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsRequest) Reset() {
+	*x = ListReturnsRequest{}
+	mi := &file_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsRequest) ProtoMessage() {}
+
+func (x *ListReturnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsRequest.ProtoReflect.Descriptor instead.
+func (*ListReturnsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListReturnsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ListReturnsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Returns       []*ReturnAuthorization `protobuf:"bytes,1,rep,name=returns,proto3" json:"returns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsResponse) Reset() {
+	*x = ListReturnsResponse{}
+	mi := &file_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsResponse) ProtoMessage() {}
+
+func (x *ListReturnsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsResponse.ProtoReflect.Descriptor instead.
+func (*ListReturnsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListReturnsResponse) GetReturns() []*ReturnAuthorization {
+	if x != nil {
+		return x.Returns
+	}
+	return nil
+}
+
+type ReviewReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ReturnId      string                 `protobuf:"bytes,2,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewReturnRequest) Reset() {
+	*x = ReviewReturnRequest{}
+	mi := &file_order_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewReturnRequest) ProtoMessage() {}
+
+func (x *ReviewReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewReturnRequest.ProtoReflect.Descriptor instead.
+func (*ReviewReturnRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReviewReturnRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReviewReturnRequest) GetReturnId() string {
+	if x != nil {
+		return x.ReturnId
+	}
+	return ""
+}
+
+func (x *ReviewReturnRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ReceiveReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ReturnId      string                 `protobuf:"bytes,2,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	Items         []*ReceivedItem        `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveReturnRequest) Reset() {
+	*x = ReceiveReturnRequest{}
+	mi := &file_order_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveReturnRequest) ProtoMessage() {}
+
+func (x *ReceiveReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveReturnRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveReturnRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReceiveReturnRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReceiveReturnRequest) GetReturnId() string {
+	if x != nil {
+		return x.ReturnId
+	}
+	return ""
+}
+
+func (x *ReceiveReturnRequest) GetItems() []*ReceivedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ReceivedItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Resellable    bool                   `protobuf:"varint,3,opt,name=resellable,proto3" json:"resellable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceivedItem) Reset() {
+	*x = ReceivedItem{}
+	mi := &file_order_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceivedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceivedItem) ProtoMessage() {}
+
+func (x *ReceivedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceivedItem.ProtoReflect.Descriptor instead.
+func (*ReceivedItem) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReceivedItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReceivedItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReceivedItem) GetResellable() bool {
+	if x != nil {
+		return x.Resellable
+	}
+	return false
+}
+
+type ShippingOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // "standard", "express" or "pickup"
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Cost          *Money                 `protobuf:"bytes,3,opt,name=cost,proto3" json:"cost,omitempty"`
+	MinDays       int32                  `protobuf:"varint,4,opt,name=min_days,json=minDays,proto3" json:"min_days,omitempty"`
+	MaxDays       int32                  `protobuf:"varint,5,opt,name=max_days,json=maxDays,proto3" json:"max_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShippingOption) Reset() {
+	*x = ShippingOption{}
+	mi := &file_order_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingOption) ProtoMessage() {}
+
+func (x *ShippingOption) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingOption.ProtoReflect.Descriptor instead.
+func (*ShippingOption) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ShippingOption) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ShippingOption) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShippingOption) GetCost() *Money {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
+func (x *ShippingOption) GetMinDays() int32 {
+	if x != nil {
+		return x.MinDays
+	}
+	return 0
+}
+
+func (x *ShippingOption) GetMaxDays() int32 {
+	if x != nil {
+		return x.MaxDays
+	}
+	return 0
+}
+
+type QuoteShippingRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Items           []*OrderItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	ShippingAddress *Address               `protobuf:"bytes,2,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *QuoteShippingRequest) Reset() {
+	*x = QuoteShippingRequest{}
+	mi := &file_order_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteShippingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteShippingRequest) ProtoMessage() {}
+
+func (x *QuoteShippingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteShippingRequest.ProtoReflect.Descriptor instead.
+func (*QuoteShippingRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *QuoteShippingRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *QuoteShippingRequest) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+type ShippingQuote struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Options             []*ShippingOption      `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty"`
+	BillableWeightGrams int32                  `protobuf:"varint,2,opt,name=billable_weight_grams,json=billableWeightGrams,proto3" json:"billable_weight_grams,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ShippingQuote) Reset() {
+	*x = ShippingQuote{}
+	mi := &file_order_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingQuote) ProtoMessage() {}
+
+func (x *ShippingQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingQuote.ProtoReflect.Descriptor instead.
+func (*ShippingQuote) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ShippingQuote) GetOptions() []*ShippingOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ShippingQuote) GetBillableWeightGrams() int32 {
+	if x != nil {
+		return x.BillableWeightGrams
+	}
+	return 0
+}
+
+// AddOrderItemRequest adds quantity units of a product to an order, on top of any
+// already ordered.
+type AddOrderItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddOrderItemRequest) Reset() {
+	*x = AddOrderItemRequest{}
+	mi := &file_order_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddOrderItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddOrderItemRequest) ProtoMessage() {}
+
+func (x *AddOrderItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddOrderItemRequest.ProtoReflect.Descriptor instead.
+func (*AddOrderItemRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AddOrderItemRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AddOrderItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AddOrderItemRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type UpdateOrderItemQuantityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"` // The new quantity of the product, at least 1
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Order) Reset() {
-	*x = Order{}
-	mi := &file_order_proto_msgTypes[0]
+func (x *UpdateOrderItemQuantityRequest) Reset() {
+	*x = UpdateOrderItemQuantityRequest{}
+	mi := &file_order_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Order) String() string {
+func (x *UpdateOrderItemQuantityRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Order) ProtoMessage() {}
+func (*UpdateOrderItemQuantityRequest) ProtoMessage() {}
 
-func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[0]
+func (x *UpdateOrderItemQuantityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,84 +2415,109 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Order.ProtoReflect.Descriptor instead.
-func (*Order) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use UpdateOrderItemQuantityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderItemQuantityRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *Order) GetId() string {
+func (x *UpdateOrderItemQuantityRequest) GetOrderId() string {
 	if x != nil {
-		return x.Id
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *Order) GetUserId() string {
+func (x *UpdateOrderItemQuantityRequest) GetProductId() string {
 	if x != nil {
-		return x.UserId
+		return x.ProductId
 	}
 	return ""
 }
 
-func (x *Order) GetItems() []*OrderItem {
+func (x *UpdateOrderItemQuantityRequest) GetQuantity() int32 {
 	if x != nil {
-		return x.Items
+		return x.Quantity
 	}
-	return nil
+	return 0
 }
 
-func (x *Order) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
+type RemoveOrderItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveOrderItemRequest) Reset() {
+	*x = RemoveOrderItemRequest{}
+	mi := &file_order_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveOrderItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Order) GetTotalAmount() float64 {
+func (*RemoveOrderItemRequest) ProtoMessage() {}
+
+func (x *RemoveOrderItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[31]
 	if x != nil {
-		return x.TotalAmount
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Order) GetCreatedAt() string {
+// Deprecated: Use RemoveOrderItemRequest.ProtoReflect.Descriptor instead.
+func (*RemoveOrderItemRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RemoveOrderItemRequest) GetOrderId() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *Order) GetUpdatedAt() string {
+func (x *RemoveOrderItemRequest) GetProductId() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.ProductId
 	}
 	return ""
 }
 
-type OrderItem struct {
+// GetInvoiceRequest returns the invoice of a delivered order, issuing it on the first
+// request. format picks the document to include, if any.
+type GetInvoiceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Format        InvoiceFormat          `protobuf:"varint,2,opt,name=format,proto3,enum=proto.InvoiceFormat" json:"format,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItem) Reset() {
-	*x = OrderItem{}
-	mi := &file_order_proto_msgTypes[1]
+func (x *GetInvoiceRequest) Reset() {
+	*x = GetInvoiceRequest{}
+	mi := &file_order_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItem) String() string {
+func (x *GetInvoiceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItem) ProtoMessage() {}
+func (*GetInvoiceRequest) ProtoMessage() {}
 
-func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[1]
+func (x *GetInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -147,55 +2528,57 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
-func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use GetInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *OrderItem) GetProductId() string {
+func (x *GetInvoiceRequest) GetOrderId() string {
 	if x != nil {
-		return x.ProductId
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *OrderItem) GetQuantity() int32 {
-	if x != nil {
-		return x.Quantity
-	}
-	return 0
-}
-
-func (x *OrderItem) GetPrice() float64 {
+func (x *GetInvoiceRequest) GetFormat() InvoiceFormat {
 	if x != nil {
-		return x.Price
+		return x.Format
 	}
-	return 0
+	return InvoiceFormat_INVOICE_FORMAT_UNSPECIFIED
 }
 
-type CreateOrderRequest struct {
+// Invoice is issued once per order and never changes afterwards. Numbers have the form
+// INV-<year>-<sequence>, with sequences starting at 1 every year and leaving no gaps.
+type Invoice struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number        string                 `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Total         *Money                 `protobuf:"bytes,5,opt,name=total,proto3" json:"total,omitempty"`
+	IssuedAt      string                 `protobuf:"bytes,6,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	Format        InvoiceFormat          `protobuf:"varint,7,opt,name=format,proto3,enum=proto.InvoiceFormat" json:"format,omitempty"`
+	Document      []byte                 `protobuf:"bytes,8,opt,name=document,proto3" json:"document,omitempty"` // The rendered invoice in format
+	ContentType   string                 `protobuf:"bytes,9,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOrderRequest) Reset() {
-	*x = CreateOrderRequest{}
-	mi := &file_order_proto_msgTypes[2]
+func (x *Invoice) Reset() {
+	*x = Invoice{}
+	mi := &file_order_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOrderRequest) String() string {
+func (x *Invoice) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOrderRequest) ProtoMessage() {}
+func (*Invoice) ProtoMessage() {}
 
-func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[2]
+func (x *Invoice) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -206,92 +2589,100 @@ func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
-func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
+func (*Invoice) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *CreateOrderRequest) GetUserId() string {
+func (x *Invoice) GetId() string {
 	if x != nil {
-		return x.UserId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *CreateOrderRequest) GetItems() []*OrderItem {
+func (x *Invoice) GetNumber() string {
 	if x != nil {
-		return x.Items
+		return x.Number
 	}
-	return nil
+	return ""
 }
 
-type GetOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Invoice) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
 }
 
-func (x *GetOrderRequest) Reset() {
-	*x = GetOrderRequest{}
-	mi := &file_order_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Invoice) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
 }
 
-func (x *GetOrderRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Invoice) GetTotal() *Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
 }
 
-func (*GetOrderRequest) ProtoMessage() {}
+func (x *Invoice) GetIssuedAt() string {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return ""
+}
 
-func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[3]
+func (x *Invoice) GetFormat() InvoiceFormat {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Format
 	}
-	return mi.MessageOf(x)
+	return InvoiceFormat_INVOICE_FORMAT_UNSPECIFIED
 }
 
-// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{3}
+func (x *Invoice) GetDocument() []byte {
+	if x != nil {
+		return x.Document
+	}
+	return nil
 }
 
-func (x *GetOrderRequest) GetId() string {
+func (x *Invoice) GetContentType() string {
 	if x != nil {
-		return x.Id
+		return x.ContentType
 	}
 	return ""
 }
 
-type UpdateOrderRequest struct {
+// ImportOrdersChunk is one piece of an import file. The format and dry_run of the
+// first chunk apply to the whole import.
+type ImportOrdersChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Format        ImportFormat           `protobuf:"varint,1,opt,name=format,proto3,enum=proto.ImportFormat" json:"format,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // Validate every order without importing any
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrderRequest) Reset() {
-	*x = UpdateOrderRequest{}
-	mi := &file_order_proto_msgTypes[4]
+func (x *ImportOrdersChunk) Reset() {
+	*x = ImportOrdersChunk{}
+	mi := &file_order_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderRequest) String() string {
+func (x *ImportOrdersChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderRequest) ProtoMessage() {}
+func (*ImportOrdersChunk) ProtoMessage() {}
 
-func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[4]
+func (x *ImportOrdersChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -302,49 +2693,60 @@ func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ImportOrdersChunk.ProtoReflect.Descriptor instead.
+func (*ImportOrdersChunk) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *UpdateOrderRequest) GetId() string {
+func (x *ImportOrdersChunk) GetFormat() ImportFormat {
 	if x != nil {
-		return x.Id
+		return x.Format
 	}
-	return ""
+	return ImportFormat_IMPORT_FORMAT_UNSPECIFIED
 }
 
-func (x *UpdateOrderRequest) GetStatus() string {
+func (x *ImportOrdersChunk) GetDryRun() bool {
 	if x != nil {
-		return x.Status
+		return x.DryRun
 	}
-	return ""
+	return false
 }
 
-type ListOrdersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ImportOrdersChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
 }
 
-func (x *ListOrdersRequest) Reset() {
-	*x = ListOrdersRequest{}
-	mi := &file_order_proto_msgTypes[5]
+type ImportOrdersResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DryRun          bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Orders          int32                  `protobuf:"varint,2,opt,name=orders,proto3" json:"orders,omitempty"`     // Orders read from the file
+	Imported        int32                  `protobuf:"varint,3,opt,name=imported,proto3" json:"imported,omitempty"` // Orders imported, or that would be without dry_run
+	Skipped         int32                  `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`   // Orders with an external_id imported before
+	Failed          int32                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors          []*ImportError         `protobuf:"bytes,6,rep,name=errors,proto3" json:"errors,omitempty"` // The first 1000 errors
+	ErrorsTruncated bool                   `protobuf:"varint,7,opt,name=errors_truncated,json=errorsTruncated,proto3" json:"errors_truncated,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ImportOrdersResponse) Reset() {
+	*x = ImportOrdersResponse{}
+	mi := &file_order_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListOrdersRequest) String() string {
+func (x *ImportOrdersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrdersRequest) ProtoMessage() {}
+func (*ImportOrdersResponse) ProtoMessage() {}
 
-func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[5]
+func (x *ImportOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -355,55 +2757,84 @@ func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
-func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ImportOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ImportOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *ListOrdersRequest) GetUserId() string {
+func (x *ImportOrdersResponse) GetDryRun() bool {
 	if x != nil {
-		return x.UserId
+		return x.DryRun
 	}
-	return ""
+	return false
 }
 
-func (x *ListOrdersRequest) GetPage() int32 {
+func (x *ImportOrdersResponse) GetOrders() int32 {
 	if x != nil {
-		return x.Page
+		return x.Orders
 	}
 	return 0
 }
 
-func (x *ListOrdersRequest) GetLimit() int32 {
+func (x *ImportOrdersResponse) GetImported() int32 {
 	if x != nil {
-		return x.Limit
+		return x.Imported
 	}
 	return 0
 }
 
-type ListOrdersResponse struct {
+func (x *ImportOrdersResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ImportOrdersResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportOrdersResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportOrdersResponse) GetErrorsTruncated() bool {
+	if x != nil {
+		return x.ErrorsTruncated
+	}
+	return false
+}
+
+type ImportError struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Row           int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"` // CSV line, or position in the JSON array, counting from 1
+	ExternalId    string                 `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Messages      []string               `protobuf:"bytes,3,rep,name=messages,proto3" json:"messages,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListOrdersResponse) Reset() {
-	*x = ListOrdersResponse{}
-	mi := &file_order_proto_msgTypes[6]
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_order_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListOrdersResponse) String() string {
+func (x *ImportError) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrdersResponse) ProtoMessage() {}
+func (*ImportError) ProtoMessage() {}
 
-func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[6]
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -414,66 +2845,318 @@ func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
-func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *ListOrdersResponse) GetOrders() []*Order {
+func (x *ImportError) GetRow() int32 {
 	if x != nil {
-		return x.Orders
+		return x.Row
 	}
-	return nil
+	return 0
 }
 
-func (x *ListOrdersResponse) GetTotal() int32 {
+func (x *ImportError) GetExternalId() string {
 	if x != nil {
-		return x.Total
+		return x.ExternalId
 	}
-	return 0
+	return ""
+}
+
+func (x *ImportError) GetMessages() []string {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
 }
 
 var File_order_proto protoreflect.FileDescriptor
 
 const file_order_proto_rawDesc = "" +
 	"\n" +
-	"\vorder.proto\x12\x05proto\"\xd1\x01\n" +
+	"\vorder.proto\x12\x05proto\x1a\vmoney.proto\"\xb9\x05\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12&\n" +
-	"\x05items\x18\x03 \x03(\v2\x10.proto.OrderItemR\x05items\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\tR\x06status\x12!\n" +
-	"\ftotal_amount\x18\x05 \x01(\x01R\vtotalAmount\x12\x1d\n" +
+	"\x05items\x18\x03 \x03(\v2\x10.proto.OrderItemR\x05items\x12*\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x12.proto.OrderStatusR\x06status\x12/\n" +
+	"\ftotal_amount\x18\n" +
+	" \x01(\v2\f.proto.MoneyR\vtotalAmount\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\tR\tupdatedAt\"\\\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12/\n" +
+	"\x13cancellation_reason\x18\b \x01(\tR\x12cancellationReason\x12:\n" +
+	"\x0estatus_history\x18\t \x03(\v2\x13.proto.StatusChangeR\rstatusHistory\x124\n" +
+	"\tdiscounts\x18\v \x03(\v2\x16.proto.AppliedDiscountR\tdiscounts\x12(\n" +
+	"\bsubtotal\x18\f \x01(\v2\f.proto.MoneyR\bsubtotal\x12+\n" +
+	"\ttax_lines\x18\r \x03(\v2\x0e.proto.TaxLineR\btaxLines\x12-\n" +
+	"\vgrand_total\x18\x0e \x01(\v2\f.proto.MoneyR\n" +
+	"grandTotal\x129\n" +
+	"\x10shipping_address\x18\x0f \x01(\v2\x0e.proto.AddressR\x0fshippingAddress\x121\n" +
+	"\bshipping\x18\x10 \x01(\v2\x15.proto.ShippingOptionR\bshipping\x12'\n" +
+	"\x0fsubscription_id\x18\x11 \x01(\tR\x0esubscriptionIdJ\x04\b\x05\x10\x06\"\x9c\x01\n" +
+	"\aAddress\x12\x14\n" +
+	"\x05line1\x18\x01 \x01(\tR\x05line1\x12\x14\n" +
+	"\x05line2\x18\x02 \x01(\tR\x05line2\x12\x12\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\x12\x1f\n" +
+	"\vpostal_code\x18\x05 \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\x06 \x01(\tR\acountry\"\xce\x01\n" +
+	"\aTaxLine\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\"\n" +
+	"\fjurisdiction\x18\x02 \x01(\tR\fjurisdiction\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\x123\n" +
+	"\x0etaxable_amount\x18\x04 \x01(\v2\f.proto.MoneyR\rtaxableAmount\x12$\n" +
+	"\x06amount\x18\x05 \x01(\v2\f.proto.MoneyR\x06amount\x12\x1c\n" +
+	"\tinclusive\x18\x06 \x01(\bR\tinclusive\"\xaf\x01\n" +
+	"\x0fAppliedDiscount\x12!\n" +
+	"\fpromotion_id\x18\x01 \x01(\tR\vpromotionId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x04 \x01(\tR\tproductId\x12$\n" +
+	"\x06amount\x18\x05 \x01(\v2\f.proto.MoneyR\x06amount\"\xc6\x01\n" +
+	"\fStatusChange\x121\n" +
+	"\n" +
+	"old_status\x18\x01 \x01(\x0e2\x12.proto.OrderStatusR\toldStatus\x121\n" +
+	"\n" +
+	"new_status\x18\x02 \x01(\x0e2\x12.proto.OrderStatusR\tnewStatus\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\tR\aactorId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x05 \x01(\tR\tchangedAt\"X\n" +
+	"\fOrderHistory\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12-\n" +
+	"\aentries\x18\x02 \x03(\v2\x13.proto.StatusChangeR\aentries\"\xa3\x01\n" +
 	"\tOrderItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\"U\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\"\n" +
+	"\x05price\x18\x04 \x01(\v2\f.proto.MoneyR\x05price\x121\n" +
+	"\x14backordered_quantity\x18\x05 \x01(\x05R\x13backorderedQuantityJ\x04\b\x03\x10\x04\"\xdc\x01\n" +
 	"\x12CreateOrderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
-	"\x05items\x18\x02 \x03(\v2\x10.proto.OrderItemR\x05items\"!\n" +
+	"\x05items\x18\x02 \x03(\v2\x10.proto.OrderItemR\x05items\x12!\n" +
+	"\fcoupon_codes\x18\x03 \x03(\tR\vcouponCodes\x129\n" +
+	"\x10shipping_address\x18\x04 \x01(\v2\x0e.proto.AddressR\x0fshippingAddress\x12'\n" +
+	"\x0fshipping_option\x18\x05 \x01(\tR\x0eshippingOption\"!\n" +
 	"\x0fGetOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"<\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"h\n" +
 	"\x12UpdateOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x12.proto.OrderStatusR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"<\n" +
+	"\x12CancelOrderRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"V\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x8f\x04\n" +
 	"\x11ListOrdersRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\"P\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12.\n" +
+	"\bstatuses\x18\x04 \x03(\x0e2\x12.proto.OrderStatusR\bstatuses\x12#\n" +
+	"\rcreated_after\x18\x05 \x01(\tR\fcreatedAfter\x12%\n" +
+	"\x0ecreated_before\x18\x06 \x01(\tR\rcreatedBefore\x12#\n" +
+	"\rupdated_after\x18\a \x01(\tR\fupdatedAfter\x12%\n" +
+	"\x0eupdated_before\x18\b \x01(\tR\rupdatedBefore\x12)\n" +
+	"\tmin_total\x18\t \x01(\v2\f.proto.MoneyR\bminTotal\x12)\n" +
+	"\tmax_total\x18\n" +
+	" \x01(\v2\f.proto.MoneyR\bmaxTotal\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\v \x01(\tR\tproductId\x12\x17\n" +
+	"\asort_by\x18\f \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\r \x01(\tR\tsortOrder\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x0e \x01(\tR\tpageToken\x12#\n" +
+	"\rinclude_total\x18\x0f \x01(\bR\fincludeTotal\"x\n" +
 	"\x12ListOrdersResponse\x12$\n" +
 	"\x06orders\x18\x01 \x03(\v2\f.proto.OrderR\x06orders\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total2\xfb\x01\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\xe1\x01\n" +
+	"\bShipment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x18\n" +
+	"\acarrier\x18\x03 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\x04 \x01(\tR\x0etrackingNumber\x12)\n" +
+	"\x05items\x18\x05 \x03(\v2\x13.proto.ShipmentItemR\x05items\x12\x1d\n" +
+	"\n" +
+	"shipped_at\x18\x06 \x01(\tR\tshippedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"I\n" +
+	"\fShipmentItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xbf\x01\n" +
+	"\x15CreateShipmentRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x18\n" +
+	"\acarrier\x18\x02 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\x03 \x01(\tR\x0etrackingNumber\x12)\n" +
+	"\x05items\x18\x04 \x03(\v2\x13.proto.ShipmentItemR\x05items\x12\x1d\n" +
+	"\n" +
+	"shipped_at\x18\x05 \x01(\tR\tshippedAt\"1\n" +
+	"\x14ListShipmentsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"F\n" +
+	"\x15ListShipmentsResponse\x12-\n" +
+	"\tshipments\x18\x01 \x03(\v2\x0f.proto.ShipmentR\tshipments\"\xf2\x02\n" +
+	"\x13ReturnAuthorization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12+\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x13.proto.ReturnStatusR\x06status\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12'\n" +
+	"\x05items\x18\x05 \x03(\v2\x11.proto.ReturnItemR\x05items\x12\x1f\n" +
+	"\vreview_note\x18\x06 \x01(\tR\n" +
+	"reviewNote\x12!\n" +
+	"\frequested_by\x18\a \x01(\tR\vrequestedBy\x12\x1f\n" +
+	"\vreviewed_by\x18\b \x01(\tR\n" +
+	"reviewedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\tR\tupdatedAt\x12\x1f\n" +
+	"\vreceived_at\x18\v \x01(\tR\n" +
+	"receivedAt\"\xb2\x01\n" +
+	"\n" +
+	"ReturnItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12+\n" +
+	"\x11received_quantity\x18\x03 \x01(\x05R\x10receivedQuantity\x12\x1e\n" +
+	"\n" +
+	"resellable\x18\x04 \x01(\bR\n" +
+	"resellable\x12\x1c\n" +
+	"\trestocked\x18\x05 \x01(\bR\trestocked\"r\n" +
+	"\x14RequestReturnRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12'\n" +
+	"\x05items\x18\x02 \x03(\v2\x11.proto.ReturnItemR\x05items\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"/\n" +
+	"\x12ListReturnsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"K\n" +
+	"\x13ListReturnsResponse\x124\n" +
+	"\areturns\x18\x01 \x03(\v2\x1a.proto.ReturnAuthorizationR\areturns\"a\n" +
+	"\x13ReviewReturnRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\treturn_id\x18\x02 \x01(\tR\breturnId\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\"y\n" +
+	"\x14ReceiveReturnRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\treturn_id\x18\x02 \x01(\tR\breturnId\x12)\n" +
+	"\x05items\x18\x03 \x03(\v2\x13.proto.ReceivedItemR\x05items\"i\n" +
+	"\fReceivedItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1e\n" +
+	"\n" +
+	"resellable\x18\x03 \x01(\bR\n" +
+	"resellable\"\x8c\x01\n" +
+	"\x0eShippingOption\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\x04cost\x18\x03 \x01(\v2\f.proto.MoneyR\x04cost\x12\x19\n" +
+	"\bmin_days\x18\x04 \x01(\x05R\aminDays\x12\x19\n" +
+	"\bmax_days\x18\x05 \x01(\x05R\amaxDays\"y\n" +
+	"\x14QuoteShippingRequest\x12&\n" +
+	"\x05items\x18\x01 \x03(\v2\x10.proto.OrderItemR\x05items\x129\n" +
+	"\x10shipping_address\x18\x02 \x01(\v2\x0e.proto.AddressR\x0fshippingAddress\"t\n" +
+	"\rShippingQuote\x12/\n" +
+	"\aoptions\x18\x01 \x03(\v2\x15.proto.ShippingOptionR\aoptions\x122\n" +
+	"\x15billable_weight_grams\x18\x02 \x01(\x05R\x13billableWeightGrams\"k\n" +
+	"\x13AddOrderItemRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"v\n" +
+	"\x1eUpdateOrderItemQuantityRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"R\n" +
+	"\x16RemoveOrderItemRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\"\\\n" +
+	"\x11GetInvoiceRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12,\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x14.proto.InvoiceFormatR\x06format\"\x93\x02\n" +
+	"\aInvoice\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\"\n" +
+	"\x05total\x18\x05 \x01(\v2\f.proto.MoneyR\x05total\x12\x1b\n" +
+	"\tissued_at\x18\x06 \x01(\tR\bissuedAt\x12,\n" +
+	"\x06format\x18\a \x01(\x0e2\x14.proto.InvoiceFormatR\x06format\x12\x1a\n" +
+	"\bdocument\x18\b \x01(\fR\bdocument\x12!\n" +
+	"\fcontent_type\x18\t \x01(\tR\vcontentType\"m\n" +
+	"\x11ImportOrdersChunk\x12+\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x13.proto.ImportFormatR\x06format\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\xec\x01\n" +
+	"\x14ImportOrdersResponse\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x16\n" +
+	"\x06orders\x18\x02 \x01(\x05R\x06orders\x12\x1a\n" +
+	"\bimported\x18\x03 \x01(\x05R\bimported\x12\x18\n" +
+	"\askipped\x18\x04 \x01(\x05R\askipped\x12\x16\n" +
+	"\x06failed\x18\x05 \x01(\x05R\x06failed\x12*\n" +
+	"\x06errors\x18\x06 \x03(\v2\x12.proto.ImportErrorR\x06errors\x12)\n" +
+	"\x10errors_truncated\x18\a \x01(\bR\x0ferrorsTruncated\"\\\n" +
+	"\vImportError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12\x1f\n" +
+	"\vexternal_id\x18\x02 \x01(\tR\n" +
+	"externalId\x12\x1a\n" +
+	"\bmessages\x18\x03 \x03(\tR\bmessages*\xa2\x02\n" +
+	"\vOrderStatus\x12\x1c\n" +
+	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ORDER_STATUS_PENDING\x10\x01\x12\x1a\n" +
+	"\x16ORDER_STATUS_CONFIRMED\x10\x02\x12\x15\n" +
+	"\x11ORDER_STATUS_PAID\x10\x03\x12\x18\n" +
+	"\x14ORDER_STATUS_SHIPPED\x10\x04\x12\x1a\n" +
+	"\x16ORDER_STATUS_DELIVERED\x10\x05\x12\x1a\n" +
+	"\x16ORDER_STATUS_CANCELLED\x10\x06\x12\x19\n" +
+	"\x15ORDER_STATUS_REFUNDED\x10\a\x12\x17\n" +
+	"\x13ORDER_STATUS_FAILED\x10\b\x12\"\n" +
+	"\x1eORDER_STATUS_PARTIALLY_SHIPPED\x10\t*\x9e\x01\n" +
+	"\fReturnStatus\x12\x1d\n" +
+	"\x19RETURN_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17RETURN_STATUS_REQUESTED\x10\x01\x12\x1a\n" +
+	"\x16RETURN_STATUS_APPROVED\x10\x02\x12\x1a\n" +
+	"\x16RETURN_STATUS_REJECTED\x10\x03\x12\x1a\n" +
+	"\x16RETURN_STATUS_RECEIVED\x10\x04*`\n" +
+	"\rInvoiceFormat\x12\x1e\n" +
+	"\x1aINVOICE_FORMAT_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13INVOICE_FORMAT_HTML\x10\x01\x12\x16\n" +
+	"\x12INVOICE_FORMAT_PDF\x10\x02*\\\n" +
+	"\fImportFormat\x12\x1d\n" +
+	"\x19IMPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11IMPORT_FORMAT_CSV\x10\x01\x12\x16\n" +
+	"\x12IMPORT_FORMAT_JSON\x10\x022\xd4\n" +
+	"\n" +
 	"\fOrderService\x128\n" +
 	"\vCreateOrder\x12\x19.proto.CreateOrderRequest\x1a\f.proto.Order\"\x00\x122\n" +
 	"\bGetOrder\x12\x16.proto.GetOrderRequest\x1a\f.proto.Order\"\x00\x128\n" +
 	"\vUpdateOrder\x12\x19.proto.UpdateOrderRequest\x1a\f.proto.Order\"\x00\x12C\n" +
 	"\n" +
-	"ListOrders\x12\x18.proto.ListOrdersRequest\x1a\x19.proto.ListOrdersResponse\"\x00B#Z!github.com/order-management/protob\x06proto3"
+	"ListOrders\x12\x18.proto.ListOrdersRequest\x1a\x19.proto.ListOrdersResponse\"\x00\x12:\n" +
+	"\fExportOrders\x12\x18.proto.ListOrdersRequest\x1a\f.proto.Order\"\x000\x01\x12I\n" +
+	"\fImportOrders\x12\x18.proto.ImportOrdersChunk\x1a\x1b.proto.ImportOrdersResponse\"\x00(\x01\x128\n" +
+	"\vCancelOrder\x12\x19.proto.CancelOrderRequest\x1a\f.proto.Order\"\x00\x12@\n" +
+	"\x0fGetOrderHistory\x12\x16.proto.GetOrderRequest\x1a\x13.proto.OrderHistory\"\x00\x12A\n" +
+	"\x0eCreateShipment\x12\x1c.proto.CreateShipmentRequest\x1a\x0f.proto.Shipment\"\x00\x12L\n" +
+	"\rListShipments\x12\x1b.proto.ListShipmentsRequest\x1a\x1c.proto.ListShipmentsResponse\"\x00\x12J\n" +
+	"\rRequestReturn\x12\x1b.proto.RequestReturnRequest\x1a\x1a.proto.ReturnAuthorization\"\x00\x12F\n" +
+	"\vListReturns\x12\x19.proto.ListReturnsRequest\x1a\x1a.proto.ListReturnsResponse\"\x00\x12I\n" +
+	"\rApproveReturn\x12\x1a.proto.ReviewReturnRequest\x1a\x1a.proto.ReturnAuthorization\"\x00\x12H\n" +
+	"\fRejectReturn\x12\x1a.proto.ReviewReturnRequest\x1a\x1a.proto.ReturnAuthorization\"\x00\x12J\n" +
+	"\rReceiveReturn\x12\x1b.proto.ReceiveReturnRequest\x1a\x1a.proto.ReturnAuthorization\"\x00\x12D\n" +
+	"\rQuoteShipping\x12\x1b.proto.QuoteShippingRequest\x1a\x14.proto.ShippingQuote\"\x00\x12:\n" +
+	"\fAddOrderItem\x12\x1a.proto.AddOrderItemRequest\x1a\f.proto.Order\"\x00\x12P\n" +
+	"\x17UpdateOrderItemQuantity\x12%.proto.UpdateOrderItemQuantityRequest\x1a\f.proto.Order\"\x00\x12@\n" +
+	"\x0fRemoveOrderItem\x12\x1d.proto.RemoveOrderItemRequest\x1a\f.proto.Order\"\x00\x128\n" +
+	"\n" +
+	"GetInvoice\x12\x18.proto.GetInvoiceRequest\x1a\x0e.proto.Invoice\"\x00B#Z!github.com/order-management/protob\x06proto3"
 
 var (
 	file_order_proto_rawDescOnce sync.Once
@@ -487,33 +3170,139 @@ func file_order_proto_rawDescGZIP() []byte {
 	return file_order_proto_rawDescData
 }
 
-var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_order_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_order_proto_goTypes = []any{
-	(*Order)(nil),              // 0: proto.Order
-	(*OrderItem)(nil),          // 1: proto.OrderItem
-	(*CreateOrderRequest)(nil), // 2: proto.CreateOrderRequest
-	(*GetOrderRequest)(nil),    // 3: proto.GetOrderRequest
-	(*UpdateOrderRequest)(nil), // 4: proto.UpdateOrderRequest
-	(*ListOrdersRequest)(nil),  // 5: proto.ListOrdersRequest
-	(*ListOrdersResponse)(nil), // 6: proto.ListOrdersResponse
+	(OrderStatus)(0),                       // 0: proto.OrderStatus
+	(ReturnStatus)(0),                      // 1: proto.ReturnStatus
+	(InvoiceFormat)(0),                     // 2: proto.InvoiceFormat
+	(ImportFormat)(0),                      // 3: proto.ImportFormat
+	(*Order)(nil),                          // 4: proto.Order
+	(*Address)(nil),                        // 5: proto.Address
+	(*TaxLine)(nil),                        // 6: proto.TaxLine
+	(*AppliedDiscount)(nil),                // 7: proto.AppliedDiscount
+	(*StatusChange)(nil),                   // 8: proto.StatusChange
+	(*OrderHistory)(nil),                   // 9: proto.OrderHistory
+	(*OrderItem)(nil),                      // 10: proto.OrderItem
+	(*CreateOrderRequest)(nil),             // 11: proto.CreateOrderRequest
+	(*GetOrderRequest)(nil),                // 12: proto.GetOrderRequest
+	(*UpdateOrderRequest)(nil),             // 13: proto.UpdateOrderRequest
+	(*CancelOrderRequest)(nil),             // 14: proto.CancelOrderRequest
+	(*ListOrdersRequest)(nil),              // 15: proto.ListOrdersRequest
+	(*ListOrdersResponse)(nil),             // 16: proto.ListOrdersResponse
+	(*Shipment)(nil),                       // 17: proto.Shipment
+	(*ShipmentItem)(nil),                   // 18: proto.ShipmentItem
+	(*CreateShipmentRequest)(nil),          // 19: proto.CreateShipmentRequest
+	(*ListShipmentsRequest)(nil),           // 20: proto.ListShipmentsRequest
+	(*ListShipmentsResponse)(nil),          // 21: proto.ListShipmentsResponse
+	(*ReturnAuthorization)(nil),            // 22: proto.ReturnAuthorization
+	(*ReturnItem)(nil),                     // 23: proto.ReturnItem
+	(*RequestReturnRequest)(nil),           // 24: proto.RequestReturnRequest
+	(*ListReturnsRequest)(nil),             // 25: proto.ListReturnsRequest
+	(*ListReturnsResponse)(nil),            // 26: proto.ListReturnsResponse
+	(*ReviewReturnRequest)(nil),            // 27: proto.ReviewReturnRequest
+	(*ReceiveReturnRequest)(nil),           // 28: proto.ReceiveReturnRequest
+	(*ReceivedItem)(nil),                   // 29: proto.ReceivedItem
+	(*ShippingOption)(nil),                 // 30: proto.ShippingOption
+	(*QuoteShippingRequest)(nil),           // 31: proto.QuoteShippingRequest
+	(*ShippingQuote)(nil),                  // 32: proto.ShippingQuote
+	(*AddOrderItemRequest)(nil),            // 33: proto.AddOrderItemRequest
+	(*UpdateOrderItemQuantityRequest)(nil), // 34: proto.UpdateOrderItemQuantityRequest
+	(*RemoveOrderItemRequest)(nil),         // 35: proto.RemoveOrderItemRequest
+	(*GetInvoiceRequest)(nil),              // 36: proto.GetInvoiceRequest
+	(*Invoice)(nil),                        // 37: proto.Invoice
+	(*ImportOrdersChunk)(nil),              // 38: proto.ImportOrdersChunk
+	(*ImportOrdersResponse)(nil),           // 39: proto.ImportOrdersResponse
+	(*ImportError)(nil),                    // 40: proto.ImportError
+	(*Money)(nil),                          // 41: proto.Money
 }
 var file_order_proto_depIdxs = []int32{
-	1, // 0: proto.Order.items:type_name -> proto.OrderItem
-	1, // 1: proto.CreateOrderRequest.items:type_name -> proto.OrderItem
-	0, // 2: proto.ListOrdersResponse.orders:type_name -> proto.Order
-	2, // 3: proto.OrderService.CreateOrder:input_type -> proto.CreateOrderRequest
-	3, // 4: proto.OrderService.GetOrder:input_type -> proto.GetOrderRequest
-	4, // 5: proto.OrderService.UpdateOrder:input_type -> proto.UpdateOrderRequest
-	5, // 6: proto.OrderService.ListOrders:input_type -> proto.ListOrdersRequest
-	0, // 7: proto.OrderService.CreateOrder:output_type -> proto.Order
-	0, // 8: proto.OrderService.GetOrder:output_type -> proto.Order
-	0, // 9: proto.OrderService.UpdateOrder:output_type -> proto.Order
-	6, // 10: proto.OrderService.ListOrders:output_type -> proto.ListOrdersResponse
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	10, // 0: proto.Order.items:type_name -> proto.OrderItem
+	0,  // 1: proto.Order.status:type_name -> proto.OrderStatus
+	41, // 2: proto.Order.total_amount:type_name -> proto.Money
+	8,  // 3: proto.Order.status_history:type_name -> proto.StatusChange
+	7,  // 4: proto.Order.discounts:type_name -> proto.AppliedDiscount
+	41, // 5: proto.Order.subtotal:type_name -> proto.Money
+	6,  // 6: proto.Order.tax_lines:type_name -> proto.TaxLine
+	41, // 7: proto.Order.grand_total:type_name -> proto.Money
+	5,  // 8: proto.Order.shipping_address:type_name -> proto.Address
+	30, // 9: proto.Order.shipping:type_name -> proto.ShippingOption
+	41, // 10: proto.TaxLine.taxable_amount:type_name -> proto.Money
+	41, // 11: proto.TaxLine.amount:type_name -> proto.Money
+	41, // 12: proto.AppliedDiscount.amount:type_name -> proto.Money
+	0,  // 13: proto.StatusChange.old_status:type_name -> proto.OrderStatus
+	0,  // 14: proto.StatusChange.new_status:type_name -> proto.OrderStatus
+	8,  // 15: proto.OrderHistory.entries:type_name -> proto.StatusChange
+	41, // 16: proto.OrderItem.price:type_name -> proto.Money
+	10, // 17: proto.CreateOrderRequest.items:type_name -> proto.OrderItem
+	5,  // 18: proto.CreateOrderRequest.shipping_address:type_name -> proto.Address
+	0,  // 19: proto.UpdateOrderRequest.status:type_name -> proto.OrderStatus
+	0,  // 20: proto.ListOrdersRequest.statuses:type_name -> proto.OrderStatus
+	41, // 21: proto.ListOrdersRequest.min_total:type_name -> proto.Money
+	41, // 22: proto.ListOrdersRequest.max_total:type_name -> proto.Money
+	4,  // 23: proto.ListOrdersResponse.orders:type_name -> proto.Order
+	18, // 24: proto.Shipment.items:type_name -> proto.ShipmentItem
+	18, // 25: proto.CreateShipmentRequest.items:type_name -> proto.ShipmentItem
+	17, // 26: proto.ListShipmentsResponse.shipments:type_name -> proto.Shipment
+	1,  // 27: proto.ReturnAuthorization.status:type_name -> proto.ReturnStatus
+	23, // 28: proto.ReturnAuthorization.items:type_name -> proto.ReturnItem
+	23, // 29: proto.RequestReturnRequest.items:type_name -> proto.ReturnItem
+	22, // 30: proto.ListReturnsResponse.returns:type_name -> proto.ReturnAuthorization
+	29, // 31: proto.ReceiveReturnRequest.items:type_name -> proto.ReceivedItem
+	41, // 32: proto.ShippingOption.cost:type_name -> proto.Money
+	10, // 33: proto.QuoteShippingRequest.items:type_name -> proto.OrderItem
+	5,  // 34: proto.QuoteShippingRequest.shipping_address:type_name -> proto.Address
+	30, // 35: proto.ShippingQuote.options:type_name -> proto.ShippingOption
+	2,  // 36: proto.GetInvoiceRequest.format:type_name -> proto.InvoiceFormat
+	41, // 37: proto.Invoice.total:type_name -> proto.Money
+	2,  // 38: proto.Invoice.format:type_name -> proto.InvoiceFormat
+	3,  // 39: proto.ImportOrdersChunk.format:type_name -> proto.ImportFormat
+	40, // 40: proto.ImportOrdersResponse.errors:type_name -> proto.ImportError
+	11, // 41: proto.OrderService.CreateOrder:input_type -> proto.CreateOrderRequest
+	12, // 42: proto.OrderService.GetOrder:input_type -> proto.GetOrderRequest
+	13, // 43: proto.OrderService.UpdateOrder:input_type -> proto.UpdateOrderRequest
+	15, // 44: proto.OrderService.ListOrders:input_type -> proto.ListOrdersRequest
+	15, // 45: proto.OrderService.ExportOrders:input_type -> proto.ListOrdersRequest
+	38, // 46: proto.OrderService.ImportOrders:input_type -> proto.ImportOrdersChunk
+	14, // 47: proto.OrderService.CancelOrder:input_type -> proto.CancelOrderRequest
+	12, // 48: proto.OrderService.GetOrderHistory:input_type -> proto.GetOrderRequest
+	19, // 49: proto.OrderService.CreateShipment:input_type -> proto.CreateShipmentRequest
+	20, // 50: proto.OrderService.ListShipments:input_type -> proto.ListShipmentsRequest
+	24, // 51: proto.OrderService.RequestReturn:input_type -> proto.RequestReturnRequest
+	25, // 52: proto.OrderService.ListReturns:input_type -> proto.ListReturnsRequest
+	27, // 53: proto.OrderService.ApproveReturn:input_type -> proto.ReviewReturnRequest
+	27, // 54: proto.OrderService.RejectReturn:input_type -> proto.ReviewReturnRequest
+	28, // 55: proto.OrderService.ReceiveReturn:input_type -> proto.ReceiveReturnRequest
+	31, // 56: proto.OrderService.QuoteShipping:input_type -> proto.QuoteShippingRequest
+	33, // 57: proto.OrderService.AddOrderItem:input_type -> proto.AddOrderItemRequest
+	34, // 58: proto.OrderService.UpdateOrderItemQuantity:input_type -> proto.UpdateOrderItemQuantityRequest
+	35, // 59: proto.OrderService.RemoveOrderItem:input_type -> proto.RemoveOrderItemRequest
+	36, // 60: proto.OrderService.GetInvoice:input_type -> proto.GetInvoiceRequest
+	4,  // 61: proto.OrderService.CreateOrder:output_type -> proto.Order
+	4,  // 62: proto.OrderService.GetOrder:output_type -> proto.Order
+	4,  // 63: proto.OrderService.UpdateOrder:output_type -> proto.Order
+	16, // 64: proto.OrderService.ListOrders:output_type -> proto.ListOrdersResponse
+	4,  // 65: proto.OrderService.ExportOrders:output_type -> proto.Order
+	39, // 66: proto.OrderService.ImportOrders:output_type -> proto.ImportOrdersResponse
+	4,  // 67: proto.OrderService.CancelOrder:output_type -> proto.Order
+	9,  // 68: proto.OrderService.GetOrderHistory:output_type -> proto.OrderHistory
+	17, // 69: proto.OrderService.CreateShipment:output_type -> proto.Shipment
+	21, // 70: proto.OrderService.ListShipments:output_type -> proto.ListShipmentsResponse
+	22, // 71: proto.OrderService.RequestReturn:output_type -> proto.ReturnAuthorization
+	26, // 72: proto.OrderService.ListReturns:output_type -> proto.ListReturnsResponse
+	22, // 73: proto.OrderService.ApproveReturn:output_type -> proto.ReturnAuthorization
+	22, // 74: proto.OrderService.RejectReturn:output_type -> proto.ReturnAuthorization
+	22, // 75: proto.OrderService.ReceiveReturn:output_type -> proto.ReturnAuthorization
+	32, // 76: proto.OrderService.QuoteShipping:output_type -> proto.ShippingQuote
+	4,  // 77: proto.OrderService.AddOrderItem:output_type -> proto.Order
+	4,  // 78: proto.OrderService.UpdateOrderItemQuantity:output_type -> proto.Order
+	4,  // 79: proto.OrderService.RemoveOrderItem:output_type -> proto.Order
+	37, // 80: proto.OrderService.GetInvoice:output_type -> proto.Invoice
+	61, // [61:81] is the sub-list for method output_type
+	41, // [41:61] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_order_proto_init() }
@@ -521,18 +3310,20 @@ func file_order_proto_init() {
 	if File_order_proto != nil {
 		return
 	}
+	file_money_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_proto_rawDesc), len(file_order_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      4,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_order_proto_goTypes,
 		DependencyIndexes: file_order_proto_depIdxs,
+		EnumInfos:         file_order_proto_enumTypes,
 		MessageInfos:      file_order_proto_msgTypes,
 	}.Build()
 	File_order_proto = out.File
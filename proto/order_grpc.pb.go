@@ -19,10 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	OrderService_CreateOrder_FullMethodName = "/proto.OrderService/CreateOrder"
-	OrderService_GetOrder_FullMethodName    = "/proto.OrderService/GetOrder"
-	OrderService_UpdateOrder_FullMethodName = "/proto.OrderService/UpdateOrder"
-	OrderService_ListOrders_FullMethodName  = "/proto.OrderService/ListOrders"
+	OrderService_CreateOrder_FullMethodName             = "/proto.OrderService/CreateOrder"
+	OrderService_GetOrder_FullMethodName                = "/proto.OrderService/GetOrder"
+	OrderService_UpdateOrder_FullMethodName             = "/proto.OrderService/UpdateOrder"
+	OrderService_ListOrders_FullMethodName              = "/proto.OrderService/ListOrders"
+	OrderService_ExportOrders_FullMethodName            = "/proto.OrderService/ExportOrders"
+	OrderService_ImportOrders_FullMethodName            = "/proto.OrderService/ImportOrders"
+	OrderService_CancelOrder_FullMethodName             = "/proto.OrderService/CancelOrder"
+	OrderService_GetOrderHistory_FullMethodName         = "/proto.OrderService/GetOrderHistory"
+	OrderService_CreateShipment_FullMethodName          = "/proto.OrderService/CreateShipment"
+	OrderService_ListShipments_FullMethodName           = "/proto.OrderService/ListShipments"
+	OrderService_RequestReturn_FullMethodName           = "/proto.OrderService/RequestReturn"
+	OrderService_ListReturns_FullMethodName             = "/proto.OrderService/ListReturns"
+	OrderService_ApproveReturn_FullMethodName           = "/proto.OrderService/ApproveReturn"
+	OrderService_RejectReturn_FullMethodName            = "/proto.OrderService/RejectReturn"
+	OrderService_ReceiveReturn_FullMethodName           = "/proto.OrderService/ReceiveReturn"
+	OrderService_QuoteShipping_FullMethodName           = "/proto.OrderService/QuoteShipping"
+	OrderService_AddOrderItem_FullMethodName            = "/proto.OrderService/AddOrderItem"
+	OrderService_UpdateOrderItemQuantity_FullMethodName = "/proto.OrderService/UpdateOrderItemQuantity"
+	OrderService_RemoveOrderItem_FullMethodName         = "/proto.OrderService/RemoveOrderItem"
+	OrderService_GetInvoice_FullMethodName              = "/proto.OrderService/GetInvoice"
 )
 
 // OrderServiceClient is the client API for OrderService service.
@@ -33,6 +49,25 @@ type OrderServiceClient interface {
 	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
 	UpdateOrder(ctx context.Context, in *UpdateOrderRequest, opts ...grpc.CallOption) (*Order, error)
 	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+	// ExportOrders streams every order matching the filters of a ListOrders request, in
+	// its sort order. The pagination fields are ignored.
+	ExportOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (OrderService_ExportOrdersClient, error)
+	// ImportOrders loads historical orders from a CSV or JSON file sent in chunks.
+	ImportOrders(ctx context.Context, opts ...grpc.CallOption) (OrderService_ImportOrdersClient, error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	GetOrderHistory(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*OrderHistory, error)
+	CreateShipment(ctx context.Context, in *CreateShipmentRequest, opts ...grpc.CallOption) (*Shipment, error)
+	ListShipments(ctx context.Context, in *ListShipmentsRequest, opts ...grpc.CallOption) (*ListShipmentsResponse, error)
+	RequestReturn(ctx context.Context, in *RequestReturnRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error)
+	ListReturns(ctx context.Context, in *ListReturnsRequest, opts ...grpc.CallOption) (*ListReturnsResponse, error)
+	ApproveReturn(ctx context.Context, in *ReviewReturnRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error)
+	RejectReturn(ctx context.Context, in *ReviewReturnRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error)
+	ReceiveReturn(ctx context.Context, in *ReceiveReturnRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error)
+	QuoteShipping(ctx context.Context, in *QuoteShippingRequest, opts ...grpc.CallOption) (*ShippingQuote, error)
+	AddOrderItem(ctx context.Context, in *AddOrderItemRequest, opts ...grpc.CallOption) (*Order, error)
+	UpdateOrderItemQuantity(ctx context.Context, in *UpdateOrderItemQuantityRequest, opts ...grpc.CallOption) (*Order, error)
+	RemoveOrderItem(ctx context.Context, in *RemoveOrderItemRequest, opts ...grpc.CallOption) (*Order, error)
+	GetInvoice(ctx context.Context, in *GetInvoiceRequest, opts ...grpc.CallOption) (*Invoice, error)
 }
 
 type orderServiceClient struct {
@@ -79,6 +114,198 @@ func (c *orderServiceClient) ListOrders(ctx context.Context, in *ListOrdersReque
 	return out, nil
 }
 
+func (c *orderServiceClient) ExportOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (OrderService_ExportOrdersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &OrderService_ServiceDesc.Streams[0], OrderService_ExportOrders_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orderServiceExportOrdersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OrderService_ExportOrdersClient interface {
+	Recv() (*Order, error)
+	grpc.ClientStream
+}
+
+type orderServiceExportOrdersClient struct {
+	grpc.ClientStream
+}
+
+func (x *orderServiceExportOrdersClient) Recv() (*Order, error) {
+	m := new(Order)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *orderServiceClient) ImportOrders(ctx context.Context, opts ...grpc.CallOption) (OrderService_ImportOrdersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &OrderService_ServiceDesc.Streams[1], OrderService_ImportOrders_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orderServiceImportOrdersClient{stream}
+	return x, nil
+}
+
+type OrderService_ImportOrdersClient interface {
+	Send(*ImportOrdersChunk) error
+	CloseAndRecv() (*ImportOrdersResponse, error)
+	grpc.ClientStream
+}
+
+type orderServiceImportOrdersClient struct {
+	grpc.ClientStream
+}
+
+func (x *orderServiceImportOrdersClient) Send(m *ImportOrdersChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *orderServiceImportOrdersClient) CloseAndRecv() (*ImportOrdersResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportOrdersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *orderServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_CancelOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrderHistory(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*OrderHistory, error) {
+	out := new(OrderHistory)
+	err := c.cc.Invoke(ctx, OrderService_GetOrderHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CreateShipment(ctx context.Context, in *CreateShipmentRequest, opts ...grpc.CallOption) (*Shipment, error) {
+	out := new(Shipment)
+	err := c.cc.Invoke(ctx, OrderService_CreateShipment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListShipments(ctx context.Context, in *ListShipmentsRequest, opts ...grpc.CallOption) (*ListShipmentsResponse, error) {
+	out := new(ListShipmentsResponse)
+	err := c.cc.Invoke(ctx, OrderService_ListShipments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RequestReturn(ctx context.Context, in *RequestReturnRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error) {
+	out := new(ReturnAuthorization)
+	err := c.cc.Invoke(ctx, OrderService_RequestReturn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListReturns(ctx context.Context, in *ListReturnsRequest, opts ...grpc.CallOption) (*ListReturnsResponse, error) {
+	out := new(ListReturnsResponse)
+	err := c.cc.Invoke(ctx, OrderService_ListReturns_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ApproveReturn(ctx context.Context, in *ReviewReturnRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error) {
+	out := new(ReturnAuthorization)
+	err := c.cc.Invoke(ctx, OrderService_ApproveReturn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RejectReturn(ctx context.Context, in *ReviewReturnRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error) {
+	out := new(ReturnAuthorization)
+	err := c.cc.Invoke(ctx, OrderService_RejectReturn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ReceiveReturn(ctx context.Context, in *ReceiveReturnRequest, opts ...grpc.CallOption) (*ReturnAuthorization, error) {
+	out := new(ReturnAuthorization)
+	err := c.cc.Invoke(ctx, OrderService_ReceiveReturn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) QuoteShipping(ctx context.Context, in *QuoteShippingRequest, opts ...grpc.CallOption) (*ShippingQuote, error) {
+	out := new(ShippingQuote)
+	err := c.cc.Invoke(ctx, OrderService_QuoteShipping_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) AddOrderItem(ctx context.Context, in *AddOrderItemRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_AddOrderItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) UpdateOrderItemQuantity(ctx context.Context, in *UpdateOrderItemQuantityRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_UpdateOrderItemQuantity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RemoveOrderItem(ctx context.Context, in *RemoveOrderItemRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_RemoveOrderItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetInvoice(ctx context.Context, in *GetInvoiceRequest, opts ...grpc.CallOption) (*Invoice, error) {
+	out := new(Invoice)
+	err := c.cc.Invoke(ctx, OrderService_GetInvoice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility
@@ -87,6 +314,25 @@ type OrderServiceServer interface {
 	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
 	UpdateOrder(context.Context, *UpdateOrderRequest) (*Order, error)
 	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
+	// ExportOrders streams every order matching the filters of a ListOrders request, in
+	// its sort order. The pagination fields are ignored.
+	ExportOrders(*ListOrdersRequest, OrderService_ExportOrdersServer) error
+	// ImportOrders loads historical orders from a CSV or JSON file sent in chunks.
+	ImportOrders(OrderService_ImportOrdersServer) error
+	CancelOrder(context.Context, *CancelOrderRequest) (*Order, error)
+	GetOrderHistory(context.Context, *GetOrderRequest) (*OrderHistory, error)
+	CreateShipment(context.Context, *CreateShipmentRequest) (*Shipment, error)
+	ListShipments(context.Context, *ListShipmentsRequest) (*ListShipmentsResponse, error)
+	RequestReturn(context.Context, *RequestReturnRequest) (*ReturnAuthorization, error)
+	ListReturns(context.Context, *ListReturnsRequest) (*ListReturnsResponse, error)
+	ApproveReturn(context.Context, *ReviewReturnRequest) (*ReturnAuthorization, error)
+	RejectReturn(context.Context, *ReviewReturnRequest) (*ReturnAuthorization, error)
+	ReceiveReturn(context.Context, *ReceiveReturnRequest) (*ReturnAuthorization, error)
+	QuoteShipping(context.Context, *QuoteShippingRequest) (*ShippingQuote, error)
+	AddOrderItem(context.Context, *AddOrderItemRequest) (*Order, error)
+	UpdateOrderItemQuantity(context.Context, *UpdateOrderItemQuantityRequest) (*Order, error)
+	RemoveOrderItem(context.Context, *RemoveOrderItemRequest) (*Order, error)
+	GetInvoice(context.Context, *GetInvoiceRequest) (*Invoice, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -106,6 +352,54 @@ func (UnimplementedOrderServiceServer) UpdateOrder(context.Context, *UpdateOrder
 func (UnimplementedOrderServiceServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
 }
+func (UnimplementedOrderServiceServer) ExportOrders(*ListOrdersRequest, OrderService_ExportOrdersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportOrders not implemented")
+}
+func (UnimplementedOrderServiceServer) ImportOrders(OrderService_ImportOrdersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportOrders not implemented")
+}
+func (UnimplementedOrderServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) GetOrderHistory(context.Context, *GetOrderRequest) (*OrderHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderHistory not implemented")
+}
+func (UnimplementedOrderServiceServer) CreateShipment(context.Context, *CreateShipmentRequest) (*Shipment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShipment not implemented")
+}
+func (UnimplementedOrderServiceServer) ListShipments(context.Context, *ListShipmentsRequest) (*ListShipmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShipments not implemented")
+}
+func (UnimplementedOrderServiceServer) RequestReturn(context.Context, *RequestReturnRequest) (*ReturnAuthorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestReturn not implemented")
+}
+func (UnimplementedOrderServiceServer) ListReturns(context.Context, *ListReturnsRequest) (*ListReturnsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReturns not implemented")
+}
+func (UnimplementedOrderServiceServer) ApproveReturn(context.Context, *ReviewReturnRequest) (*ReturnAuthorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveReturn not implemented")
+}
+func (UnimplementedOrderServiceServer) RejectReturn(context.Context, *ReviewReturnRequest) (*ReturnAuthorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectReturn not implemented")
+}
+func (UnimplementedOrderServiceServer) ReceiveReturn(context.Context, *ReceiveReturnRequest) (*ReturnAuthorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveReturn not implemented")
+}
+func (UnimplementedOrderServiceServer) QuoteShipping(context.Context, *QuoteShippingRequest) (*ShippingQuote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteShipping not implemented")
+}
+func (UnimplementedOrderServiceServer) AddOrderItem(context.Context, *AddOrderItemRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddOrderItem not implemented")
+}
+func (UnimplementedOrderServiceServer) UpdateOrderItemQuantity(context.Context, *UpdateOrderItemQuantityRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrderItemQuantity not implemented")
+}
+func (UnimplementedOrderServiceServer) RemoveOrderItem(context.Context, *RemoveOrderItemRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveOrderItem not implemented")
+}
+func (UnimplementedOrderServiceServer) GetInvoice(context.Context, *GetInvoiceRequest) (*Invoice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInvoice not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 
 // UnsafeOrderServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -191,6 +485,305 @@ func _OrderService_ListOrders_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_ExportOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListOrdersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderServiceServer).ExportOrders(m, &orderServiceExportOrdersServer{stream})
+}
+
+type OrderService_ExportOrdersServer interface {
+	Send(*Order) error
+	grpc.ServerStream
+}
+
+type orderServiceExportOrdersServer struct {
+	grpc.ServerStream
+}
+
+func (x *orderServiceExportOrdersServer) Send(m *Order) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _OrderService_ImportOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(OrderServiceServer).ImportOrders(&orderServiceImportOrdersServer{stream})
+}
+
+type OrderService_ImportOrdersServer interface {
+	SendAndClose(*ImportOrdersResponse) error
+	Recv() (*ImportOrdersChunk, error)
+	grpc.ServerStream
+}
+
+type orderServiceImportOrdersServer struct {
+	grpc.ServerStream
+}
+
+func (x *orderServiceImportOrdersServer) SendAndClose(m *ImportOrdersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *orderServiceImportOrdersServer) Recv() (*ImportOrdersChunk, error) {
+	m := new(ImportOrdersChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _OrderService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrderHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrderHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetOrderHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrderHistory(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CreateShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreateShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CreateShipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreateShipment(ctx, req.(*CreateShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListShipments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShipmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListShipments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ListShipments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListShipments(ctx, req.(*ListShipmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RequestReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RequestReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_RequestReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RequestReturn(ctx, req.(*RequestReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListReturns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReturnsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListReturns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ListReturns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListReturns(ctx, req.(*ListReturnsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ApproveReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ApproveReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ApproveReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ApproveReturn(ctx, req.(*ReviewReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RejectReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RejectReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_RejectReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RejectReturn(ctx, req.(*ReviewReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ReceiveReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ReceiveReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ReceiveReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ReceiveReturn(ctx, req.(*ReceiveReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_QuoteShipping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteShippingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).QuoteShipping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_QuoteShipping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).QuoteShipping(ctx, req.(*QuoteShippingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_AddOrderItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddOrderItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).AddOrderItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_AddOrderItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).AddOrderItem(ctx, req.(*AddOrderItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_UpdateOrderItemQuantity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrderItemQuantityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).UpdateOrderItemQuantity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_UpdateOrderItemQuantity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).UpdateOrderItemQuantity(ctx, req.(*UpdateOrderItemQuantityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RemoveOrderItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveOrderItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RemoveOrderItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_RemoveOrderItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RemoveOrderItem(ctx, req.(*RemoveOrderItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetInvoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetInvoice(ctx, req.(*GetInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -214,7 +807,74 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListOrders",
 			Handler:    _OrderService_ListOrders_Handler,
 		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _OrderService_CancelOrder_Handler,
+		},
+		{
+			MethodName: "GetOrderHistory",
+			Handler:    _OrderService_GetOrderHistory_Handler,
+		},
+		{
+			MethodName: "CreateShipment",
+			Handler:    _OrderService_CreateShipment_Handler,
+		},
+		{
+			MethodName: "ListShipments",
+			Handler:    _OrderService_ListShipments_Handler,
+		},
+		{
+			MethodName: "RequestReturn",
+			Handler:    _OrderService_RequestReturn_Handler,
+		},
+		{
+			MethodName: "ListReturns",
+			Handler:    _OrderService_ListReturns_Handler,
+		},
+		{
+			MethodName: "ApproveReturn",
+			Handler:    _OrderService_ApproveReturn_Handler,
+		},
+		{
+			MethodName: "RejectReturn",
+			Handler:    _OrderService_RejectReturn_Handler,
+		},
+		{
+			MethodName: "ReceiveReturn",
+			Handler:    _OrderService_ReceiveReturn_Handler,
+		},
+		{
+			MethodName: "QuoteShipping",
+			Handler:    _OrderService_QuoteShipping_Handler,
+		},
+		{
+			MethodName: "AddOrderItem",
+			Handler:    _OrderService_AddOrderItem_Handler,
+		},
+		{
+			MethodName: "UpdateOrderItemQuantity",
+			Handler:    _OrderService_UpdateOrderItemQuantity_Handler,
+		},
+		{
+			MethodName: "RemoveOrderItem",
+			Handler:    _OrderService_RemoveOrderItem_Handler,
+		},
+		{
+			MethodName: "GetInvoice",
+			Handler:    _OrderService_GetInvoice_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportOrders",
+			Handler:       _OrderService_ExportOrders_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportOrders",
+			Handler:       _OrderService_ImportOrders_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "order.proto",
 }
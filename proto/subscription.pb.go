@@ -0,0 +1,923 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: subscription.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SubscriptionInterval int32
+
+const (
+	SubscriptionInterval_SUBSCRIPTION_INTERVAL_UNSPECIFIED SubscriptionInterval = 0
+	SubscriptionInterval_SUBSCRIPTION_INTERVAL_DAY         SubscriptionInterval = 1
+	SubscriptionInterval_SUBSCRIPTION_INTERVAL_WEEK        SubscriptionInterval = 2
+	SubscriptionInterval_SUBSCRIPTION_INTERVAL_MONTH       SubscriptionInterval = 3 // Runs on the same day of the month, or the last day of shorter months
+)
+
+// Enum value maps for SubscriptionInterval.
+var (
+	SubscriptionInterval_name = map[int32]string{
+		0: "SUBSCRIPTION_INTERVAL_UNSPECIFIED",
+		1: "SUBSCRIPTION_INTERVAL_DAY",
+		2: "SUBSCRIPTION_INTERVAL_WEEK",
+		3: "SUBSCRIPTION_INTERVAL_MONTH",
+	}
+	SubscriptionInterval_value = map[string]int32{
+		"SUBSCRIPTION_INTERVAL_UNSPECIFIED": 0,
+		"SUBSCRIPTION_INTERVAL_DAY":         1,
+		"SUBSCRIPTION_INTERVAL_WEEK":        2,
+		"SUBSCRIPTION_INTERVAL_MONTH":       3,
+	}
+)
+
+func (x SubscriptionInterval) Enum() *SubscriptionInterval {
+	p := new(SubscriptionInterval)
+	*p = x
+	return p
+}
+
+func (x SubscriptionInterval) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubscriptionInterval) Descriptor() protoreflect.EnumDescriptor {
+	return file_subscription_proto_enumTypes[0].Descriptor()
+}
+
+func (SubscriptionInterval) Type() protoreflect.EnumType {
+	return &file_subscription_proto_enumTypes[0]
+}
+
+func (x SubscriptionInterval) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubscriptionInterval.Descriptor instead.
+func (SubscriptionInterval) EnumDescriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{0}
+}
+
+type SubscriptionStatus int32
+
+const (
+	SubscriptionStatus_SUBSCRIPTION_STATUS_UNSPECIFIED SubscriptionStatus = 0
+	SubscriptionStatus_SUBSCRIPTION_STATUS_ACTIVE      SubscriptionStatus = 1
+	SubscriptionStatus_SUBSCRIPTION_STATUS_PAUSED      SubscriptionStatus = 2
+	SubscriptionStatus_SUBSCRIPTION_STATUS_CANCELLED   SubscriptionStatus = 3
+)
+
+// Enum value maps for SubscriptionStatus.
+var (
+	SubscriptionStatus_name = map[int32]string{
+		0: "SUBSCRIPTION_STATUS_UNSPECIFIED",
+		1: "SUBSCRIPTION_STATUS_ACTIVE",
+		2: "SUBSCRIPTION_STATUS_PAUSED",
+		3: "SUBSCRIPTION_STATUS_CANCELLED",
+	}
+	SubscriptionStatus_value = map[string]int32{
+		"SUBSCRIPTION_STATUS_UNSPECIFIED": 0,
+		"SUBSCRIPTION_STATUS_ACTIVE":      1,
+		"SUBSCRIPTION_STATUS_PAUSED":      2,
+		"SUBSCRIPTION_STATUS_CANCELLED":   3,
+	}
+)
+
+func (x SubscriptionStatus) Enum() *SubscriptionStatus {
+	p := new(SubscriptionStatus)
+	*p = x
+	return p
+}
+
+func (x SubscriptionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubscriptionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_subscription_proto_enumTypes[1].Descriptor()
+}
+
+func (SubscriptionStatus) Type() protoreflect.EnumType {
+	return &file_subscription_proto_enumTypes[1]
+}
+
+func (x SubscriptionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubscriptionStatus.Descriptor instead.
+func (SubscriptionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{1}
+}
+
+type Subscription struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items           []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"` // Priced from the catalog on every run
+	CouponCodes     []string               `protobuf:"bytes,4,rep,name=coupon_codes,json=couponCodes,proto3" json:"coupon_codes,omitempty"`
+	ShippingAddress *Address               `protobuf:"bytes,5,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	ShippingOption  string                 `protobuf:"bytes,6,opt,name=shipping_option,json=shippingOption,proto3" json:"shipping_option,omitempty"`
+	Interval        SubscriptionInterval   `protobuf:"varint,7,opt,name=interval,proto3,enum=proto.SubscriptionInterval" json:"interval,omitempty"`
+	IntervalCount   int32                  `protobuf:"varint,8,opt,name=interval_count,json=intervalCount,proto3" json:"interval_count,omitempty"` // Runs every interval_count intervals, e.g. every 2 weeks
+	Status          SubscriptionStatus     `protobuf:"varint,9,opt,name=status,proto3,enum=proto.SubscriptionStatus" json:"status,omitempty"`
+	NextRunAt       string                 `protobuf:"bytes,10,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`               // When the next cycle's order is placed
+	FailedAttempts  int32                  `protobuf:"varint,11,opt,name=failed_attempts,json=failedAttempts,proto3" json:"failed_attempts,omitempty"` // Failed attempts at the current cycle
+	RetryAt         string                 `protobuf:"bytes,12,opt,name=retry_at,json=retryAt,proto3" json:"retry_at,omitempty"`                       // Set while a failed cycle waits to be retried
+	LastError       string                 `protobuf:"bytes,13,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	Runs            []*SubscriptionRun     `protobuf:"bytes,14,rep,name=runs,proto3" json:"runs,omitempty"` // Most recent first
+	CreatedAt       string                 `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       string                 `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	mi := &file_subscription_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscription) ProtoMessage() {}
+
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Subscription) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Subscription) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Subscription) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Subscription) GetCouponCodes() []string {
+	if x != nil {
+		return x.CouponCodes
+	}
+	return nil
+}
+
+func (x *Subscription) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *Subscription) GetShippingOption() string {
+	if x != nil {
+		return x.ShippingOption
+	}
+	return ""
+}
+
+func (x *Subscription) GetInterval() SubscriptionInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return SubscriptionInterval_SUBSCRIPTION_INTERVAL_UNSPECIFIED
+}
+
+func (x *Subscription) GetIntervalCount() int32 {
+	if x != nil {
+		return x.IntervalCount
+	}
+	return 0
+}
+
+func (x *Subscription) GetStatus() SubscriptionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SubscriptionStatus_SUBSCRIPTION_STATUS_UNSPECIFIED
+}
+
+func (x *Subscription) GetNextRunAt() string {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return ""
+}
+
+func (x *Subscription) GetFailedAttempts() int32 {
+	if x != nil {
+		return x.FailedAttempts
+	}
+	return 0
+}
+
+func (x *Subscription) GetRetryAt() string {
+	if x != nil {
+		return x.RetryAt
+	}
+	return ""
+}
+
+func (x *Subscription) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Subscription) GetRuns() []*SubscriptionRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+func (x *Subscription) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Subscription) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// SubscriptionRun is one attempt at placing the order of a cycle, or a skipped cycle.
+type SubscriptionRun struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledFor  string                 `protobuf:"bytes,1,opt,name=scheduled_for,json=scheduledFor,proto3" json:"scheduled_for,omitempty"`
+	RanAt         string                 `protobuf:"bytes,2,opt,name=ran_at,json=ranAt,proto3" json:"ran_at,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // Set when the order was placed
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                    // Set when the attempt failed
+	Skipped       bool                   `protobuf:"varint,5,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscriptionRun) Reset() {
+	*x = SubscriptionRun{}
+	mi := &file_subscription_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscriptionRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionRun) ProtoMessage() {}
+
+func (x *SubscriptionRun) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionRun.ProtoReflect.Descriptor instead.
+func (*SubscriptionRun) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubscriptionRun) GetScheduledFor() string {
+	if x != nil {
+		return x.ScheduledFor
+	}
+	return ""
+}
+
+func (x *SubscriptionRun) GetRanAt() string {
+	if x != nil {
+		return x.RanAt
+	}
+	return ""
+}
+
+func (x *SubscriptionRun) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *SubscriptionRun) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SubscriptionRun) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+type CreateSubscriptionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items           []*OrderItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	CouponCodes     []string               `protobuf:"bytes,3,rep,name=coupon_codes,json=couponCodes,proto3" json:"coupon_codes,omitempty"`
+	ShippingAddress *Address               `protobuf:"bytes,4,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	ShippingOption  string                 `protobuf:"bytes,5,opt,name=shipping_option,json=shippingOption,proto3" json:"shipping_option,omitempty"`
+	Interval        SubscriptionInterval   `protobuf:"varint,6,opt,name=interval,proto3,enum=proto.SubscriptionInterval" json:"interval,omitempty"`
+	IntervalCount   int32                  `protobuf:"varint,7,opt,name=interval_count,json=intervalCount,proto3" json:"interval_count,omitempty"` // Defaults to 1
+	FirstRunAt      string                 `protobuf:"bytes,8,opt,name=first_run_at,json=firstRunAt,proto3" json:"first_run_at,omitempty"`         // RFC 3339; defaults to now
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateSubscriptionRequest) Reset() {
+	*x = CreateSubscriptionRequest{}
+	mi := &file_subscription_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubscriptionRequest) ProtoMessage() {}
+
+func (x *CreateSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateSubscriptionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateSubscriptionRequest) GetCouponCodes() []string {
+	if x != nil {
+		return x.CouponCodes
+	}
+	return nil
+}
+
+func (x *CreateSubscriptionRequest) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *CreateSubscriptionRequest) GetShippingOption() string {
+	if x != nil {
+		return x.ShippingOption
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetInterval() SubscriptionInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return SubscriptionInterval_SUBSCRIPTION_INTERVAL_UNSPECIFIED
+}
+
+func (x *CreateSubscriptionRequest) GetIntervalCount() int32 {
+	if x != nil {
+		return x.IntervalCount
+	}
+	return 0
+}
+
+func (x *CreateSubscriptionRequest) GetFirstRunAt() string {
+	if x != nil {
+		return x.FirstRunAt
+	}
+	return ""
+}
+
+type GetSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubscriptionRequest) Reset() {
+	*x = GetSubscriptionRequest{}
+	mi := &file_subscription_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubscriptionRequest) ProtoMessage() {}
+
+func (x *GetSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*GetSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetSubscriptionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// UpdateSubscriptionRequest replaces the items, coupons, shipping and cadence of a
+// subscription.
+type UpdateSubscriptionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Items           []*OrderItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	CouponCodes     []string               `protobuf:"bytes,3,rep,name=coupon_codes,json=couponCodes,proto3" json:"coupon_codes,omitempty"`
+	ShippingAddress *Address               `protobuf:"bytes,4,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	ShippingOption  string                 `protobuf:"bytes,5,opt,name=shipping_option,json=shippingOption,proto3" json:"shipping_option,omitempty"`
+	Interval        SubscriptionInterval   `protobuf:"varint,6,opt,name=interval,proto3,enum=proto.SubscriptionInterval" json:"interval,omitempty"`
+	IntervalCount   int32                  `protobuf:"varint,7,opt,name=interval_count,json=intervalCount,proto3" json:"interval_count,omitempty"` // Defaults to 1
+	NextRunAt       string                 `protobuf:"bytes,8,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`            // RFC 3339; reschedules the next cycle when set
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateSubscriptionRequest) Reset() {
+	*x = UpdateSubscriptionRequest{}
+	mi := &file_subscription_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSubscriptionRequest) ProtoMessage() {}
+
+func (x *UpdateSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateSubscriptionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateSubscriptionRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *UpdateSubscriptionRequest) GetCouponCodes() []string {
+	if x != nil {
+		return x.CouponCodes
+	}
+	return nil
+}
+
+func (x *UpdateSubscriptionRequest) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *UpdateSubscriptionRequest) GetShippingOption() string {
+	if x != nil {
+		return x.ShippingOption
+	}
+	return ""
+}
+
+func (x *UpdateSubscriptionRequest) GetInterval() SubscriptionInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return SubscriptionInterval_SUBSCRIPTION_INTERVAL_UNSPECIFIED
+}
+
+func (x *UpdateSubscriptionRequest) GetIntervalCount() int32 {
+	if x != nil {
+		return x.IntervalCount
+	}
+	return 0
+}
+
+func (x *UpdateSubscriptionRequest) GetNextRunAt() string {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return ""
+}
+
+type ListSubscriptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        SubscriptionStatus     `protobuf:"varint,2,opt,name=status,proto3,enum=proto.SubscriptionStatus" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`           // next_page_token of the previous page
+	IncludeTotal  bool                   `protobuf:"varint,6,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"` // Count the matching subscriptions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionsRequest) Reset() {
+	*x = ListSubscriptionsRequest{}
+	mi := &file_subscription_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionsRequest) ProtoMessage() {}
+
+func (x *ListSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListSubscriptionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListSubscriptionsRequest) GetStatus() SubscriptionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SubscriptionStatus_SUBSCRIPTION_STATUS_UNSPECIFIED
+}
+
+func (x *ListSubscriptionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSubscriptionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListSubscriptionsRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
+type ListSubscriptionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscriptions []*Subscription        `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`                                       // Only set when counted, see ListSubscriptionsRequest
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionsResponse) Reset() {
+	*x = ListSubscriptionsResponse{}
+	mi := &file_subscription_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionsResponse) ProtoMessage() {}
+
+func (x *ListSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_subscription_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListSubscriptionsResponse) GetSubscriptions() []*Subscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+func (x *ListSubscriptionsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListSubscriptionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_subscription_proto protoreflect.FileDescriptor
+
+const file_subscription_proto_rawDesc = "" +
+	"\n" +
+	"\x12subscription.proto\x12\x05proto\x1a\vorder.proto\"\xe6\x04\n" +
+	"\fSubscription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12&\n" +
+	"\x05items\x18\x03 \x03(\v2\x10.proto.OrderItemR\x05items\x12!\n" +
+	"\fcoupon_codes\x18\x04 \x03(\tR\vcouponCodes\x129\n" +
+	"\x10shipping_address\x18\x05 \x01(\v2\x0e.proto.AddressR\x0fshippingAddress\x12'\n" +
+	"\x0fshipping_option\x18\x06 \x01(\tR\x0eshippingOption\x127\n" +
+	"\binterval\x18\a \x01(\x0e2\x1b.proto.SubscriptionIntervalR\binterval\x12%\n" +
+	"\x0einterval_count\x18\b \x01(\x05R\rintervalCount\x121\n" +
+	"\x06status\x18\t \x01(\x0e2\x19.proto.SubscriptionStatusR\x06status\x12\x1e\n" +
+	"\vnext_run_at\x18\n" +
+	" \x01(\tR\tnextRunAt\x12'\n" +
+	"\x0ffailed_attempts\x18\v \x01(\x05R\x0efailedAttempts\x12\x19\n" +
+	"\bretry_at\x18\f \x01(\tR\aretryAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\r \x01(\tR\tlastError\x12*\n" +
+	"\x04runs\x18\x0e \x03(\v2\x16.proto.SubscriptionRunR\x04runs\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\tR\tupdatedAt\"\x98\x01\n" +
+	"\x0fSubscriptionRun\x12#\n" +
+	"\rscheduled_for\x18\x01 \x01(\tR\fscheduledFor\x12\x15\n" +
+	"\x06ran_at\x18\x02 \x01(\tR\x05ranAt\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x18\n" +
+	"\askipped\x18\x05 \x01(\bR\askipped\"\xe5\x02\n" +
+	"\x19CreateSubscriptionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
+	"\x05items\x18\x02 \x03(\v2\x10.proto.OrderItemR\x05items\x12!\n" +
+	"\fcoupon_codes\x18\x03 \x03(\tR\vcouponCodes\x129\n" +
+	"\x10shipping_address\x18\x04 \x01(\v2\x0e.proto.AddressR\x0fshippingAddress\x12'\n" +
+	"\x0fshipping_option\x18\x05 \x01(\tR\x0eshippingOption\x127\n" +
+	"\binterval\x18\x06 \x01(\x0e2\x1b.proto.SubscriptionIntervalR\binterval\x12%\n" +
+	"\x0einterval_count\x18\a \x01(\x05R\rintervalCount\x12 \n" +
+	"\ffirst_run_at\x18\b \x01(\tR\n" +
+	"firstRunAt\"(\n" +
+	"\x16GetSubscriptionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xda\x02\n" +
+	"\x19UpdateSubscriptionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x05items\x18\x02 \x03(\v2\x10.proto.OrderItemR\x05items\x12!\n" +
+	"\fcoupon_codes\x18\x03 \x03(\tR\vcouponCodes\x129\n" +
+	"\x10shipping_address\x18\x04 \x01(\v2\x0e.proto.AddressR\x0fshippingAddress\x12'\n" +
+	"\x0fshipping_option\x18\x05 \x01(\tR\x0eshippingOption\x127\n" +
+	"\binterval\x18\x06 \x01(\x0e2\x1b.proto.SubscriptionIntervalR\binterval\x12%\n" +
+	"\x0einterval_count\x18\a \x01(\x05R\rintervalCount\x12\x1e\n" +
+	"\vnext_run_at\x18\b \x01(\tR\tnextRunAt\"\xc6\x01\n" +
+	"\x18ListSubscriptionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x121\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x19.proto.SubscriptionStatusR\x06status\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\x12#\n" +
+	"\rinclude_total\x18\x06 \x01(\bR\fincludeTotalJ\x04\b\x03\x10\x04\"\x94\x01\n" +
+	"\x19ListSubscriptionsResponse\x129\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2\x13.proto.SubscriptionR\rsubscriptions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken*\x9d\x01\n" +
+	"\x14SubscriptionInterval\x12%\n" +
+	"!SUBSCRIPTION_INTERVAL_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19SUBSCRIPTION_INTERVAL_DAY\x10\x01\x12\x1e\n" +
+	"\x1aSUBSCRIPTION_INTERVAL_WEEK\x10\x02\x12\x1f\n" +
+	"\x1bSUBSCRIPTION_INTERVAL_MONTH\x10\x03*\x9c\x01\n" +
+	"\x12SubscriptionStatus\x12#\n" +
+	"\x1fSUBSCRIPTION_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aSUBSCRIPTION_STATUS_ACTIVE\x10\x01\x12\x1e\n" +
+	"\x1aSUBSCRIPTION_STATUS_PAUSED\x10\x02\x12!\n" +
+	"\x1dSUBSCRIPTION_STATUS_CANCELLED\x10\x032\x83\x05\n" +
+	"\x13SubscriptionService\x12M\n" +
+	"\x12CreateSubscription\x12 .proto.CreateSubscriptionRequest\x1a\x13.proto.Subscription\"\x00\x12G\n" +
+	"\x0fGetSubscription\x12\x1d.proto.GetSubscriptionRequest\x1a\x13.proto.Subscription\"\x00\x12M\n" +
+	"\x12UpdateSubscription\x12 .proto.UpdateSubscriptionRequest\x1a\x13.proto.Subscription\"\x00\x12X\n" +
+	"\x11ListSubscriptions\x12\x1f.proto.ListSubscriptionsRequest\x1a .proto.ListSubscriptionsResponse\"\x00\x12I\n" +
+	"\x11PauseSubscription\x12\x1d.proto.GetSubscriptionRequest\x1a\x13.proto.Subscription\"\x00\x12J\n" +
+	"\x12ResumeSubscription\x12\x1d.proto.GetSubscriptionRequest\x1a\x13.proto.Subscription\"\x00\x12H\n" +
+	"\x10SkipSubscription\x12\x1d.proto.GetSubscriptionRequest\x1a\x13.proto.Subscription\"\x00\x12J\n" +
+	"\x12CancelSubscription\x12\x1d.proto.GetSubscriptionRequest\x1a\x13.proto.Subscription\"\x00B#Z!github.com/order-management/protob\x06proto3"
+
+var (
+	file_subscription_proto_rawDescOnce sync.Once
+	file_subscription_proto_rawDescData []byte
+)
+
+func file_subscription_proto_rawDescGZIP() []byte {
+	file_subscription_proto_rawDescOnce.Do(func() {
+		file_subscription_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_subscription_proto_rawDesc), len(file_subscription_proto_rawDesc)))
+	})
+	return file_subscription_proto_rawDescData
+}
+
+var file_subscription_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_subscription_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_subscription_proto_goTypes = []any{
+	(SubscriptionInterval)(0),         // 0: proto.SubscriptionInterval
+	(SubscriptionStatus)(0),           // 1: proto.SubscriptionStatus
+	(*Subscription)(nil),              // 2: proto.Subscription
+	(*SubscriptionRun)(nil),           // 3: proto.SubscriptionRun
+	(*CreateSubscriptionRequest)(nil), // 4: proto.CreateSubscriptionRequest
+	(*GetSubscriptionRequest)(nil),    // 5: proto.GetSubscriptionRequest
+	(*UpdateSubscriptionRequest)(nil), // 6: proto.UpdateSubscriptionRequest
+	(*ListSubscriptionsRequest)(nil),  // 7: proto.ListSubscriptionsRequest
+	(*ListSubscriptionsResponse)(nil), // 8: proto.ListSubscriptionsResponse
+	(*OrderItem)(nil),                 // 9: proto.OrderItem
+	(*Address)(nil),                   // 10: proto.Address
+}
+var file_subscription_proto_depIdxs = []int32{
+	9,  // 0: proto.Subscription.items:type_name -> proto.OrderItem
+	10, // 1: proto.Subscription.shipping_address:type_name -> proto.Address
+	0,  // 2: proto.Subscription.interval:type_name -> proto.SubscriptionInterval
+	1,  // 3: proto.Subscription.status:type_name -> proto.SubscriptionStatus
+	3,  // 4: proto.Subscription.runs:type_name -> proto.SubscriptionRun
+	9,  // 5: proto.CreateSubscriptionRequest.items:type_name -> proto.OrderItem
+	10, // 6: proto.CreateSubscriptionRequest.shipping_address:type_name -> proto.Address
+	0,  // 7: proto.CreateSubscriptionRequest.interval:type_name -> proto.SubscriptionInterval
+	9,  // 8: proto.UpdateSubscriptionRequest.items:type_name -> proto.OrderItem
+	10, // 9: proto.UpdateSubscriptionRequest.shipping_address:type_name -> proto.Address
+	0,  // 10: proto.UpdateSubscriptionRequest.interval:type_name -> proto.SubscriptionInterval
+	1,  // 11: proto.ListSubscriptionsRequest.status:type_name -> proto.SubscriptionStatus
+	2,  // 12: proto.ListSubscriptionsResponse.subscriptions:type_name -> proto.Subscription
+	4,  // 13: proto.SubscriptionService.CreateSubscription:input_type -> proto.CreateSubscriptionRequest
+	5,  // 14: proto.SubscriptionService.GetSubscription:input_type -> proto.GetSubscriptionRequest
+	6,  // 15: proto.SubscriptionService.UpdateSubscription:input_type -> proto.UpdateSubscriptionRequest
+	7,  // 16: proto.SubscriptionService.ListSubscriptions:input_type -> proto.ListSubscriptionsRequest
+	5,  // 17: proto.SubscriptionService.PauseSubscription:input_type -> proto.GetSubscriptionRequest
+	5,  // 18: proto.SubscriptionService.ResumeSubscription:input_type -> proto.GetSubscriptionRequest
+	5,  // 19: proto.SubscriptionService.SkipSubscription:input_type -> proto.GetSubscriptionRequest
+	5,  // 20: proto.SubscriptionService.CancelSubscription:input_type -> proto.GetSubscriptionRequest
+	2,  // 21: proto.SubscriptionService.CreateSubscription:output_type -> proto.Subscription
+	2,  // 22: proto.SubscriptionService.GetSubscription:output_type -> proto.Subscription
+	2,  // 23: proto.SubscriptionService.UpdateSubscription:output_type -> proto.Subscription
+	8,  // 24: proto.SubscriptionService.ListSubscriptions:output_type -> proto.ListSubscriptionsResponse
+	2,  // 25: proto.SubscriptionService.PauseSubscription:output_type -> proto.Subscription
+	2,  // 26: proto.SubscriptionService.ResumeSubscription:output_type -> proto.Subscription
+	2,  // 27: proto.SubscriptionService.SkipSubscription:output_type -> proto.Subscription
+	2,  // 28: proto.SubscriptionService.CancelSubscription:output_type -> proto.Subscription
+	21, // [21:29] is the sub-list for method output_type
+	13, // [13:21] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_subscription_proto_init() }
+func file_subscription_proto_init() {
+	if File_subscription_proto != nil {
+		return
+	}
+	file_order_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_subscription_proto_rawDesc), len(file_subscription_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_subscription_proto_goTypes,
+		DependencyIndexes: file_subscription_proto_depIdxs,
+		EnumInfos:         file_subscription_proto_enumTypes,
+		MessageInfos:      file_subscription_proto_msgTypes,
+	}.Build()
+	File_subscription_proto = out.File
+	file_subscription_proto_goTypes = nil
+	file_subscription_proto_depIdxs = nil
+}
@@ -51,8 +51,8 @@ echo -e "\nCreating product..."
 PRODUCT_RESPONSE=$(curl -s -X POST $API_URL/products -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{
   "name": "Test Product",
   "description": "A test product",
-  "price": 99.99,
-  "stock": 100
+  "price": {"units_minor": 9999, "currency": "USD"},
+  "stock_quantity": 100
 }')
 PRODUCT_ID=$(echo $PRODUCT_RESPONSE | grep -o '"id":"[^"]*' | cut -d'"' -f4)
 
@@ -84,8 +84,7 @@ ORDER_RESPONSE=$(curl -s -X POST $API_URL/orders -H "Content-Type: application/j
   "items": [
     {
       "product_id": "'$PRODUCT_ID'",
-      "quantity": 2,
-      "price": 99.99
+      "quantity": 2
     }
   ]
 }')
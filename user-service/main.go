@@ -65,6 +65,18 @@ func main() {
 		log.Fatalf("Failed to create index: %v", err)
 	}
 
+	// Create indexes for listing users page by page
+	_, err = client.Database("order_management").Collection("users").Indexes().CreateMany(
+		context.Background(),
+		[]mongo.IndexModel{
+			{Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+			{Keys: bson.D{{Key: "role", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+		},
+	)
+	if err != nil {
+		log.Fatalf("Failed to create index: %v", err)
+	}
+
 	// Initialize gRPC server
 	lis, err := net.Listen("tcp", ":50053") // Different port from other services
 	if err != nil {
@@ -247,9 +259,6 @@ func (s *server) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb
 
 func (s *server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
 	// Set default values for pagination
-	if req.Page < 1 {
-		req.Page = 1
-	}
 	if req.Limit < 1 || req.Limit > 100 {
 		req.Limit = 10
 	}
@@ -260,22 +269,35 @@ func (s *server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.L
 		filter["role"] = req.Role
 	}
 
-	// Calculate skip value for pagination
-	skip := (req.Page - 1) * req.Limit
+	// Get total count, only when asked for or for clients paging by page number
+	var total int64
+	var err error
+	if req.IncludeTotal || (req.PageToken == "" && req.Page > 0) {
+		total, err = s.db.Collection("users").CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to count users: %v", err)
+		}
+	}
 
-	// Get total count
-	total, err := s.db.Collection("users").CountDocuments(ctx, filter)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to count users: %v", err)
+	// Continue after the page token, or skip to the requested page. One user more
+	// than the limit is read to tell whether there is a next page.
+	query := "role=" + req.Role
+	findOptions := options.Find().
+		SetLimit(int64(req.Limit) + 1).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if req.PageToken != "" {
+		token, err := decodePageToken(req.PageToken, query)
+		if err != nil {
+			return nil, err
+		}
+		filter = bson.M{"$and": bson.A{filter,
+			afterFilter("created_at", -1, primitive.DateTime(token.Value), token.ID)}}
+	} else if req.Page > 1 {
+		findOptions.SetSkip(int64((req.Page - 1) * req.Limit))
 	}
 
 	// Find users
-	cursor, err := s.db.Collection("users").Find(ctx, filter,
-		options.Find().
-			SetSkip(int64(skip)).
-			SetLimit(int64(req.Limit)).
-			SetSort(bson.M{"created_at": -1}),
-	)
+	cursor, err := s.db.Collection("users").Find(ctx, filter, findOptions)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
 	}
@@ -283,11 +305,19 @@ func (s *server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.L
 
 	// Process results
 	var users []*pb.User
+	var last userModel
+	var nextPageToken string
 	for cursor.Next(ctx) {
+		if len(users) == int(req.Limit) {
+			nextPageToken = encodePageToken(query, int64(primitive.NewDateTimeFromTime(last.CreatedAt)), last.ID)
+			break
+		}
+
 		var user userModel
 		if err := cursor.Decode(&user); err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to decode user: %v", err)
 		}
+		last = user
 
 		users = append(users, &pb.User{
 			Id:        user.ID.Hex(),
@@ -303,8 +333,9 @@ func (s *server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.L
 	}
 
 	return &pb.ListUsersResponse{
-		Users: users,
-		Total: int32(total),
+		Users:         users,
+		Total:         int32(total),
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
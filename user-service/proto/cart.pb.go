@@ -0,0 +1,791 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: cart.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Cart lists its items with the current catalog price and stock. Prices are only
+// fixed when the cart is checked out.
+type Cart struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId           string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Empty for anonymous carts
+	Items            []*CartItem            `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Subtotal         *Money                 `protobuf:"bytes,4,opt,name=subtotal,proto3" json:"subtotal,omitempty"`                                            // Items at current prices, before promotions, taxes and shipping
+	ReadyForCheckout bool                   `protobuf:"varint,5,opt,name=ready_for_checkout,json=readyForCheckout,proto3" json:"ready_for_checkout,omitempty"` // The cart has items and none of them has a problem
+	ExpiresAt        string                 `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                         // Anonymous carts expire when left unchanged for a while
+	CreatedAt        string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Cart) Reset() {
+	*x = Cart{}
+	mi := &file_cart_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cart) ProtoMessage() {}
+
+func (x *Cart) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cart.ProtoReflect.Descriptor instead.
+func (*Cart) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Cart) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Cart) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Cart) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Cart) GetSubtotal() *Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *Cart) GetReadyForCheckout() bool {
+	if x != nil {
+		return x.ReadyForCheckout
+	}
+	return false
+}
+
+func (x *Cart) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Cart) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Cart) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type CartItem struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ProductId           string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity            int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Name                string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	UnitPrice           *Money                 `protobuf:"bytes,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	LineTotal           *Money                 `protobuf:"bytes,5,opt,name=line_total,json=lineTotal,proto3" json:"line_total,omitempty"`
+	StockQuantity       int32                  `protobuf:"varint,6,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	BackorderedQuantity int32                  `protobuf:"varint,7,opt,name=backordered_quantity,json=backorderedQuantity,proto3" json:"backordered_quantity,omitempty"` // Units that would be backordered if checked out now
+	Problem             string                 `protobuf:"bytes,8,opt,name=problem,proto3" json:"problem,omitempty"`                                                     // Why the item cannot be ordered now, if it cannot
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CartItem) Reset() {
+	*x = CartItem{}
+	mi := &file_cart_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartItem) ProtoMessage() {}
+
+func (x *CartItem) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartItem.ProtoReflect.Descriptor instead.
+func (*CartItem) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CartItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CartItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CartItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CartItem) GetUnitPrice() *Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *CartItem) GetLineTotal() *Money {
+	if x != nil {
+		return x.LineTotal
+	}
+	return nil
+}
+
+func (x *CartItem) GetStockQuantity() int32 {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return 0
+}
+
+func (x *CartItem) GetBackorderedQuantity() int32 {
+	if x != nil {
+		return x.BackorderedQuantity
+	}
+	return 0
+}
+
+func (x *CartItem) GetProblem() string {
+	if x != nil {
+		return x.Problem
+	}
+	return ""
+}
+
+type CreateCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Returns the user's cart; without it a new anonymous cart is created
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCartRequest) Reset() {
+	*x = CreateCartRequest{}
+	mi := &file_cart_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCartRequest) ProtoMessage() {}
+
+func (x *CreateCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCartRequest.ProtoReflect.Descriptor instead.
+func (*CreateCartRequest) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateCartRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CartId        string                 `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCartRequest) Reset() {
+	*x = GetCartRequest{}
+	mi := &file_cart_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCartRequest) ProtoMessage() {}
+
+func (x *GetCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCartRequest.ProtoReflect.Descriptor instead.
+func (*GetCartRequest) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCartRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *GetCartRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type AddCartItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CartId        string                 `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"` // Added to the quantity already in the cart
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCartItemRequest) Reset() {
+	*x = AddCartItemRequest{}
+	mi := &file_cart_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCartItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCartItemRequest) ProtoMessage() {}
+
+func (x *AddCartItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCartItemRequest.ProtoReflect.Descriptor instead.
+func (*AddCartItemRequest) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AddCartItemRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *AddCartItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddCartItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AddCartItemRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type UpdateCartItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CartId        string                 `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCartItemRequest) Reset() {
+	*x = UpdateCartItemRequest{}
+	mi := &file_cart_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCartItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCartItemRequest) ProtoMessage() {}
+
+func (x *UpdateCartItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCartItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCartItemRequest) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateCartItemRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *UpdateCartItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateCartItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UpdateCartItemRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type RemoveCartItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CartId        string                 `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCartItemRequest) Reset() {
+	*x = RemoveCartItemRequest{}
+	mi := &file_cart_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCartItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCartItemRequest) ProtoMessage() {}
+
+func (x *RemoveCartItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCartItemRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCartItemRequest) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RemoveCartItemRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *RemoveCartItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemoveCartItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+// MergeCartsRequest moves the items of an anonymous cart into a user's cart and
+// deletes the anonymous cart. Quantities of products in both are added up.
+type MergeCartsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CartId        string                 `protobuf:"bytes,1,opt,name=cart_id,json=cartId,proto3" json:"cart_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCartsRequest) Reset() {
+	*x = MergeCartsRequest{}
+	mi := &file_cart_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCartsRequest) ProtoMessage() {}
+
+func (x *MergeCartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCartsRequest.ProtoReflect.Descriptor instead.
+func (*MergeCartsRequest) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MergeCartsRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *MergeCartsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// CheckoutCartRequest places an order for the items of a user's cart, which are then
+// removed from the cart.
+type CheckoutCartRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CouponCodes     []string               `protobuf:"bytes,2,rep,name=coupon_codes,json=couponCodes,proto3" json:"coupon_codes,omitempty"`
+	ShippingAddress *Address               `protobuf:"bytes,3,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	ShippingOption  string                 `protobuf:"bytes,4,opt,name=shipping_option,json=shippingOption,proto3" json:"shipping_option,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CheckoutCartRequest) Reset() {
+	*x = CheckoutCartRequest{}
+	mi := &file_cart_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutCartRequest) ProtoMessage() {}
+
+func (x *CheckoutCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutCartRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutCartRequest) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CheckoutCartRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CheckoutCartRequest) GetCouponCodes() []string {
+	if x != nil {
+		return x.CouponCodes
+	}
+	return nil
+}
+
+func (x *CheckoutCartRequest) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *CheckoutCartRequest) GetShippingOption() string {
+	if x != nil {
+		return x.ShippingOption
+	}
+	return ""
+}
+
+var File_cart_proto protoreflect.FileDescriptor
+
+const file_cart_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"cart.proto\x12\x05proto\x1a\vmoney.proto\x1a\vorder.proto\"\x8b\x02\n" +
+	"\x04Cart\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12%\n" +
+	"\x05items\x18\x03 \x03(\v2\x0f.proto.CartItemR\x05items\x12(\n" +
+	"\bsubtotal\x18\x04 \x01(\v2\f.proto.MoneyR\bsubtotal\x12,\n" +
+	"\x12ready_for_checkout\x18\x05 \x01(\bR\x10readyForCheckout\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\tR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\"\xa7\x02\n" +
+	"\bCartItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12+\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\v2\f.proto.MoneyR\tunitPrice\x12+\n" +
+	"\n" +
+	"line_total\x18\x05 \x01(\v2\f.proto.MoneyR\tlineTotal\x12%\n" +
+	"\x0estock_quantity\x18\x06 \x01(\x05R\rstockQuantity\x121\n" +
+	"\x14backordered_quantity\x18\a \x01(\x05R\x13backorderedQuantity\x12\x18\n" +
+	"\aproblem\x18\b \x01(\tR\aproblem\",\n" +
+	"\x11CreateCartRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"B\n" +
+	"\x0eGetCartRequest\x12\x17\n" +
+	"\acart_id\x18\x01 \x01(\tR\x06cartId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x81\x01\n" +
+	"\x12AddCartItemRequest\x12\x17\n" +
+	"\acart_id\x18\x01 \x01(\tR\x06cartId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\"\x84\x01\n" +
+	"\x15UpdateCartItemRequest\x12\x17\n" +
+	"\acart_id\x18\x01 \x01(\tR\x06cartId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\"h\n" +
+	"\x15RemoveCartItemRequest\x12\x17\n" +
+	"\acart_id\x18\x01 \x01(\tR\x06cartId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\"E\n" +
+	"\x11MergeCartsRequest\x12\x17\n" +
+	"\acart_id\x18\x01 \x01(\tR\x06cartId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xb5\x01\n" +
+	"\x13CheckoutCartRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fcoupon_codes\x18\x02 \x03(\tR\vcouponCodes\x129\n" +
+	"\x10shipping_address\x18\x03 \x01(\v2\x0e.proto.AddressR\x0fshippingAddress\x12'\n" +
+	"\x0fshipping_option\x18\x04 \x01(\tR\x0eshippingOption2\x9f\x03\n" +
+	"\vCartService\x125\n" +
+	"\n" +
+	"CreateCart\x12\x18.proto.CreateCartRequest\x1a\v.proto.Cart\"\x00\x12/\n" +
+	"\aGetCart\x12\x15.proto.GetCartRequest\x1a\v.proto.Cart\"\x00\x127\n" +
+	"\vAddCartItem\x12\x19.proto.AddCartItemRequest\x1a\v.proto.Cart\"\x00\x12=\n" +
+	"\x0eUpdateCartItem\x12\x1c.proto.UpdateCartItemRequest\x1a\v.proto.Cart\"\x00\x12=\n" +
+	"\x0eRemoveCartItem\x12\x1c.proto.RemoveCartItemRequest\x1a\v.proto.Cart\"\x00\x125\n" +
+	"\n" +
+	"MergeCarts\x12\x18.proto.MergeCartsRequest\x1a\v.proto.Cart\"\x00\x12:\n" +
+	"\fCheckoutCart\x12\x1a.proto.CheckoutCartRequest\x1a\f.proto.Order\"\x00B#Z!github.com/order-management/protob\x06proto3"
+
+var (
+	file_cart_proto_rawDescOnce sync.Once
+	file_cart_proto_rawDescData []byte
+)
+
+func file_cart_proto_rawDescGZIP() []byte {
+	file_cart_proto_rawDescOnce.Do(func() {
+		file_cart_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cart_proto_rawDesc), len(file_cart_proto_rawDesc)))
+	})
+	return file_cart_proto_rawDescData
+}
+
+var file_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_cart_proto_goTypes = []any{
+	(*Cart)(nil),                  // 0: proto.Cart
+	(*CartItem)(nil),              // 1: proto.CartItem
+	(*CreateCartRequest)(nil),     // 2: proto.CreateCartRequest
+	(*GetCartRequest)(nil),        // 3: proto.GetCartRequest
+	(*AddCartItemRequest)(nil),    // 4: proto.AddCartItemRequest
+	(*UpdateCartItemRequest)(nil), // 5: proto.UpdateCartItemRequest
+	(*RemoveCartItemRequest)(nil), // 6: proto.RemoveCartItemRequest
+	(*MergeCartsRequest)(nil),     // 7: proto.MergeCartsRequest
+	(*CheckoutCartRequest)(nil),   // 8: proto.CheckoutCartRequest
+	(*Money)(nil),                 // 9: proto.Money
+	(*Address)(nil),               // 10: proto.Address
+	(*Order)(nil),                 // 11: proto.Order
+}
+var file_cart_proto_depIdxs = []int32{
+	1,  // 0: proto.Cart.items:type_name -> proto.CartItem
+	9,  // 1: proto.Cart.subtotal:type_name -> proto.Money
+	9,  // 2: proto.CartItem.unit_price:type_name -> proto.Money
+	9,  // 3: proto.CartItem.line_total:type_name -> proto.Money
+	10, // 4: proto.CheckoutCartRequest.shipping_address:type_name -> proto.Address
+	2,  // 5: proto.CartService.CreateCart:input_type -> proto.CreateCartRequest
+	3,  // 6: proto.CartService.GetCart:input_type -> proto.GetCartRequest
+	4,  // 7: proto.CartService.AddCartItem:input_type -> proto.AddCartItemRequest
+	5,  // 8: proto.CartService.UpdateCartItem:input_type -> proto.UpdateCartItemRequest
+	6,  // 9: proto.CartService.RemoveCartItem:input_type -> proto.RemoveCartItemRequest
+	7,  // 10: proto.CartService.MergeCarts:input_type -> proto.MergeCartsRequest
+	8,  // 11: proto.CartService.CheckoutCart:input_type -> proto.CheckoutCartRequest
+	0,  // 12: proto.CartService.CreateCart:output_type -> proto.Cart
+	0,  // 13: proto.CartService.GetCart:output_type -> proto.Cart
+	0,  // 14: proto.CartService.AddCartItem:output_type -> proto.Cart
+	0,  // 15: proto.CartService.UpdateCartItem:output_type -> proto.Cart
+	0,  // 16: proto.CartService.RemoveCartItem:output_type -> proto.Cart
+	0,  // 17: proto.CartService.MergeCarts:output_type -> proto.Cart
+	11, // 18: proto.CartService.CheckoutCart:output_type -> proto.Order
+	12, // [12:19] is the sub-list for method output_type
+	5,  // [5:12] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_cart_proto_init() }
+func file_cart_proto_init() {
+	if File_cart_proto != nil {
+		return
+	}
+	file_money_proto_init()
+	file_order_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cart_proto_rawDesc), len(file_cart_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cart_proto_goTypes,
+		DependencyIndexes: file_cart_proto_depIdxs,
+		MessageInfos:      file_cart_proto_msgTypes,
+	}.Build()
+	File_cart_proto = out.File
+	file_cart_proto_goTypes = nil
+	file_cart_proto_depIdxs = nil
+}
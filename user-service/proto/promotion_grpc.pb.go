@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v5.29.3
+// source: promotion.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PromotionService_CreatePromotion_FullMethodName = "/proto.PromotionService/CreatePromotion"
+	PromotionService_GetPromotion_FullMethodName    = "/proto.PromotionService/GetPromotion"
+	PromotionService_UpdatePromotion_FullMethodName = "/proto.PromotionService/UpdatePromotion"
+	PromotionService_ListPromotions_FullMethodName  = "/proto.PromotionService/ListPromotions"
+	PromotionService_DeletePromotion_FullMethodName = "/proto.PromotionService/DeletePromotion"
+)
+
+// PromotionServiceClient is the client API for PromotionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PromotionServiceClient interface {
+	CreatePromotion(ctx context.Context, in *Promotion, opts ...grpc.CallOption) (*Promotion, error)
+	GetPromotion(ctx context.Context, in *GetPromotionRequest, opts ...grpc.CallOption) (*Promotion, error)
+	UpdatePromotion(ctx context.Context, in *Promotion, opts ...grpc.CallOption) (*Promotion, error)
+	ListPromotions(ctx context.Context, in *ListPromotionsRequest, opts ...grpc.CallOption) (*ListPromotionsResponse, error)
+	DeletePromotion(ctx context.Context, in *DeletePromotionRequest, opts ...grpc.CallOption) (*DeletePromotionResponse, error)
+}
+
+type promotionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPromotionServiceClient(cc grpc.ClientConnInterface) PromotionServiceClient {
+	return &promotionServiceClient{cc}
+}
+
+func (c *promotionServiceClient) CreatePromotion(ctx context.Context, in *Promotion, opts ...grpc.CallOption) (*Promotion, error) {
+	out := new(Promotion)
+	err := c.cc.Invoke(ctx, PromotionService_CreatePromotion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promotionServiceClient) GetPromotion(ctx context.Context, in *GetPromotionRequest, opts ...grpc.CallOption) (*Promotion, error) {
+	out := new(Promotion)
+	err := c.cc.Invoke(ctx, PromotionService_GetPromotion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promotionServiceClient) UpdatePromotion(ctx context.Context, in *Promotion, opts ...grpc.CallOption) (*Promotion, error) {
+	out := new(Promotion)
+	err := c.cc.Invoke(ctx, PromotionService_UpdatePromotion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promotionServiceClient) ListPromotions(ctx context.Context, in *ListPromotionsRequest, opts ...grpc.CallOption) (*ListPromotionsResponse, error) {
+	out := new(ListPromotionsResponse)
+	err := c.cc.Invoke(ctx, PromotionService_ListPromotions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promotionServiceClient) DeletePromotion(ctx context.Context, in *DeletePromotionRequest, opts ...grpc.CallOption) (*DeletePromotionResponse, error) {
+	out := new(DeletePromotionResponse)
+	err := c.cc.Invoke(ctx, PromotionService_DeletePromotion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PromotionServiceServer is the server API for PromotionService service.
+// All implementations must embed UnimplementedPromotionServiceServer
+// for forward compatibility
+type PromotionServiceServer interface {
+	CreatePromotion(context.Context, *Promotion) (*Promotion, error)
+	GetPromotion(context.Context, *GetPromotionRequest) (*Promotion, error)
+	UpdatePromotion(context.Context, *Promotion) (*Promotion, error)
+	ListPromotions(context.Context, *ListPromotionsRequest) (*ListPromotionsResponse, error)
+	DeletePromotion(context.Context, *DeletePromotionRequest) (*DeletePromotionResponse, error)
+	mustEmbedUnimplementedPromotionServiceServer()
+}
+
+// UnimplementedPromotionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPromotionServiceServer struct {
+}
+
+func (UnimplementedPromotionServiceServer) CreatePromotion(context.Context, *Promotion) (*Promotion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePromotion not implemented")
+}
+func (UnimplementedPromotionServiceServer) GetPromotion(context.Context, *GetPromotionRequest) (*Promotion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPromotion not implemented")
+}
+func (UnimplementedPromotionServiceServer) UpdatePromotion(context.Context, *Promotion) (*Promotion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePromotion not implemented")
+}
+func (UnimplementedPromotionServiceServer) ListPromotions(context.Context, *ListPromotionsRequest) (*ListPromotionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPromotions not implemented")
+}
+func (UnimplementedPromotionServiceServer) DeletePromotion(context.Context, *DeletePromotionRequest) (*DeletePromotionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePromotion not implemented")
+}
+func (UnimplementedPromotionServiceServer) mustEmbedUnimplementedPromotionServiceServer() {}
+
+// UnsafePromotionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PromotionServiceServer will
+// result in compilation errors.
+type UnsafePromotionServiceServer interface {
+	mustEmbedUnimplementedPromotionServiceServer()
+}
+
+func RegisterPromotionServiceServer(s grpc.ServiceRegistrar, srv PromotionServiceServer) {
+	s.RegisterService(&PromotionService_ServiceDesc, srv)
+}
+
+func _PromotionService_CreatePromotion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Promotion)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromotionServiceServer).CreatePromotion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromotionService_CreatePromotion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromotionServiceServer).CreatePromotion(ctx, req.(*Promotion))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromotionService_GetPromotion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPromotionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromotionServiceServer).GetPromotion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromotionService_GetPromotion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromotionServiceServer).GetPromotion(ctx, req.(*GetPromotionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromotionService_UpdatePromotion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Promotion)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromotionServiceServer).UpdatePromotion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromotionService_UpdatePromotion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromotionServiceServer).UpdatePromotion(ctx, req.(*Promotion))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromotionService_ListPromotions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPromotionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromotionServiceServer).ListPromotions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromotionService_ListPromotions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromotionServiceServer).ListPromotions(ctx, req.(*ListPromotionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromotionService_DeletePromotion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePromotionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromotionServiceServer).DeletePromotion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromotionService_DeletePromotion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromotionServiceServer).DeletePromotion(ctx, req.(*DeletePromotionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PromotionService_ServiceDesc is the grpc.ServiceDesc for PromotionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PromotionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.PromotionService",
+	HandlerType: (*PromotionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePromotion",
+			Handler:    _PromotionService_CreatePromotion_Handler,
+		},
+		{
+			MethodName: "GetPromotion",
+			Handler:    _PromotionService_GetPromotion_Handler,
+		},
+		{
+			MethodName: "UpdatePromotion",
+			Handler:    _PromotionService_UpdatePromotion_Handler,
+		},
+		{
+			MethodName: "ListPromotions",
+			Handler:    _PromotionService_ListPromotions_Handler,
+		},
+		{
+			MethodName: "DeletePromotion",
+			Handler:    _PromotionService_DeletePromotion_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "promotion.proto",
+}